@@ -1,14 +1,27 @@
 package http
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/moura95/go-ddd/internal/infra/cfg"
 	"github.com/moura95/go-ddd/internal/infra/http/gin"
+	"github.com/moura95/go-ddd/internal/infra/tracing"
 	"go.uber.org/zap"
 )
 
 func RunGinServer(cfg cfg.Config, store *sqlx.DB, log *zap.SugaredLogger) {
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Invalid tracing config: %s", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Errorf("Failed Flush Traces %s", err.Error())
+		}
+	}()
+
 	server := gin.NewServer(cfg, store, log)
 
 	_ = server.Start(cfg.HTTPServerAddress)
@@ -2,20 +2,74 @@ package aggregate
 
 import (
 	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/event"
 	"github.com/moura95/go-ddd/internal/domain/vehicle"
-	"time"
 )
 
+// DriverVehicleAggregate is a driver together with the vehicles it is
+// currently assigned to. Vehicles is empty, never nil, when there are none.
 type DriverVehicleAggregate struct {
-	Uuid          uuid.UUID
-	Name          string
-	Email         string
-	TaxID         string
-	DriverLicense string
-	DateOfBirth   sql.NullString
-	DeletedAt     sql.NullString
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Vehicles      []vehicle.Vehicle
+	Uuid            uuid.UUID
+	Name            string
+	Email           string
+	TaxID           string
+	DriverLicense   string
+	LicenseCategory sql.NullString
+	DateOfBirth     sql.NullString
+	DeletedAt       sql.NullString
+	Version         int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Vehicles        []vehicle.Vehicle
+
+	event.Recorder
+}
+
+// DriverAggregateType identifies driver events in the outbox.
+const DriverAggregateType = "driver"
+
+// Events raised by the driver aggregate.
+const (
+	EventDriverCreated     = "DriverCreated"
+	EventDriverUpdated     = "DriverUpdated"
+	EventDriverSoftDeleted = "DriverSoftDeleted"
+	EventDriverRestored    = "DriverRestored"
+	EventDriverDeleted     = "DriverDeleted"
+)
+
+type driverPayload struct {
+	Uuid            uuid.UUID   `json:"uuid"`
+	Name            string      `json:"name"`
+	Email           string      `json:"email"`
+	TaxID           string      `json:"tax_id"`
+	DriverLicense   string      `json:"driver_license"`
+	LicenseCategory string      `json:"license_category"`
+	DateOfBirth     string      `json:"date_of_birth"`
+	DeletedAt       string      `json:"deleted_at"`
+	Version         int         `json:"version"`
+	Vehicles        []uuid.UUID `json:"vehicles"`
+}
+
+// Raise records one of the driver events with the aggregate's current state
+// as its payload.
+func (a *DriverVehicleAggregate) Raise(name string) {
+	vehicles := make([]uuid.UUID, 0, len(a.Vehicles))
+	for _, v := range a.Vehicles {
+		vehicles = append(vehicles, v.Uuid)
+	}
+	a.Record(event.New(name, DriverAggregateType, a.Uuid, driverPayload{
+		Uuid:            a.Uuid,
+		Name:            a.Name,
+		Email:           a.Email,
+		TaxID:           a.TaxID,
+		DriverLicense:   a.DriverLicense,
+		LicenseCategory: a.LicenseCategory.String,
+		DateOfBirth:     a.DateOfBirth.String,
+		DeletedAt:       a.DeletedAt.String,
+		Version:         a.Version,
+		Vehicles:        vehicles,
+	}))
 }
@@ -0,0 +1,125 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/moura95/go-ddd/internal/domain/user"
+)
+
+// KeyPrefix starts every key, so leaked keys are easy to spot and scan for.
+const KeyPrefix = "gddd_"
+
+const MaxNameLength = 100
+
+// APIKey lets an integration call the API without logging in. Only the hash
+// of the key is stored; Prefix is its public start, shown in listings to
+// tell keys apart. The key is granted Scopes and nothing else, on behalf of
+// the user who created it and within that user's tenant.
+type APIKey struct {
+	Uuid       uuid.UUID
+	TenantID   uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []user.Permission
+	CreatedBy  uuid.UUID
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// New validates the key's settings and returns it with the plain key, which
+// is shown to the caller once and not kept anywhere.
+func New(name string, scopes []string, expiresAt time.Time, createdBy uuid.UUID) (*APIKey, string, error) {
+	var fields domainerr.Fields
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxNameLength {
+		fields.Add("name", ErrInvalidName)
+	}
+	parsed := make([]user.Permission, 0, len(scopes))
+	for _, raw := range scopes {
+		perm, err := user.NewPermission(raw)
+		if err != nil {
+			fields.Add("scopes", user.ErrUnknownPermission)
+			break
+		}
+		parsed = append(parsed, perm)
+	}
+	if len(scopes) == 0 {
+		fields.Add("scopes", ErrNoScopes)
+	}
+	now := time.Now()
+	if !expiresAt.After(now) {
+		fields.Add("expires_at", ErrInvalidExpiry)
+	}
+	if err := fields.Err(); err != nil {
+		return nil, "", err
+	}
+
+	k := &APIKey{
+		Uuid:      uuid.New(),
+		Name:      name,
+		Scopes:    parsed,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	plain, err := k.generate()
+	if err != nil {
+		return nil, "", err
+	}
+	return k, plain, nil
+}
+
+// Rotate returns the successor of k: the same name and scopes under a new
+// key, valid for as long as k was.
+func (k *APIKey) Rotate(rotatedBy uuid.UUID) (*APIKey, string, error) {
+	now := time.Now()
+	next := &APIKey{
+		Uuid:      uuid.New(),
+		TenantID:  k.TenantID,
+		Name:      k.Name,
+		Scopes:    append([]user.Permission(nil), k.Scopes...),
+		CreatedBy: rotatedBy,
+		ExpiresAt: now.Add(k.ExpiresAt.Sub(k.CreatedAt)),
+		CreatedAt: now,
+	}
+	plain, err := next.generate()
+	if err != nil {
+		return nil, "", err
+	}
+	return next, plain, nil
+}
+
+// generate sets the prefix and hash of a new "gddd_<id>_<secret>" key.
+func (k *APIKey) generate() (string, error) {
+	b := make([]byte, 36)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	k.Prefix = KeyPrefix + hex.EncodeToString(b[:4])
+	plain := k.Prefix + "_" + hex.EncodeToString(b[4:])
+	k.KeyHash = Hash(plain)
+	return plain, nil
+}
+
+// Hash is the lookup key stored for a plain key.
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+func (k *APIKey) Expired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
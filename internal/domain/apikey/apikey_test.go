@@ -0,0 +1,52 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssuesAPrefixedKey(t *testing.T) {
+	creator := uuid.New()
+	k, plain, err := New(" telematics ", []string{"driver:read", "assignment:write"}, time.Now().Add(time.Hour), creator)
+	assert.NoError(t, err)
+	assert.Equal(t, "telematics", k.Name)
+	assert.True(t, strings.HasPrefix(plain, k.Prefix+"_"))
+	assert.True(t, strings.HasPrefix(k.Prefix, KeyPrefix))
+	assert.Equal(t, Hash(plain), k.KeyHash)
+	assert.NotContains(t, k.KeyHash, plain)
+	assert.Equal(t, []user.Permission{user.PermDriverRead, user.PermAssignmentWrite}, k.Scopes)
+	assert.Equal(t, creator, k.CreatedBy)
+	assert.False(t, k.Revoked())
+	assert.False(t, k.Expired(time.Now()))
+}
+
+func TestNewValidates(t *testing.T) {
+	_, _, err := New("", nil, time.Now().Add(-time.Hour), uuid.Nil)
+	assert.ErrorIs(t, err, ErrInvalidName)
+	assert.ErrorIs(t, err, ErrNoScopes)
+	assert.ErrorIs(t, err, ErrInvalidExpiry)
+
+	_, _, err = New("erp", []string{"driver:everything"}, time.Now().Add(time.Hour), uuid.Nil)
+	assert.ErrorIs(t, err, user.ErrUnknownPermission)
+}
+
+func TestRotateKeepsNameScopesAndLifetime(t *testing.T) {
+	k, plain, err := New("erp", []string{"vehicle:read"}, time.Now().Add(24*time.Hour), uuid.New())
+	assert.NoError(t, err)
+	rotator := uuid.New()
+
+	next, nextPlain, err := k.Rotate(rotator)
+	assert.NoError(t, err)
+	assert.NotEqual(t, k.Uuid, next.Uuid)
+	assert.NotEqual(t, plain, nextPlain)
+	assert.Equal(t, Hash(nextPlain), next.KeyHash)
+	assert.Equal(t, k.Name, next.Name)
+	assert.Equal(t, k.Scopes, next.Scopes)
+	assert.Equal(t, rotator, next.CreatedBy)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), next.ExpiresAt, time.Second)
+}
@@ -0,0 +1,19 @@
+package apikey
+
+import "github.com/moura95/go-ddd/internal/domain/domainerr"
+
+var (
+	ErrNotFound = domainerr.NotFound("api_key_not_found", "api key not found")
+	ErrRevoked  = domainerr.Conflict("api_key_revoked", "api key has been revoked")
+
+	ErrInvalidName   = domainerr.Validation("invalid_name", "name is required and must have at most 100 characters")
+	ErrNoScopes      = domainerr.Validation("no_scopes", "at least one scope is required")
+	ErrInvalidExpiry = domainerr.Validation("invalid_expiry", "expiry must be in the future")
+
+	// ErrScopeNotHeld keeps callers from issuing keys more powerful than
+	// themselves.
+	ErrScopeNotHeld = domainerr.Forbidden("scope_not_held", "you cannot grant a scope you do not hold")
+	// ErrInvalidKey does not say whether the key is unknown, expired or
+	// revoked.
+	ErrInvalidKey = domainerr.Unauthorized("invalid_api_key", "api key is invalid, expired or revoked")
+)
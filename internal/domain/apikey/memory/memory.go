@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/apikey"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+)
+
+type IAPIKeyRepositoryMemory interface {
+	apikey.IAPIKeyRepository
+	Snapshot() (restore func())
+}
+
+type apiKeyRepositoryMemory struct {
+	keys []apikey.APIKey
+}
+
+func NewAPIKeyRepositoryMemory() IAPIKeyRepositoryMemory {
+	return &apiKeyRepositoryMemory{}
+}
+
+func (m *apiKeyRepositoryMemory) Create(ctx context.Context, k *apikey.APIKey) error {
+	m.keys = append(m.keys, *k)
+	return nil
+}
+
+func (m *apiKeyRepositoryMemory) GetByID(ctx context.Context, uid uuid.UUID) (*apikey.APIKey, error) {
+	for _, k := range m.keys {
+		if k.Uuid == uid {
+			return &k, nil
+		}
+	}
+	return nil, apikey.ErrNotFound
+}
+
+func (m *apiKeyRepositoryMemory) GetByHash(ctx context.Context, hash string) (*apikey.APIKey, error) {
+	for _, k := range m.keys {
+		if k.KeyHash == hash {
+			return &k, nil
+		}
+	}
+	return nil, apikey.ErrNotFound
+}
+
+func (m *apiKeyRepositoryMemory) GetAll(ctx context.Context, filter dto.ListInput) ([]apikey.APIKey, int, error) {
+	filter.Normalize()
+	newest := make([]apikey.APIKey, 0, len(m.keys))
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if m.keys[i].TenantID == filter.TenantID {
+			newest = append(newest, m.keys[i])
+		}
+	}
+	start, end := filter.Window(len(newest))
+	return newest[start:end], len(newest), nil
+}
+
+func (m *apiKeyRepositoryMemory) Revoke(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	for i := range m.keys {
+		if m.keys[i].Uuid != uid {
+			continue
+		}
+		if m.keys[i].Revoked() {
+			return apikey.ErrRevoked
+		}
+		m.keys[i].RevokedAt = at
+		return nil
+	}
+	return apikey.ErrNotFound
+}
+
+func (m *apiKeyRepositoryMemory) TouchLastUsed(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	for i := range m.keys {
+		if m.keys[i].Uuid == uid {
+			m.keys[i].LastUsedAt = at
+			return nil
+		}
+	}
+	return apikey.ErrNotFound
+}
+
+func (m *apiKeyRepositoryMemory) Snapshot() func() {
+	saved := append([]apikey.APIKey(nil), m.keys...)
+	return func() { m.keys = saved }
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/moura95/go-ddd/internal/domain/apikey"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type apiKeyRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewAPIKeyRepository(db *sqlx.DB, log *zap.SugaredLogger) apikey.IAPIKeyRepository {
+	return &apiKeyRepository{db: db, logger: log}
+}
+
+func (r *apiKeyRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const apiKeyColumns = "uuid, tenant_id, name, prefix, key_hash, scopes, created_by, expires_at, last_used_at, revoked_at, created_at"
+
+type apiKeyRow struct {
+	Uuid       uuid.UUID      `db:"uuid"`
+	TenantID   uuid.UUID      `db:"tenant_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	KeyHash    string         `db:"key_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	CreatedBy  uuid.NullUUID  `db:"created_by"`
+	ExpiresAt  time.Time      `db:"expires_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (row apiKeyRow) toAPIKey() apikey.APIKey {
+	scopes := make([]user.Permission, len(row.Scopes))
+	for i, scope := range row.Scopes {
+		scopes[i] = user.Permission(scope)
+	}
+	return apikey.APIKey{
+		Uuid:       row.Uuid,
+		TenantID:   row.TenantID,
+		Name:       row.Name,
+		Prefix:     row.Prefix,
+		KeyHash:    row.KeyHash,
+		Scopes:     scopes,
+		CreatedBy:  row.CreatedBy.UUID,
+		ExpiresAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt.Time,
+		RevokedAt:  row.RevokedAt.Time,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, k *apikey.APIKey) error {
+	scopes := make(pq.StringArray, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+	query := `
+        INSERT INTO api_keys (uuid, tenant_id, name, prefix, key_hash, scopes, created_by, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+	createdBy := uuid.NullUUID{UUID: k.CreatedBy, Valid: k.CreatedBy != uuid.Nil}
+	_, err := r.conn(ctx).ExecContext(ctx, query, k.Uuid, k.TenantID, k.Name, k.Prefix, k.KeyHash, scopes, createdBy, k.ExpiresAt, k.CreatedAt)
+	return err
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, uid uuid.UUID) (*apikey.APIKey, error) {
+	return r.get(ctx, "uuid", uid)
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*apikey.APIKey, error) {
+	return r.get(ctx, "key_hash", hash)
+}
+
+func (r *apiKeyRepository) get(ctx context.Context, column string, value interface{}) (*apikey.APIKey, error) {
+	var row apiKeyRow
+	err := r.conn(ctx).GetContext(ctx, &row, "SELECT "+apiKeyColumns+" FROM api_keys WHERE "+column+" = $1", value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apikey.ErrNotFound
+		}
+		return nil, err
+	}
+	k := row.toAPIKey()
+	return &k, nil
+}
+
+func (r *apiKeyRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]apikey.APIKey, int, error) {
+	filter.Normalize()
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM api_keys WHERE tenant_id = $1", filter.TenantID); err != nil {
+		return []apikey.APIKey{}, 0, err
+	}
+	var rows []apiKeyRow
+	query := "SELECT " + apiKeyColumns + " FROM api_keys WHERE tenant_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3"
+	if err := r.conn(ctx).SelectContext(ctx, &rows, query, filter.TenantID, filter.PageSize, filter.Offset()); err != nil {
+		return []apikey.APIKey{}, 0, err
+	}
+	keys := make([]apikey.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = row.toAPIKey()
+	}
+	return keys, total, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	res, err := r.conn(ctx).ExecContext(ctx, "UPDATE api_keys SET revoked_at = $2 WHERE uuid = $1 AND revoked_at IS NULL", uid, at)
+	if err != nil {
+		return err
+	}
+	if err := database.ExpectAffected(res, apikey.ErrRevoked); err != nil {
+		// tell a revoked key from a missing one
+		if _, getErr := r.GetByID(ctx, uid); getErr != nil {
+			return getErr
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "UPDATE api_keys SET last_used_at = $2 WHERE uuid = $1", uid, at)
+	return err
+}
@@ -0,0 +1,21 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+)
+
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, k *APIKey) error
+	GetByID(ctx context.Context, uid uuid.UUID) (*APIKey, error)
+	// GetByHash returns the key stored under hash, expired or revoked or not.
+	GetByHash(ctx context.Context, hash string) (*APIKey, error)
+	// GetAll lists keys newest first, revoked ones included.
+	GetAll(ctx context.Context, filter dto.ListInput) ([]APIKey, int, error)
+	// Revoke returns ErrRevoked when the key already was.
+	Revoke(ctx context.Context, uid uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, uid uuid.UUID, at time.Time) error
+}
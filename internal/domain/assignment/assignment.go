@@ -0,0 +1,71 @@
+package assignment
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/moura95/go-ddd/internal/domain/event"
+)
+
+const MaxNotesLength = 1000
+
+// Assignment records a driver being put in charge of a vehicle. Assignments
+// are never deleted: ending one sets UnassignedAt so the history is kept.
+type Assignment struct {
+	Uuid         uuid.UUID
+	DriverUUID   uuid.UUID
+	VehicleUUID  uuid.UUID
+	Primary      bool
+	AssignedAt   time.Time
+	UnassignedAt sql.NullTime
+	AssignedBy   string
+	Notes        string
+
+	event.Recorder
+}
+
+func NewAssignment(driverUUID, vehicleUUID uuid.UUID, primary bool, assignedBy, notes string) (*Assignment, error) {
+	a := &Assignment{
+		Uuid:        uuid.New(),
+		DriverUUID:  driverUUID,
+		VehicleUUID: vehicleUUID,
+		Primary:     primary,
+		AssignedAt:  time.Now(),
+		AssignedBy:  strings.TrimSpace(assignedBy),
+		Notes:       strings.TrimSpace(notes),
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Assignment) Validate() error {
+	var fields domainerr.Fields
+	if a.DriverUUID == uuid.Nil {
+		fields.Add("driver_uuid", ErrInvalidDriver)
+	}
+	if a.VehicleUUID == uuid.Nil {
+		fields.Add("vehicle_uuid", ErrInvalidVehicle)
+	}
+	if len(a.Notes) > MaxNotesLength {
+		fields.Add("notes", ErrNotesTooLong)
+	}
+	return fields.Err()
+}
+
+func (a *Assignment) Active() bool {
+	return !a.UnassignedAt.Valid
+}
+
+// Unassign ends the assignment at the given time.
+func (a *Assignment) Unassign(at time.Time) error {
+	if !a.Active() {
+		return ErrAlreadyUnassigned
+	}
+	a.UnassignedAt = sql.NullTime{Time: at, Valid: true}
+	return nil
+}
@@ -0,0 +1,40 @@
+package assignment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestNewAssignment(t *testing.T) {
+	driverUUID, vehicleUUID := uuid.New(), uuid.New()
+	a, err := NewAssignment(driverUUID, vehicleUUID, true, " dispatcher ", " night shift ")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, a.DriverUUID, driverUUID)
+	assert.Equal(t, a.VehicleUUID, vehicleUUID)
+	assert.Equal(t, a.AssignedBy, "dispatcher")
+	assert.Equal(t, a.Notes, "night shift")
+	assert.Equal(t, a.Primary, true)
+	assert.Equal(t, a.Active(), true)
+}
+
+func TestNewAssignmentInvalid(t *testing.T) {
+	a, err := NewAssignment(uuid.Nil, uuid.Nil, false, "", strings.Repeat("x", MaxNotesLength+1))
+	assert.Equal(t, a == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidDriver), true)
+	assert.Equal(t, errors.Is(err, ErrInvalidVehicle), true)
+	assert.Equal(t, errors.Is(err, ErrNotesTooLong), true)
+}
+
+func TestUnassign(t *testing.T) {
+	a, _ := NewAssignment(uuid.New(), uuid.New(), false, "", "")
+	at := time.Now()
+	assert.Equal(t, a.Unassign(at), nil)
+	assert.Equal(t, a.Active(), false)
+	assert.Equal(t, a.UnassignedAt.Time, at)
+	assert.Equal(t, errors.Is(a.Unassign(at), ErrAlreadyUnassigned), true)
+}
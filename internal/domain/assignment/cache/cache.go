@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/assignment"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	infracache "github.com/moura95/go-ddd/internal/infra/cache"
+	"github.com/moura95/go-ddd/internal/infra/database"
+)
+
+// assignmentRepository caches nothing itself. It retires the cached driver
+// aggregates whenever an assignment starts or ends, since those list the
+// driver's current vehicles.
+type assignmentRepository struct {
+	assignment.IAssignmentRepository
+	cache *infracache.Cache
+}
+
+func NewAssignmentRepository(inner assignment.IAssignmentRepository, c *infracache.Cache) assignment.IAssignmentRepository {
+	return &assignmentRepository{IAssignmentRepository: inner, cache: c}
+}
+
+func (r *assignmentRepository) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	out, err := r.IAssignmentRepository.Create(ctx, input)
+	if err == nil {
+		r.invalidate(ctx)
+	}
+	return out, err
+}
+
+func (r *assignmentRepository) Unassign(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	err := r.IAssignmentRepository.Unassign(ctx, uid, at)
+	if err == nil {
+		r.invalidate(ctx)
+	}
+	return err
+}
+
+func (r *assignmentRepository) invalidate(ctx context.Context) {
+	bump := func() { r.cache.Bump(ctx, infracache.DriverRelations) }
+	bump()
+	database.AfterCommit(ctx, bump)
+}
@@ -0,0 +1,21 @@
+package assignment
+
+import "github.com/moura95/go-ddd/internal/domain/domainerr"
+
+var (
+	ErrNotFound          = domainerr.NotFound("assignment_not_found", "assignment not found")
+	ErrActiveNotFound    = domainerr.NotFound("active_assignment_not_found", "driver is not assigned to this vehicle")
+	ErrAlreadyAssigned   = domainerr.Conflict("driver_already_assigned", "driver is already assigned to this vehicle")
+	ErrAlreadyUnassigned = domainerr.Conflict("assignment_already_ended", "assignment has already ended")
+	ErrDriverNotFound    = domainerr.RelationNotFound("related_driver_not_found", "driver referenced by the assignment does not exist")
+	ErrVehicleNotFound   = domainerr.RelationNotFound("related_vehicle_not_found", "vehicle referenced by the assignment does not exist")
+
+	ErrPrimaryDriverTaken      = domainerr.Conflict("primary_driver_taken", "vehicle already has an active primary driver")
+	ErrDriverDeleted           = domainerr.Validation("driver_deleted", "deleted drivers cannot be assigned")
+	ErrVehicleDeleted          = domainerr.Validation("vehicle_deleted", "deleted vehicles cannot be assigned")
+	ErrLicenseCategoryMismatch = domainerr.Validation("license_category_mismatch", "driver license category does not cover the vehicle category")
+
+	ErrInvalidDriver  = domainerr.Validation("invalid_driver_uuid", "invalid driver uuid")
+	ErrInvalidVehicle = domainerr.Validation("invalid_vehicle_uuid", "invalid vehicle uuid")
+	ErrNotesTooLong   = domainerr.Validation("notes_too_long", "notes must have at most 1000 characters")
+)
@@ -0,0 +1,42 @@
+package assignment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/event"
+)
+
+const AggregateType = "assignment"
+
+const (
+	EventDriverAssigned   = "DriverAssignedToVehicle"
+	EventDriverUnassigned = "DriverUnassignedFromVehicle"
+)
+
+type payload struct {
+	Uuid         uuid.UUID  `json:"uuid"`
+	DriverUUID   uuid.UUID  `json:"driver_uuid"`
+	VehicleUUID  uuid.UUID  `json:"vehicle_uuid"`
+	Primary      bool       `json:"primary"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+	AssignedBy   string     `json:"assigned_by"`
+}
+
+// Raise records one of the assignment events with the assignment's current
+// state as its payload.
+func (a *Assignment) Raise(name string) {
+	p := payload{
+		Uuid:        a.Uuid,
+		DriverUUID:  a.DriverUUID,
+		VehicleUUID: a.VehicleUUID,
+		Primary:     a.Primary,
+		AssignedAt:  a.AssignedAt,
+		AssignedBy:  a.AssignedBy,
+	}
+	if a.UnassignedAt.Valid {
+		p.UnassignedAt = &a.UnassignedAt.Time
+	}
+	a.Record(event.New(name, AggregateType, a.Uuid, p))
+}
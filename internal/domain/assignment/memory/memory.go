@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/assignment"
+	"github.com/moura95/go-ddd/internal/domain/driver"
+	drivermemory "github.com/moura95/go-ddd/internal/domain/driver/memory"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+	vehiclememory "github.com/moura95/go-ddd/internal/domain/vehicle/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+)
+
+type IAssignmentRepositoryMemory interface {
+	assignment.IAssignmentRepository
+	Snapshot() (restore func())
+}
+
+type assignmentRepositoryMemory struct {
+	assignments []assignment.Assignment
+	drivers     drivermemory.IDriverRepositoryMemory
+	vehicles    vehiclememory.IVehicleRepositoryMemory
+}
+
+// NewAssignmentRepositoryMemory keeps assignments in memory and resolves the
+// drivers and vehicles they refer to through the given memory repositories.
+func NewAssignmentRepositoryMemory(drivers drivermemory.IDriverRepositoryMemory, vehicles vehiclememory.IVehicleRepositoryMemory) IAssignmentRepositoryMemory {
+	return &assignmentRepositoryMemory{drivers: drivers, vehicles: vehicles}
+}
+
+func (m *assignmentRepositoryMemory) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	a, err := assignment.NewAssignment(input.DriverUUID, input.VehicleUUID, input.Primary, input.AssignedBy, input.Notes)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.GetDriver(ctx, a.DriverUUID); err != nil {
+		return nil, err
+	}
+	if _, err := m.GetVehicle(ctx, a.VehicleUUID); err != nil {
+		return nil, err
+	}
+	if _, err := m.GetActive(ctx, a.DriverUUID, a.VehicleUUID); err == nil {
+		return nil, assignment.ErrAlreadyAssigned
+	}
+	m.assignments = append(m.assignments, *a)
+	out := toOutput(*a)
+	return &out, nil
+}
+
+func (m *assignmentRepositoryMemory) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	for _, a := range m.assignments {
+		if a.Uuid == uid {
+			out := toOutput(a)
+			return &out, nil
+		}
+	}
+	return nil, assignment.ErrNotFound
+}
+
+func (m *assignmentRepositoryMemory) GetActive(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	for _, a := range m.assignments {
+		if a.DriverUUID == driverUUID && a.VehicleUUID == vehicleUUID && a.Active() {
+			out := toOutput(a)
+			return &out, nil
+		}
+	}
+	return nil, assignment.ErrActiveNotFound
+}
+
+func (m *assignmentRepositoryMemory) GetActivePrimary(ctx context.Context, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	for _, a := range m.assignments {
+		if a.VehicleUUID == vehicleUUID && a.Primary && a.Active() {
+			out := toOutput(a)
+			return &out, nil
+		}
+	}
+	return nil, assignment.ErrActiveNotFound
+}
+
+func (m *assignmentRepositoryMemory) ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]dto.Output, error) {
+	return m.list(func(a assignment.Assignment) bool { return a.DriverUUID == driverUUID }), nil
+}
+
+func (m *assignmentRepositoryMemory) ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]dto.Output, error) {
+	return m.list(func(a assignment.Assignment) bool { return a.VehicleUUID == vehicleUUID }), nil
+}
+
+func (m *assignmentRepositoryMemory) Unassign(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	for i := range m.assignments {
+		if m.assignments[i].Uuid == uid {
+			return m.assignments[i].Unassign(at)
+		}
+	}
+	return assignment.ErrAlreadyUnassigned
+}
+
+func (m *assignmentRepositoryMemory) GetDriver(ctx context.Context, uid uuid.UUID) (*assignment.Driver, error) {
+	d, err := m.drivers.GetByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, driver.ErrNotFound) {
+			return nil, assignment.ErrDriverNotFound
+		}
+		return nil, err
+	}
+	return &assignment.Driver{
+		Uuid:            d.Uuid,
+		LicenseCategory: d.LicenseCategory.String,
+		Deleted:         d.DeletedAt.Valid,
+	}, nil
+}
+
+func (m *assignmentRepositoryMemory) GetVehicle(ctx context.Context, uid uuid.UUID) (*assignment.Vehicle, error) {
+	v, err := m.vehicles.GetByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, vehicle.ErrNotFound) {
+			return nil, assignment.ErrVehicleNotFound
+		}
+		return nil, err
+	}
+	return &assignment.Vehicle{
+		Uuid:     v.Uuid,
+		Category: v.Category.String,
+		Deleted:  v.DeletedAt.Valid,
+	}, nil
+}
+
+func (m *assignmentRepositoryMemory) Snapshot() func() {
+	saved := append([]assignment.Assignment(nil), m.assignments...)
+	return func() { m.assignments = saved }
+}
+
+func (m *assignmentRepositoryMemory) list(match func(assignment.Assignment) bool) []dto.Output {
+	out := []dto.Output{}
+	for _, a := range m.assignments {
+		if match(a) {
+			out = append(out, toOutput(a))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].AssignedAt.After(out[j].AssignedAt)
+	})
+	return out
+}
+
+func toOutput(a assignment.Assignment) dto.Output {
+	return dto.Output{
+		Uuid:         a.Uuid,
+		DriverUUID:   a.DriverUUID,
+		VehicleUUID:  a.VehicleUUID,
+		Primary:      a.Primary,
+		AssignedAt:   a.AssignedAt,
+		UnassignedAt: a.UnassignedAt,
+		AssignedBy:   a.AssignedBy,
+		Notes:        a.Notes,
+	}
+}
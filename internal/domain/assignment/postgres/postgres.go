@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/assignment"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+const assignmentColumns = "uuid, driver_uuid, vehicle_uuid, is_primary, assigned_at, unassigned_at, coalesce(assigned_by, '') AS assigned_by, coalesce(notes, '') AS notes"
+
+type assignmentRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewAssignmentRepository(db *sqlx.DB, log *zap.SugaredLogger) assignment.IAssignmentRepository {
+	return &assignmentRepository{db: db, logger: log}
+}
+
+func (r *assignmentRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+func (r *assignmentRepository) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a, err := assignment.NewAssignment(input.DriverUUID, input.VehicleUUID, input.Primary, input.AssignedBy, input.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO drivers_vehicles (uuid, tenant_id, driver_uuid, vehicle_uuid, is_primary, assigned_at, assigned_by, notes)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	args := []interface{}{
+		a.Uuid,
+		tenantID,
+		a.DriverUUID,
+		a.VehicleUUID,
+		a.Primary,
+		a.AssignedAt,
+		a.AssignedBy,
+		a.Notes,
+	}
+	_, err = r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, assignmentError(err)
+	}
+	if err := r.touchDriver(ctx, a.DriverUUID, tenantID); err != nil {
+		return nil, err
+	}
+
+	return &dto.Output{
+		Uuid:         a.Uuid,
+		DriverUUID:   a.DriverUUID,
+		VehicleUUID:  a.VehicleUUID,
+		Primary:      a.Primary,
+		AssignedAt:   a.AssignedAt,
+		UnassignedAt: a.UnassignedAt,
+		AssignedBy:   a.AssignedBy,
+		Notes:        a.Notes,
+	}, nil
+}
+
+func (r *assignmentRepository) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var a dto.Output
+	err = r.conn(ctx).GetContext(ctx, &a, "SELECT "+assignmentColumns+" FROM drivers_vehicles WHERE uuid = $1 AND tenant_id = $2", uid, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, assignment.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *assignmentRepository) GetActive(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var a dto.Output
+	query := "SELECT " + assignmentColumns + " FROM drivers_vehicles WHERE driver_uuid = $1 AND vehicle_uuid = $2 AND tenant_id = $3 AND unassigned_at IS NULL"
+	err = r.conn(ctx).GetContext(ctx, &a, query, driverUUID, vehicleUUID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, assignment.ErrActiveNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *assignmentRepository) ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments := []dto.Output{}
+	query := "SELECT " + assignmentColumns + " FROM drivers_vehicles WHERE driver_uuid = $1 AND tenant_id = $2 ORDER BY assigned_at DESC"
+	if err := r.conn(ctx).SelectContext(ctx, &assignments, query, driverUUID, tenantID); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *assignmentRepository) ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments := []dto.Output{}
+	query := "SELECT " + assignmentColumns + " FROM drivers_vehicles WHERE vehicle_uuid = $1 AND tenant_id = $2 ORDER BY assigned_at DESC"
+	if err := r.conn(ctx).SelectContext(ctx, &assignments, query, vehicleUUID, tenantID); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *assignmentRepository) Unassign(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	var driverUUID uuid.UUID
+	query := "UPDATE drivers_vehicles SET unassigned_at = $2 WHERE uuid = $1 AND tenant_id = $3 AND unassigned_at IS NULL RETURNING driver_uuid"
+	err = r.conn(ctx).GetContext(ctx, &driverUUID, query, uid, at, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return assignment.ErrAlreadyUnassigned
+		}
+		return err
+	}
+	return r.touchDriver(ctx, driverUUID, tenantID)
+}
+
+// touchDriver bumps the driver's version. A driver's representation lists
+// its current vehicles, so its version, and with it its ETag, has to move
+// whenever an assignment starts or ends.
+func (r *assignmentRepository) touchDriver(ctx context.Context, driverUUID, tenantID uuid.UUID) error {
+	query := "UPDATE drivers SET version = version + 1 WHERE uuid = $1 AND tenant_id = $2"
+	_, err := r.conn(ctx).ExecContext(ctx, query, driverUUID, tenantID)
+	return err
+}
+
+func (r *assignmentRepository) GetActivePrimary(ctx context.Context, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var a dto.Output
+	query := "SELECT " + assignmentColumns + " FROM drivers_vehicles WHERE vehicle_uuid = $1 AND tenant_id = $2 AND is_primary AND unassigned_at IS NULL LIMIT 1"
+	err = r.conn(ctx).GetContext(ctx, &a, query, vehicleUUID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, assignment.ErrActiveNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *assignmentRepository) GetDriver(ctx context.Context, uid uuid.UUID) (*assignment.Driver, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var row struct {
+		Uuid            uuid.UUID `db:"uuid"`
+		LicenseCategory string    `db:"license_category"`
+		Deleted         bool      `db:"deleted"`
+	}
+	query := "SELECT uuid, coalesce(license_category, '') AS license_category, deleted_at IS NOT NULL AS deleted FROM drivers WHERE uuid = $1 AND tenant_id = $2"
+	err = r.conn(ctx).GetContext(ctx, &row, query, uid, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, assignment.ErrDriverNotFound
+		}
+		return nil, err
+	}
+	return &assignment.Driver{Uuid: row.Uuid, LicenseCategory: row.LicenseCategory, Deleted: row.Deleted}, nil
+}
+
+func (r *assignmentRepository) GetVehicle(ctx context.Context, uid uuid.UUID) (*assignment.Vehicle, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var row struct {
+		Uuid     uuid.UUID `db:"uuid"`
+		Category string    `db:"category"`
+		Deleted  bool      `db:"deleted"`
+	}
+	query := "SELECT uuid, coalesce(category, '') AS category, deleted_at IS NOT NULL AS deleted FROM vehicles WHERE uuid = $1 AND tenant_id = $2 FOR UPDATE"
+	err = r.conn(ctx).GetContext(ctx, &row, query, uid, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, assignment.ErrVehicleNotFound
+		}
+		return nil, err
+	}
+	return &assignment.Vehicle{Uuid: row.Uuid, Category: row.Category, Deleted: row.Deleted}, nil
+}
+
+// assignmentError translates constraint violations into domain errors.
+func assignmentError(err error) error {
+	if constraint, ok := database.UniqueViolation(err); ok && constraint == "drivers_vehicles_active_key" {
+		return assignment.ErrAlreadyAssigned
+	}
+	if constraint, ok := database.ForeignKeyViolation(err); ok {
+		switch constraint {
+		case "drivers_vehicles_driver_uuid_fkey":
+			return assignment.ErrDriverNotFound
+		case "drivers_vehicles_vehicle_uuid_fkey":
+			return assignment.ErrVehicleNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,23 @@
+package assignment
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/assignment"
+)
+
+type IAssignmentRepository interface {
+	Create(ctx context.Context, input assignment.CreateInput) (*assignment.Output, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*assignment.Output, error)
+	GetActive(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*assignment.Output, error)
+	ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]assignment.Output, error)
+	ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]assignment.Output, error)
+	Unassign(ctx context.Context, uid uuid.UUID, at time.Time) error
+	GetActivePrimary(ctx context.Context, vehicleUUID uuid.UUID) (*assignment.Output, error)
+	GetDriver(ctx context.Context, uid uuid.UUID) (*Driver, error)
+	// GetVehicle locks the vehicle until the unit of work carried by ctx
+	// ends, so assignments to the same vehicle are checked one at a time.
+	GetVehicle(ctx context.Context, uid uuid.UUID) (*Vehicle, error)
+}
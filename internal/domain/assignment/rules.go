@@ -0,0 +1,69 @@
+package assignment
+
+import (
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/driver"
+)
+
+// Driver is what the assignment rules need to know about a driver.
+type Driver struct {
+	Uuid            uuid.UUID
+	LicenseCategory string
+	Deleted         bool
+}
+
+// Vehicle is what the assignment rules need to know about a vehicle.
+type Vehicle struct {
+	Uuid     uuid.UUID
+	Category string
+	Deleted  bool
+}
+
+// Rules are the invariants checked before a driver is assigned to a vehicle.
+// Each one can be switched off through configuration.
+type Rules struct {
+	SinglePrimaryDriver    bool
+	RejectDeleted          bool
+	RequireLicenseCategory bool
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		SinglePrimaryDriver:    true,
+		RejectDeleted:          true,
+		RequireLicenseCategory: true,
+	}
+}
+
+// Candidate is a proposed assignment together with the state it is checked
+// against. CurrentPrimary is the vehicle's active primary driver, if any.
+type Candidate struct {
+	Driver         Driver
+	Vehicle        Vehicle
+	Primary        bool
+	CurrentPrimary uuid.UUID
+}
+
+// Check returns the first rule the candidate breaks, or nil.
+func (r Rules) Check(c Candidate) error {
+	if r.RejectDeleted {
+		if c.Driver.Deleted {
+			return ErrDriverDeleted
+		}
+		if c.Vehicle.Deleted {
+			return ErrVehicleDeleted
+		}
+	}
+	// vehicles registered before categories existed are not checked
+	if r.RequireLicenseCategory && c.Vehicle.Category != "" {
+		license, _ := driver.NewLicenseCategory(c.Driver.LicenseCategory)
+		if !license.Covers(c.Vehicle.Category) {
+			return ErrLicenseCategoryMismatch
+		}
+	}
+	if r.SinglePrimaryDriver && c.Primary &&
+		c.CurrentPrimary != uuid.Nil && c.CurrentPrimary != c.Driver.Uuid {
+		return ErrPrimaryDriverTaken
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package assignment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func candidate() Candidate {
+	return Candidate{
+		Driver:  Driver{Uuid: uuid.New(), LicenseCategory: "B"},
+		Vehicle: Vehicle{Uuid: uuid.New(), Category: "B"},
+		Primary: true,
+	}
+}
+
+func TestRulesCheck(t *testing.T) {
+	rules := DefaultRules()
+	assert.NoError(t, rules.Check(candidate()))
+
+	c := candidate()
+	c.Driver.Deleted = true
+	assert.ErrorIs(t, rules.Check(c), ErrDriverDeleted)
+
+	c = candidate()
+	c.Vehicle.Deleted = true
+	assert.ErrorIs(t, rules.Check(c), ErrVehicleDeleted)
+
+	c = candidate()
+	c.Vehicle.Category = "C"
+	assert.ErrorIs(t, rules.Check(c), ErrLicenseCategoryMismatch)
+
+	c.Driver.LicenseCategory = ""
+	assert.ErrorIs(t, rules.Check(c), ErrLicenseCategoryMismatch)
+
+	c = candidate()
+	c.Vehicle.Category = ""
+	c.Driver.LicenseCategory = ""
+	assert.NoError(t, rules.Check(c))
+
+	c = candidate()
+	c.CurrentPrimary = uuid.New()
+	assert.ErrorIs(t, rules.Check(c), ErrPrimaryDriverTaken)
+
+	c.Primary = false
+	assert.NoError(t, rules.Check(c))
+}
+
+func TestRulesCanBeDisabled(t *testing.T) {
+	c := candidate()
+	c.Driver.Deleted = true
+	c.Vehicle.Category = "E"
+	c.CurrentPrimary = uuid.New()
+
+	assert.NoError(t, Rules{}.Check(c))
+}
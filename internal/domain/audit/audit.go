@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+)
+
+// Entity types that carry an audit trail.
+const (
+	EntityDriver  = "driver"
+	EntityVehicle = "vehicle"
+)
+
+type Action string
+
+const (
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionSoftDelete Action = "soft_delete"
+	ActionUnDelete   Action = "undelete"
+	ActionHardDelete Action = "hard_delete"
+	ActionAssign     Action = "assign"
+	ActionUnassign   Action = "unassign"
+)
+
+// Anonymous is recorded as the actor when the request carried no principal.
+const Anonymous = "anonymous"
+
+// Entry is one recorded change. Before is null for a create and After is null
+// for a hard delete; entries are never updated once written.
+type Entry struct {
+	Uuid uuid.UUID
+	// TenantID owns the changed entity; nil for changes made outside a tenant.
+	TenantID   uuid.UUID
+	EntityType string
+	EntityUUID uuid.UUID
+	Action     Action
+	Before     json.RawMessage
+	After      json.RawMessage
+	Actor      string
+	// APIKey is the key the change was made with, empty for a login.
+	APIKey    string
+	RequestID string
+	// IP is the client IP the request claimed, which a trusted proxy may
+	// have forwarded; RemoteIP is the peer it actually came from.
+	IP        string
+	RemoteIP  string
+	CreatedAt time.Time
+}
+
+// NewEntry snapshots before and after as JSON and stamps the entry with the
+// request metadata carried by ctx.
+func NewEntry(ctx context.Context, entityType string, entityUUID uuid.UUID, action Action, before, after interface{}) (*Entry, error) {
+	b, err := snapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := snapshot(after)
+	if err != nil {
+		return nil, err
+	}
+	meta := MetadataFrom(ctx)
+	tenantID, _ := tenant.FromContext(ctx)
+	return &Entry{
+		Uuid:       uuid.New(),
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityUUID: entityUUID,
+		Action:     action,
+		Before:     b,
+		After:      a,
+		Actor:      meta.Actor,
+		APIKey:     meta.APIKey,
+		RequestID:  meta.RequestID,
+		IP:         meta.IP,
+		RemoteIP:   meta.RemoteIP,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// Metadata describes who made a change and through which request.
+type Metadata struct {
+	Actor     string
+	APIKey    string
+	RequestID string
+	IP        string
+	RemoteIP  string
+}
+
+type metadataKey struct{}
+
+func WithMetadata(ctx context.Context, meta Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, meta)
+}
+
+// MetadataFrom returns the metadata stored in ctx. Changes made outside an
+// HTTP request are attributed to Anonymous.
+func MetadataFrom(ctx context.Context) Metadata {
+	meta, _ := ctx.Value(metadataKey{}).(Metadata)
+	if meta.Actor == "" {
+		meta.Actor = Anonymous
+	}
+	return meta
+}
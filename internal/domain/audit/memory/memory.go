@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/audit"
+	dto "github.com/moura95/go-ddd/internal/dtos/audit"
+)
+
+type IAuditRepositoryMemory interface {
+	audit.IAuditRepository
+	Snapshot() (restore func())
+}
+
+type auditRepositoryMemory struct {
+	entries []audit.Entry
+}
+
+func NewAuditRepositoryMemory() IAuditRepositoryMemory {
+	return &auditRepositoryMemory{}
+}
+
+func (m *auditRepositoryMemory) Append(ctx context.Context, e *audit.Entry) error {
+	m.entries = append(m.entries, *e)
+	return nil
+}
+
+func (m *auditRepositoryMemory) ListByEntity(ctx context.Context, entityType string, entityUUID uuid.UUID, filter dto.ListInput) ([]dto.Output, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+
+	matched := []dto.Output{}
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		e := m.entries[i]
+		if e.EntityType == entityType && e.EntityUUID == entityUUID {
+			matched = append(matched, toOutput(e))
+		}
+	}
+	start, end := filter.Window(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+func (m *auditRepositoryMemory) Snapshot() func() {
+	saved := append([]audit.Entry(nil), m.entries...)
+	return func() { m.entries = saved }
+}
+
+func toOutput(e audit.Entry) dto.Output {
+	return dto.Output{
+		Uuid:       e.Uuid,
+		EntityType: e.EntityType,
+		EntityUUID: e.EntityUUID,
+		Action:     string(e.Action),
+		Before:     e.Before,
+		After:      e.After,
+		Actor:      e.Actor,
+		APIKey:     sql.NullString{String: e.APIKey, Valid: e.APIKey != ""},
+		RequestID:  sql.NullString{String: e.RequestID, Valid: e.RequestID != ""},
+		IP:         sql.NullString{String: e.IP, Valid: e.IP != ""},
+		RemoteIP:   sql.NullString{String: e.RemoteIP, Valid: e.RemoteIP != ""},
+		CreatedAt:  e.CreatedAt,
+	}
+}
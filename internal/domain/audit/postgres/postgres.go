@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/audit"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/audit"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type auditRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewAuditRepository(db *sqlx.DB, log *zap.SugaredLogger) audit.IAuditRepository {
+	return &auditRepository{db: db, logger: log}
+}
+
+func (r *auditRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const auditColumns = "uuid, entity_type, entity_uuid, action, before, after, actor, api_key_uuid, request_id, ip, remote_ip, created_at"
+
+func (r *auditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	query := `
+        INSERT INTO audit_log (uuid, tenant_id, entity_type, entity_uuid, action, before, after, actor, api_key_uuid, request_id, ip, remote_ip, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid, NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), $13)
+    `
+	args := []interface{}{
+		e.Uuid,
+		uuid.NullUUID{UUID: e.TenantID, Valid: e.TenantID != uuid.Nil},
+		e.EntityType,
+		e.EntityUUID,
+		string(e.Action),
+		nullJSON(e.Before),
+		nullJSON(e.After),
+		e.Actor,
+		e.APIKey,
+		e.RequestID,
+		e.IP,
+		e.RemoteIP,
+		e.CreatedAt,
+	}
+	_, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *auditRepository) ListByEntity(ctx context.Context, entityType string, entityUUID uuid.UUID, filter dto.ListInput) ([]dto.Output, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+
+	var total int
+	err = r.conn(ctx).GetContext(ctx, &total,
+		"SELECT count(*) FROM audit_log WHERE entity_type = $1 AND entity_uuid = $2 AND tenant_id = $3", entityType, entityUUID, tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT " + auditColumns + ` FROM audit_log
+        WHERE entity_type = $1 AND entity_uuid = $2 AND tenant_id = $3
+        ORDER BY created_at DESC, id DESC LIMIT $4 OFFSET $5`
+	entries := []dto.Output{}
+	err = r.conn(ctx).SelectContext(ctx, &entries, query, entityType, entityUUID, tenantID, filter.PageSize, filter.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// nullJSON stores a missing snapshot as SQL NULL rather than JSON null.
+func nullJSON(raw []byte) interface{} {
+	if raw == nil {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/audit"
+)
+
+// IAuditRepository stores audit entries. It is append-only: there is no way
+// to change or remove an entry once it has been written.
+type IAuditRepository interface {
+	Append(ctx context.Context, entry *Entry) error
+	ListByEntity(ctx context.Context, entityType string, entityUUID uuid.UUID, filter audit.ListInput) ([]audit.Output, int, error)
+}
@@ -0,0 +1,119 @@
+package domainerr
+
+import "errors"
+
+// Kind classifies a domain error so outer layers can react to it (e.g. pick an
+// HTTP status) without knowing about every concrete error value.
+type Kind string
+
+const (
+	KindNotFound         Kind = "not_found"
+	KindConflict         Kind = "conflict"
+	KindValidation       Kind = "validation"
+	KindRelationNotFound Kind = "relation_not_found"
+	// KindPreconditionFailed reports a write made against a stale version.
+	KindPreconditionFailed Kind = "precondition_failed"
+	// KindUnauthorized reports missing, wrong or expired credentials.
+	KindUnauthorized Kind = "unauthorized"
+	// KindForbidden reports a known caller lacking the permission required.
+	KindForbidden Kind = "forbidden"
+)
+
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is lets a multi-field validation error match the sentinel of any of its
+// fields, so errors.Is(err, ErrInvalidName) keeps working after aggregation.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Kind != KindValidation {
+		return false
+	}
+	for _, f := range e.Fields {
+		if f.Code == t.Code {
+			return true
+		}
+	}
+	return false
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func Validation(code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func RelationNotFound(code, message string) *Error {
+	return &Error{Kind: KindRelationNotFound, Code: code, Message: message}
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+const CodeValidationFailed = "validation_failed"
+
+// Fields accumulates field errors while a whole input is being validated.
+type Fields []FieldError
+
+// Add records err against field, using the sentinel's code and message.
+func (f *Fields) Add(field string, err *Error) {
+	*f = append(*f, FieldError{Field: field, Code: err.Code, Message: err.Message})
+}
+
+// Err returns nil when no field failed, otherwise a single validation error
+// carrying every failure.
+func (f Fields) Err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &Error{
+		Kind:    KindValidation,
+		Code:    CodeValidationFailed,
+		Message: "one or more fields are invalid",
+		Fields:  f,
+	}
+}
+
+// As returns the first *Error in err's chain.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+func IsKind(err error, kind Kind) bool {
+	e, ok := As(err)
+	return ok && e.Kind == kind
+}
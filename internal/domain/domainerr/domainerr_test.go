@@ -0,0 +1,37 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errInvalidName  = Validation("invalid_name", "invalid name")
+	errInvalidEmail = Validation("invalid_email", "invalid email")
+	errNotFound     = NotFound("not_found", "not found")
+)
+
+func TestFieldsErr(t *testing.T) {
+	var fields Fields
+	assert.NoError(t, fields.Err())
+
+	fields.Add("name", errInvalidName)
+	fields.Add("email", errInvalidEmail)
+	err := fmt.Errorf("failed to create %w", fields.Err())
+
+	assert.True(t, errors.Is(err, errInvalidName))
+	assert.True(t, errors.Is(err, errInvalidEmail))
+	assert.False(t, errors.Is(err, errNotFound))
+	assert.True(t, IsKind(err, KindValidation))
+
+	e, ok := As(err)
+	assert.True(t, ok)
+	assert.Equal(t, CodeValidationFailed, e.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "name", Code: "invalid_name", Message: "invalid name"},
+		{Field: "email", Code: "invalid_email", Message: "invalid email"},
+	}, e.Fields)
+}
@@ -0,0 +1,39 @@
+package driver
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	DateOfBirthLayout = "2006-01-02"
+	// MinimumDrivingAge is the minimum age to hold a CNH in Brazil.
+	MinimumDrivingAge = 18
+)
+
+// now is swapped in tests to pin the reference date used for age checks.
+var now = time.Now
+
+// ParseDateOfBirth parses a YYYY-MM-DD date and checks the driver is old enough to drive.
+func ParseDateOfBirth(raw string) (time.Time, error) {
+	date, err := time.Parse(DateOfBirthLayout, strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}, ErrInvalidDateOfBirth
+	}
+	today := now()
+	if date.After(today) {
+		return time.Time{}, ErrInvalidDateOfBirth
+	}
+	if age(date, today) < MinimumDrivingAge {
+		return time.Time{}, ErrUnderMinimumAge
+	}
+	return date, nil
+}
+
+func age(birth, at time.Time) int {
+	years := at.Year() - birth.Year()
+	if at.Month() < birth.Month() || (at.Month() == birth.Month() && at.Day() < birth.Day()) {
+		years--
+	}
+	return years
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/driver"
+	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	infracache "github.com/moura95/go-ddd/internal/infra/cache"
+	"github.com/moura95/go-ddd/internal/infra/database"
+)
+
+// Namespaces the hits and misses are counted under.
+const (
+	NamespaceGet  = "driver"
+	NamespaceList = "driver_list"
+)
+
+// listGeneration is bumped by every driver write, retiring all cached pages.
+const listGeneration = "driver:list"
+
+// driverRepository is a read-through cache in front of another driver
+// repository. Reads inside a unit of work go straight to inner, so nothing
+// uncommitted is ever cached; writes invalidate right away and again once
+// their unit of work commits.
+type driverRepository struct {
+	driver.IDriverRepository
+	cache *infracache.Cache
+}
+
+func NewDriverRepository(inner driver.IDriverRepository, c *infracache.Cache) driver.IDriverRepository {
+	return &driverRepository{IDriverRepository: inner, cache: c}
+}
+
+type page struct {
+	Drivers []dto.Output
+	Total   int
+}
+
+func (r *driverRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if database.InUnitOfWork(ctx) {
+		return r.IDriverRepository.GetAll(ctx, filter)
+	}
+	filter.Normalize()
+	key := "driver:list:" + infracache.TenantScope(ctx) + ":" + r.cache.Generation(ctx, listGeneration) + ":" + r.cache.Generation(ctx, infracache.DriverRelations) + ":" + infracache.Hash(filter)
+
+	var cached page
+	if r.cache.Get(ctx, NamespaceList, key, &cached) {
+		return cached.Drivers, cached.Total, nil
+	}
+	drivers, total, err := r.IDriverRepository.GetAll(ctx, filter)
+	if err != nil {
+		return drivers, total, err
+	}
+	r.cache.Set(ctx, key, page{Drivers: drivers, Total: total}, r.cache.ListTTL())
+	return drivers, total, nil
+}
+
+func (r *driverRepository) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	if database.InUnitOfWork(ctx) {
+		return r.IDriverRepository.GetByID(ctx, uid)
+	}
+	key := r.key(ctx, uid)
+
+	var cached aggregate.DriverVehicleAggregate
+	if r.cache.Get(ctx, NamespaceGet, key, &cached) {
+		return &cached, nil
+	}
+	agg, err := r.IDriverRepository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Set(ctx, key, agg, r.cache.TTL())
+	return agg, nil
+}
+
+func (r *driverRepository) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	uid, err := r.IDriverRepository.Create(ctx, input)
+	if err == nil {
+		r.invalidate(ctx, uid)
+	}
+	return uid, err
+}
+
+func (r *driverRepository) Update(ctx context.Context, uid uuid.UUID, input *dto.UpdateInput) error {
+	return r.written(ctx, uid, r.IDriverRepository.Update(ctx, uid, input))
+}
+
+func (r *driverRepository) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return r.written(ctx, uid, r.IDriverRepository.HardDelete(ctx, uid, expectedVersion))
+}
+
+func (r *driverRepository) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return r.written(ctx, uid, r.IDriverRepository.SoftDelete(ctx, uid, expectedVersion))
+}
+
+func (r *driverRepository) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return r.written(ctx, uid, r.IDriverRepository.UnDelete(ctx, uid, expectedVersion))
+}
+
+func (r *driverRepository) UnRelate(ctx context.Context, driverUUID uuid.UUID) error {
+	return r.written(ctx, driverUUID, r.IDriverRepository.UnRelate(ctx, driverUUID))
+}
+
+func (r *driverRepository) written(ctx context.Context, uid uuid.UUID, err error) error {
+	if err == nil {
+		r.invalidate(ctx, uid)
+	}
+	return err
+}
+
+func (r *driverRepository) invalidate(ctx context.Context, uid uuid.UUID) {
+	drop := func() {
+		r.cache.Delete(ctx, r.key(ctx, uid))
+		r.cache.Bump(ctx, listGeneration)
+	}
+	drop()
+	database.AfterCommit(ctx, drop)
+}
+
+// key names the cached aggregate. It carries the relations generation, so
+// vehicle and assignment writes retire it without knowing which drivers
+// they touch.
+func (r *driverRepository) key(ctx context.Context, uid uuid.UUID) string {
+	return "driver:" + infracache.TenantScope(ctx) + ":" + uid.String() + ":" + r.cache.Generation(ctx, infracache.DriverRelations)
+}
@@ -0,0 +1,61 @@
+package driver
+
+import (
+	"sort"
+	"strings"
+)
+
+// LicenseCategory is the set of CNH categories a driver holds, stored as its
+// letters in order, e.g. "AB" or "AE".
+type LicenseCategory string
+
+// licenseCoverage lists the vehicle categories each CNH category may drive.
+// Heavier categories include the lighter four-wheel ones; A stands alone.
+var licenseCoverage = map[byte]string{
+	'A': "A",
+	'B': "B",
+	'C': "BC",
+	'D': "BCD",
+	'E': "BCDE",
+}
+
+func NewLicenseCategory(raw string) (LicenseCategory, error) {
+	seen := map[byte]bool{}
+	var letters []byte
+	for _, r := range strings.ToUpper(raw) {
+		if r == ' ' || r == ',' {
+			continue
+		}
+		if r < 'A' || r > 'E' {
+			return "", ErrInvalidLicenseCategory
+		}
+		c := byte(r)
+		if !seen[c] {
+			seen[c] = true
+			letters = append(letters, c)
+		}
+	}
+	if len(letters) == 0 {
+		return "", ErrInvalidLicenseCategory
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return LicenseCategory(letters), nil
+}
+
+// Covers reports whether the license allows driving a vehicle of the given
+// category.
+func (l LicenseCategory) Covers(vehicleCategory string) bool {
+	if len(vehicleCategory) != 1 {
+		return false
+	}
+	for i := 0; i < len(l); i++ {
+		if strings.IndexByte(licenseCoverage[l[i]], vehicleCategory[0]) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (l LicenseCategory) String() string {
+	return string(l)
+}
@@ -0,0 +1,42 @@
+package driver
+
+// CNH is a Brazilian driver license (Carteira Nacional de Habilitação)
+// registration number: 9 base digits followed by 2 check digits.
+type CNH string
+
+func NewCNH(raw string) (CNH, error) {
+	digits, ok := onlyDigits(raw, ".- ")
+	if !ok || len(digits) != 11 || repeated(digits) {
+		return "", ErrInvalidDriverLicense
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (9 - i)
+	}
+	first, discount := sum%11, 0
+	if first >= 10 {
+		first, discount = 0, 2
+	}
+
+	sum = 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (i + 1)
+	}
+	second := sum%11 - discount
+	if second < 0 {
+		second += 11
+	}
+	if second >= 10 {
+		second = 0
+	}
+
+	if int(digits[9]-'0') != first || int(digits[10]-'0') != second {
+		return "", ErrInvalidDriverLicense
+	}
+	return CNH(digits), nil
+}
+
+func (c CNH) String() string {
+	return string(c)
+}
@@ -0,0 +1,60 @@
+package driver
+
+import "strings"
+
+// CPF is a Brazilian individual taxpayer number stored as its 11 digits,
+// without the usual "000.000.000-00" punctuation.
+type CPF string
+
+func NewCPF(raw string) (CPF, error) {
+	digits, ok := onlyDigits(raw, ".-/ ")
+	if !ok || len(digits) != 11 || repeated(digits) {
+		return "", ErrInvalidTaxID
+	}
+	if cpfDigit(digits[:9], 10) != digits[9] || cpfDigit(digits[:10], 11) != digits[10] {
+		return "", ErrInvalidTaxID
+	}
+	return CPF(digits), nil
+}
+
+func (c CPF) String() string {
+	return string(c)
+}
+
+// Formatted returns the CPF in the 000.000.000-00 form used on documents.
+func (c CPF) Formatted() string {
+	s := string(c)
+	return s[0:3] + "." + s[3:6] + "." + s[6:9] + "-" + s[9:11]
+}
+
+func cpfDigit(digits string, weight int) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * (weight - i)
+	}
+	rest := sum * 10 % 11
+	if rest == 10 {
+		rest = 0
+	}
+	return byte('0' + rest)
+}
+
+// onlyDigits strips the allowed separators from raw and reports whether what
+// is left is made only of digits.
+func onlyDigits(raw, separators string) (string, bool) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(separators, r):
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+func repeated(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
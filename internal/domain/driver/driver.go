@@ -2,47 +2,100 @@ package driver
 
 import (
 	"database/sql"
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
 )
 
 type Driver struct {
-	Uuid          uuid.UUID
-	Name          string
-	Email         string
-	TaxID         string
-	DriverLicense string
-	DateOfBirth   sql.NullString
-	DeletedAt     sql.NullString
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	Uuid            uuid.UUID
+	Name            string
+	Email           string
+	TaxID           string
+	DriverLicense   string
+	LicenseCategory sql.NullString
+	DateOfBirth     sql.NullString
+	DeletedAt       sql.NullString
+	Version         int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
-func NewDriver(name, email, taxId, driverLicense, dateOfBirth string) *Driver {
-	return &Driver{
+// NewDriver builds a driver, validates it and normalizes its value objects so
+// they are persisted in canonical form. Every invalid field is returned at once.
+func NewDriver(name, email, taxId, driverLicense, licenseCategory, dateOfBirth string) (*Driver, error) {
+	d := &Driver{
 		Uuid:          uuid.New(),
+		Version:       1,
 		Name:          name,
 		Email:         email,
 		TaxID:         taxId,
 		DriverLicense: driverLicense,
+		LicenseCategory: sql.NullString{
+			String: licenseCategory,
+			Valid:  licenseCategory != "",
+		},
 		DateOfBirth: sql.NullString{
 			String: dateOfBirth,
-			Valid:  false,
+			Valid:  dateOfBirth != "",
 		},
 	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	d.normalize()
+	return d, nil
 }
 
 func (d *Driver) Validate() error {
-	if d.Name == "" {
-		return errors.New("invalid name")
+	var fields domainerr.Fields
+	if strings.TrimSpace(d.Name) == "" {
+		fields.Add("name", ErrInvalidName)
+	}
+	if _, err := NewCPF(d.TaxID); err != nil {
+		fields.Add("tax_id", ErrInvalidTaxID)
+	}
+	if _, err := NewEmail(d.Email); err != nil {
+		fields.Add("email", ErrInvalidEmail)
+	}
+	if d.DriverLicense != "" {
+		if _, err := NewCNH(d.DriverLicense); err != nil {
+			fields.Add("driver_license", ErrInvalidDriverLicense)
+		}
+	}
+	if d.LicenseCategory.Valid {
+		if _, err := NewLicenseCategory(d.LicenseCategory.String); err != nil {
+			fields.Add("license_category", ErrInvalidLicenseCategory)
+		}
+	}
+	if d.DateOfBirth.Valid {
+		if _, err := ParseDateOfBirth(d.DateOfBirth.String); err != nil {
+			if e, ok := domainerr.As(err); ok {
+				fields.Add("date_of_birth", e)
+			}
+		}
+	}
+	return fields.Err()
+}
+
+// normalize rewrites already validated fields into their canonical form.
+func (d *Driver) normalize() {
+	d.Name = strings.TrimSpace(d.Name)
+	if email, err := NewEmail(d.Email); err == nil {
+		d.Email = email.String()
+	}
+	if cpf, err := NewCPF(d.TaxID); err == nil {
+		d.TaxID = cpf.String()
+	}
+	if cnh, err := NewCNH(d.DriverLicense); err == nil {
+		d.DriverLicense = cnh.String()
 	}
-	if d.TaxID == "" {
-		return errors.New("invalid tax id")
+	if category, err := NewLicenseCategory(d.LicenseCategory.String); err == nil {
+		d.LicenseCategory.String = category.String()
 	}
-	if d.Email == "" {
-		return errors.New("invalid email")
+	if date, err := ParseDateOfBirth(d.DateOfBirth.String); err == nil {
+		d.DateOfBirth.String = date.Format(DateOfBirthLayout)
 	}
-	return nil
 }
@@ -1,35 +1,67 @@
 package driver
 
 import (
-	"github.com/magiconair/properties/assert"
+	"errors"
 	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
 )
 
 func TestNewDriver(t *testing.T) {
-	driver := NewDriver("Motorista 1", "motorista1@example.com", "12345678901", "ABC12345", "1990-01-01")
+	driver, err := NewDriver("Motorista 1", "motorista1@example.com", "123.456.789-09", "02650306461", "", "1990-01-01")
+	assert.Equal(t, err, nil)
 	assert.Equal(t, "Motorista 1", driver.Name)
 	assert.Equal(t, "motorista1@example.com", driver.Email)
-	assert.Equal(t, "12345678901", driver.TaxID)
+	assert.Equal(t, "12345678909", driver.TaxID)
 }
 
 func TestNewDriverWithOutName(t *testing.T) {
-	driver := NewDriver("", "motorista1@example.com", "12345678901", "ABC12345", "1990-01-01")
-	assert.Equal(t, "", driver.Name)
-	assert.Equal(t, "motorista1@example.com", driver.Email)
-	assert.Equal(t, "12345678901", driver.TaxID)
+	driver, err := NewDriver("", "motorista1@example.com", "123.456.789-09", "02650306461", "", "1990-01-01")
+	assert.Equal(t, driver == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidName), true)
 }
 
 func TestNewDriverWithOutTaxId(t *testing.T) {
-	driver := NewDriver("Motorista 2", "motorista1@example.com", "", "ABC12345", "1990-01-01")
-	assert.Equal(t, "Motorista 2", driver.Name)
-	assert.Equal(t, "motorista1@example.com", driver.Email)
-	assert.Equal(t, "", driver.TaxID)
+	driver, err := NewDriver("Motorista 2", "motorista1@example.com", "", "02650306461", "", "1990-01-01")
+	assert.Equal(t, driver == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidTaxID), true)
 }
 
 func TestNewDriverWithOutEmail(t *testing.T) {
-	driver := NewDriver("motorista 3", "", "12345678901", "ABC12345", "1990-01-01")
-	assert.Equal(t, "motorista 3", driver.Name)
-	assert.Equal(t, "", driver.Email)
-	assert.Equal(t, "12345678901", driver.TaxID)
+	driver, err := NewDriver("motorista 3", "", "123.456.789-09", "02650306461", "", "1990-01-01")
+	assert.Equal(t, driver == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidEmail), true)
+
+}
+
+func TestNewDriverNormalizesValueObjects(t *testing.T) {
+	driver, err := NewDriver("  Motorista 1 ", " Motorista1@Example.COM", "529.982.247-25", "026.503.064-61", "e, a", "1990-01-01")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, driver.Name, "Motorista 1")
+	assert.Equal(t, driver.Email, "motorista1@example.com")
+	assert.Equal(t, driver.TaxID, "52998224725")
+	assert.Equal(t, driver.DriverLicense, "02650306461")
+	assert.Equal(t, driver.LicenseCategory.String, "AE")
+	assert.Equal(t, driver.DateOfBirth.String, "1990-01-01")
+}
+
+func TestNewDriverRejectsInvalidDocuments(t *testing.T) {
+	_, err := NewDriver("Motorista 1", "motorista1@example", "12345678901", "12345678901", "F", "2020-01-01")
+	assert.Equal(t, errors.Is(err, ErrInvalidEmail), true)
+	assert.Equal(t, errors.Is(err, ErrInvalidTaxID), true)
+	assert.Equal(t, errors.Is(err, ErrInvalidDriverLicense), true)
+	assert.Equal(t, errors.Is(err, ErrInvalidLicenseCategory), true)
+	assert.Equal(t, errors.Is(err, ErrUnderMinimumAge), true)
+}
 
+func TestNewDriverReportsEveryInvalidField(t *testing.T) {
+	_, err := NewDriver("", "", "", "02650306461", "", "1990-01-01")
+	e, ok := domainerr.As(err)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, e.Kind, domainerr.KindValidation)
+	assert.Equal(t, len(e.Fields), 3)
+	assert.Equal(t, e.Fields[0].Field, "name")
+	assert.Equal(t, e.Fields[1].Field, "tax_id")
+	assert.Equal(t, e.Fields[2].Field, "email")
 }
@@ -0,0 +1,29 @@
+package driver
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// Email is a syntactically valid, lower-cased e-mail address.
+type Email string
+
+func NewEmail(raw string) (Email, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	if normalized == "" {
+		return "", ErrInvalidEmail
+	}
+	addr, err := mail.ParseAddress(normalized)
+	if err != nil || addr.Address != normalized || addr.Name != "" {
+		return "", ErrInvalidEmail
+	}
+	at := strings.LastIndex(normalized, "@")
+	if !strings.Contains(normalized[at+1:], ".") {
+		return "", ErrInvalidEmail
+	}
+	return Email(normalized), nil
+}
+
+func (e Email) String() string {
+	return string(e)
+}
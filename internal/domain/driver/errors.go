@@ -0,0 +1,19 @@
+package driver
+
+import "github.com/moura95/go-ddd/internal/domain/domainerr"
+
+var (
+	ErrNotFound        = domainerr.NotFound("driver_not_found", "driver not found")
+	ErrEmailTaken      = domainerr.Conflict("driver_email_taken", "email is already registered to another driver")
+	ErrTaxIDTaken      = domainerr.Conflict("driver_tax_id_taken", "tax id is already registered to another driver")
+	ErrVersionMismatch = domainerr.PreconditionFailed("driver_version_mismatch", "driver was modified by another request")
+
+	ErrInvalidName  = domainerr.Validation("invalid_name", "invalid name")
+	ErrInvalidTaxID = domainerr.Validation("invalid_tax_id", "invalid tax id (CPF)")
+	ErrInvalidEmail = domainerr.Validation("invalid_email", "invalid email")
+
+	ErrInvalidDriverLicense   = domainerr.Validation("invalid_driver_license", "invalid driver license (CNH)")
+	ErrInvalidLicenseCategory = domainerr.Validation("invalid_license_category", "license category must be a combination of A, B, C, D and E")
+	ErrInvalidDateOfBirth     = domainerr.Validation("invalid_date_of_birth", "date of birth must be a past date in YYYY-MM-DD format")
+	ErrUnderMinimumAge        = domainerr.Validation("under_minimum_age", "driver is under the minimum legal driving age")
+)
@@ -1,115 +1,192 @@
 package memory
 
 import (
+	"context"
 	"database/sql"
 	"github.com/google/uuid"
 	"github.com/moura95/go-ddd/internal/aggregate"
 	"github.com/moura95/go-ddd/internal/domain/driver"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
 	dto "github.com/moura95/go-ddd/internal/dtos/driver"
-	"github.com/moura95/go-ddd/internal/dtos/driver_vehicle"
+	"sort"
+	"strings"
 	"time"
 )
 
-func (m *driverRepositoryMemory) GetAll() ([]driver.Driver, error) {
+func (m *driverRepositoryMemory) GetAll(ctx context.Context, filter dto.ListInput) ([]driver.Driver, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+
+	matched := make([]driver.Driver, 0, len(m.drivers))
+	for _, d := range m.drivers {
+		if d.DeletedAt.Valid {
+			continue
+		}
+		if filter.Name != "" && !containsFold(d.Name, filter.Name) {
+			continue
+		}
+		if filter.Email != "" && !containsFold(d.Email, filter.Email) {
+			continue
+		}
+		if filter.TaxID != "" && d.TaxID != filter.TaxID {
+			continue
+		}
+		if filter.DriverLicense != "" && d.DriverLicense != filter.DriverLicense {
+			continue
+		}
+		matched = append(matched, d)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if filter.Descending() {
+			a, b = b, a
+		}
+		switch filter.SortBy {
+		case dto.SortByName:
+			return a.Name < b.Name
+		case dto.SortByEmail:
+			return a.Email < b.Email
+		case dto.SortByUpdatedAt:
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		default:
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	})
+
+	start, end := filter.Window(len(matched))
+	return matched[start:end], len(matched), nil
+}
 
-	return m.drivers, nil
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
 
-func (m *driverRepositoryMemory) Create(dto dto.CreateInput) error {
-	d := driver.NewDriver(dto.Name, dto.Email, dto.TaxID, dto.DriverLicense, dto.DateOfBirth.String)
+func (m *driverRepositoryMemory) Create(ctx context.Context, dto dto.CreateInput) error {
+	d, err := driver.NewDriver(dto.Name, dto.Email, dto.TaxID, dto.DriverLicense, dto.LicenseCategory, dto.DateOfBirth.String)
+	if err != nil {
+		return err
+	}
+	d.CreatedAt = time.Now()
+	d.UpdatedAt = d.CreatedAt
 	m.drivers = append(m.drivers, *d)
 	return nil
 }
 
-func (m *driverRepositoryMemory) GetByID(u uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+func (m *driverRepositoryMemory) GetByID(ctx context.Context, u uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
 	for _, d := range m.drivers {
 		if d.Uuid == u {
 
 			return &aggregate.DriverVehicleAggregate{
-				Uuid:          d.Uuid,
-				Name:          d.Name,
-				Email:         d.Email,
-				TaxID:         d.TaxID,
-				DriverLicense: d.DriverLicense,
-				DateOfBirth:   d.DateOfBirth,
-				DeletedAt:     sql.NullString{},
-				CreatedAt:     time.Time{},
-				UpdatedAt:     time.Time{},
-				Vehicles:      nil,
+				Uuid:            d.Uuid,
+				Name:            d.Name,
+				Email:           d.Email,
+				TaxID:           d.TaxID,
+				DriverLicense:   d.DriverLicense,
+				LicenseCategory: d.LicenseCategory,
+				DateOfBirth:     d.DateOfBirth,
+				DeletedAt:       d.DeletedAt,
+				Version:         d.Version,
+				CreatedAt:       d.CreatedAt,
+				UpdatedAt:       d.UpdatedAt,
+				Vehicles:        []vehicle.Vehicle{},
 			}, nil
 		}
 	}
-	return nil, nil
+	return nil, driver.ErrNotFound
 }
 
-func (m *driverRepositoryMemory) Update(u uuid.UUID, dto *dto.UpdateInput) error {
+func (m *driverRepositoryMemory) Update(ctx context.Context, u uuid.UUID, dto *dto.UpdateInput) error {
 	for i, d := range m.drivers {
 		if d.Uuid == u {
-			m.drivers[i] = *driver.NewDriver(dto.Name, dto.Email, dto.TaxID, dto.DriverLicense, dto.DateOfBirth.String)
+			if !versionMatches(d, dto.ExpectedVersion) {
+				return driver.ErrVersionMismatch
+			}
+			updated, err := driver.NewDriver(dto.Name, dto.Email, dto.TaxID, dto.DriverLicense, dto.LicenseCategory, dto.DateOfBirth.String)
+			if err != nil {
+				return err
+			}
+			updated.Uuid = d.Uuid
+			updated.CreatedAt = d.CreatedAt
+			updated.UpdatedAt = time.Now()
+			updated.Version = d.Version + 1
+			m.drivers[i] = *updated
 			return nil
 		}
 	}
-	return nil
+	return driver.ErrNotFound
 }
 
-func (m *driverRepositoryMemory) HardDelete(u uuid.UUID) error {
-	for i, driver := range m.drivers {
-		if driver.Uuid == u {
+func (m *driverRepositoryMemory) HardDelete(ctx context.Context, u uuid.UUID, expectedVersion int) error {
+	for i, d := range m.drivers {
+		if d.Uuid == u {
+			if !versionMatches(d, expectedVersion) {
+				return driver.ErrVersionMismatch
+			}
 			m.drivers = append(m.drivers[:i], m.drivers[i+1:]...)
 			return nil
 		}
 	}
-	return nil
+	return driver.ErrNotFound
 }
 
-func (m *driverRepositoryMemory) SoftDelete(u uuid.UUID) error {
-	for i, driver := range m.drivers {
-		if driver.Uuid == u {
-			driver.DeletedAt.String = time.Now().String()
-			m.drivers[i] = driver
+func (m *driverRepositoryMemory) SoftDelete(ctx context.Context, u uuid.UUID, expectedVersion int) error {
+	for i, d := range m.drivers {
+		if d.Uuid == u {
+			if !versionMatches(d, expectedVersion) {
+				return driver.ErrVersionMismatch
+			}
+			d.DeletedAt = sql.NullString{String: time.Now().String(), Valid: true}
+			d.Version++
+			m.drivers[i] = d
 			return nil
 		}
 	}
-	return nil
+	return driver.ErrNotFound
 }
 
-func (m *driverRepositoryMemory) UnDelete(u uuid.UUID) error {
-	for i, driver := range m.drivers {
-		if driver.Uuid == u {
-			driver.DeletedAt.String = ""
-			m.drivers[i] = driver
+func (m *driverRepositoryMemory) UnDelete(ctx context.Context, u uuid.UUID, expectedVersion int) error {
+	for i, d := range m.drivers {
+		if d.Uuid == u {
+			if !versionMatches(d, expectedVersion) {
+				return driver.ErrVersionMismatch
+			}
+			d.DeletedAt = sql.NullString{}
+			d.Version++
+			m.drivers[i] = d
 			return nil
 		}
 	}
-	return nil
+	return driver.ErrNotFound
 }
 
-func (m *driverRepositoryMemory) Subscribe(driver driver_vehicle.Input) error {
-	//TODO implement me
-	panic("implement me")
+func versionMatches(d driver.Driver, expected int) bool {
+	return expected == 0 || d.Version == expected
 }
 
-func (m *driverRepositoryMemory) UnSubscribe(vehicle driver_vehicle.Input) error {
-	//TODO implement me
-	panic("implement me")
+func (m *driverRepositoryMemory) UnRelate(ctx context.Context, uuid2 uuid.UUID) error {
+	// assignments live in the assignment repository
+	return nil
 }
 
-func (m *driverRepositoryMemory) UnRelate(uuid2 uuid.UUID) error {
-	//TODO implement me
-	panic("implement me")
+// Snapshot lets a memory unit of work roll the repository back.
+func (m *driverRepositoryMemory) Snapshot() func() {
+	saved := append([]driver.Driver(nil), m.drivers...)
+	return func() { m.drivers = saved }
 }
 
 type IDriverRepositoryMemory interface {
-	GetAll() ([]driver.Driver, error)
-	Create(input dto.CreateInput) error
-	Subscribe(driver driver_vehicle.Input) error
-	UnSubscribe(vehicle driver_vehicle.Input) error
-	GetByID(uuid.UUID) (*aggregate.DriverVehicleAggregate, error)
-	Update(uuid.UUID, *dto.UpdateInput) error
-	HardDelete(uuid.UUID) error
-	SoftDelete(uuid.UUID) error
-	UnDelete(uuid.UUID) error
-	UnRelate(uuid2 uuid.UUID) error
+	GetAll(ctx context.Context, filter dto.ListInput) ([]driver.Driver, int, error)
+	Create(ctx context.Context, input dto.CreateInput) error
+	GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error)
+	Update(ctx context.Context, uid uuid.UUID, input *dto.UpdateInput) error
+	HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnRelate(ctx context.Context, driverUUID uuid.UUID) error
+	Snapshot() (restore func())
 }
 
 type driverRepositoryMemory struct {
@@ -128,6 +205,7 @@ func NewDriverRepositoryMemory() IDriverRepositoryMemory {
 				DateOfBirth:   sql.NullString{String: "1990-01-01", Valid: true},
 				CreatedAt:     time.Now(),
 				UpdatedAt:     time.Now(),
+				Version:       1,
 			},
 			{
 				Uuid:          uuid.MustParse("ef9da75e-949f-4780-92b5-eda71618fc6c"),
@@ -138,6 +216,7 @@ func NewDriverRepositoryMemory() IDriverRepositoryMemory {
 				DateOfBirth:   sql.NullString{String: "1985-02-15", Valid: true},
 				CreatedAt:     time.Now(),
 				UpdatedAt:     time.Now(),
+				Version:       1,
 			},
 		},
 	}
@@ -1,17 +1,22 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/aggregate"
 	"github.com/moura95/go-ddd/internal/domain/driver"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
 	"github.com/moura95/go-ddd/internal/domain/vehicle"
 	dto "github.com/moura95/go-ddd/internal/dtos/driver"
-	"github.com/moura95/go-ddd/internal/dtos/driver_vehicle"
+	vehicledto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/database"
 	"go.uber.org/zap"
 )
 
@@ -24,170 +29,267 @@ func NewDriverRepository(db *sqlx.DB, log *zap.SugaredLogger) driver.IDriverRepo
 	return &driverRepository{db: db, logger: log}
 }
 
-func (r *driverRepository) GetAll() ([]dto.Output, error) {
-	var drivers []dto.Output
-	query := "SELECT * FROM drivers WHERE deleted_at is null"
-	if err := r.db.Select(&drivers, query); err != nil {
-		return []dto.Output{}, err
+// conn returns the transaction carried by ctx, so the repository joins an
+// ongoing unit of work, or the pool otherwise. It does no tenant scoping of
+// its own: each method reads the tenant with tenant.Require and filters on
+// tenant_id, and under row-level security the transaction is already scoped.
+func (r *driverRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const driverColumns = "uuid, name, email, tax_id, driver_license, license_category, date_of_birth, deleted_at, version, created_at, update_at"
+
+var driverSortColumns = map[string]string{
+	dto.SortByName:      "name",
+	dto.SortByEmail:     "email",
+	dto.SortByCreatedAt: "created_at",
+	dto.SortByUpdatedAt: "update_at",
+}
+
+func (r *driverRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []dto.Output{}, 0, err
+	}
+	filter.Normalize()
+
+	conditions := []string{"tenant_id = $1", "deleted_at is null"}
+	args := []interface{}{tenantID}
+	if filter.Name != "" {
+		args = append(args, database.Contains(filter.Name))
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d ESCAPE '\\'", len(args)))
+	}
+	if filter.Email != "" {
+		args = append(args, database.Contains(filter.Email))
+		conditions = append(conditions, fmt.Sprintf("email ILIKE $%d ESCAPE '\\'", len(args)))
+	}
+	if filter.TaxID != "" {
+		args = append(args, filter.TaxID)
+		conditions = append(conditions, fmt.Sprintf("tax_id = $%d", len(args)))
+	}
+	if filter.DriverLicense != "" {
+		args = append(args, filter.DriverLicense)
+		conditions = append(conditions, fmt.Sprintf("driver_license = $%d", len(args)))
 	}
-	return drivers, nil
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM drivers"+where, args...); err != nil {
+		return []dto.Output{}, 0, err
+	}
+
+	sortColumn, ok := driverSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = "created_at"
+	}
+	order := "ASC"
+	if filter.Descending() {
+		order = "DESC"
+	}
+
+	args = append(args, filter.PageSize, filter.Offset())
+	query := fmt.Sprintf("SELECT %s FROM drivers%s ORDER BY %s %s, uuid LIMIT $%d OFFSET $%d",
+		driverColumns, where, sortColumn, order, len(args)-1, len(args))
+
+	drivers := []dto.Output{}
+	if err := r.conn(ctx).SelectContext(ctx, &drivers, query, args...); err != nil {
+		return []dto.Output{}, 0, err
+	}
+	return drivers, total, nil
 }
 
-func (r *driverRepository) Create(dto dto.CreateInput) error {
+func (r *driverRepository) Create(ctx context.Context, dto dto.CreateInput) (uuid.UUID, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
 
-	dr := driver.NewDriver(dto.Name, dto.Email, dto.TaxID, dto.DriverLicense, dto.DateOfBirth.String)
-	err := dr.Validate()
+	dr, err := driver.NewDriver(dto.Name, dto.Email, dto.TaxID, dto.DriverLicense, dto.LicenseCategory, dto.DateOfBirth.String)
 	if err != nil {
-		log.Fatal("Error Validation Driver domain")
+		return uuid.Nil, err
 	}
 
 	query := `
-        INSERT INTO drivers (name, email, tax_id, driver_license, date_of_birth)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO drivers (uuid, tenant_id, name, email, tax_id, driver_license, license_category, date_of_birth)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
 	args := []interface{}{
+		dr.Uuid,
+		tenantID,
 		dr.Name,
 		dr.Email,
 		dr.TaxID,
 		dr.DriverLicense,
+		dr.LicenseCategory,
 		dr.DateOfBirth,
 	}
-	_, err = r.db.Exec(query, args...)
+	_, err = r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return uuid.Nil, driverError(err)
 	}
 
-	return nil
+	return dr.Uuid, nil
 }
 
-func (r *driverRepository) Subscribe(driverVehicle driver_vehicle.Input) error {
-	query := `
-        INSERT INTO drivers_vehicles (driver_uuid, vehicle_uuid)
-        VALUES ($1, $2)
-    `
-	args := []interface{}{
-		driverVehicle.VehicleUUID,
-		driverVehicle.DriverUUID,
-	}
-	_, err := r.db.Exec(query, args...)
+// GetByID loads the driver aggregate: the driver and the vehicles it is
+// currently assigned to. Deleted vehicles are left out.
+func (r *driverRepository) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	tenantID, err := tenant.Require(ctx)
 	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (r *driverRepository) UnSubscribe(driverVehicle driver_vehicle.Input) error {
-	query := "DELETE FROM drivers_vehicles WHERE driver_uuid =$1 AND vehicle_uuid =$2"
-	args := []interface{}{
-		driverVehicle.VehicleUUID,
-		driverVehicle.DriverUUID,
+		return nil, err
 	}
-	_, err := r.db.Exec(query, args...)
+	var d dto.Output
+	err = r.conn(ctx).GetContext(ctx, &d, "SELECT "+driverColumns+" FROM drivers WHERE uuid = $1 AND tenant_id = $2", uid, tenantID)
 	if err != nil {
-		return err
-	}
-	return err
-}
-
-func (r *driverRepository) GetByID(uid uuid.UUID) (*driver_vehicle.Output, error) {
-	var result []struct {
-		DriverUUID    uuid.UUID      `database:"driver_uuid"`
-		DriverName    string         `database:"name"`
-		DriverEmail   string         `database:"email"`
-		DriverTaxID   string         `database:"tax_id"`
-		DriverLicense string         `database:"driver_license"`
-		DriverDOB     sql.NullString `database:"date_of_birth"`
-		VehicleUUID   uuid.UUID      `database:"uuid"`
-		VehicleBrand  string         `database:"brand"`
-		VehicleModel  string         `database:"model"`
-		VehicleYear   uint           `database:"year_of_manufacture"`
-		VehicleColor  string         `database:"color"`
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, driver.ErrNotFound
+		}
+		return nil, err
 	}
 
 	query := `
-		SELECT d.uuid, d.name, d.email, d.tax_id, d.driver_license, d.date_of_birth,
-		       v.uuid, v.brand , v.model,
-		       v.year_of_manufacture , v.color
-		FROM drivers AS d
-		LEFT JOIN drivers_vehicles AS dv ON d.uuid = dv.driver_uuid
-		LEFT JOIN vehicles AS v ON v.uuid = dv.vehicle_uuid
-		WHERE d.uuid = $1
+		SELECT v.uuid, v.brand, v.model, v.year_of_manufacture, v.license_plate, v.renavam,
+		       v.category, v.color, v.deleted_at, v.created_at, v.update_at
+		FROM drivers_vehicles AS dv
+		JOIN vehicles AS v ON v.uuid = dv.vehicle_uuid
+		WHERE dv.driver_uuid = $1 AND dv.tenant_id = $2 AND v.tenant_id = $2
+		  AND dv.unassigned_at IS NULL AND v.deleted_at IS NULL
+		ORDER BY dv.assigned_at
 	`
-
-	err := r.db.Select(&result, query, uid)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
+	var rows []vehicledto.Output
+	if err := r.conn(ctx).SelectContext(ctx, &rows, query, uid, tenantID); err != nil {
 		return nil, err
 	}
 
-	d := &driver_vehicle.Output{
-		Uuid:          result[0].DriverUUID,
-		Name:          result[0].DriverName,
-		Email:         result[0].DriverEmail,
-		TaxID:         result[0].DriverTaxID,
-		DriverLicense: result[0].DriverLicense,
-		DateOfBirth:   result[0].DriverDOB,
-		Vehicles:      make([]vehicle.Vehicle, 0, len(result)),
+	agg := &aggregate.DriverVehicleAggregate{
+		Uuid:            d.Uuid,
+		Name:            d.Name,
+		Email:           d.Email,
+		TaxID:           d.TaxID,
+		DriverLicense:   d.DriverLicense,
+		LicenseCategory: d.LicenseCategory,
+		DateOfBirth:     d.DateOfBirth,
+		DeletedAt:       d.DeletedAt,
+		Version:         d.Version,
+		CreatedAt:       d.CreatedAt,
+		UpdatedAt:       d.UpdatedAt,
+		Vehicles:        make([]vehicle.Vehicle, 0, len(rows)),
 	}
-
-	for _, res := range result {
-		v := vehicle.Vehicle{
-			Uuid:              res.VehicleUUID,
-			Brand:             res.VehicleBrand,
-			Model:             res.VehicleModel,
-			YearOfManufacture: res.VehicleYear,
-			Color:             res.VehicleColor,
-		}
-		d.Vehicles = append(d.Vehicles, v)
+	for _, v := range rows {
+		agg.Vehicles = append(agg.Vehicles, vehicle.Vehicle{
+			Uuid:              v.Uuid,
+			Brand:             v.Brand,
+			Model:             v.Model,
+			YearOfManufacture: v.YearOfManufacture,
+			LicensePlate:      v.LicensePlate,
+			Renavam:           v.Renavam,
+			Category:          v.Category,
+			Color:             v.Color,
+			DeletedAt:         v.DeletedAt,
+			CreatedAt:         v.CreatedAt,
+			UpdatedAt:         v.UpdatedAt,
+		})
 	}
-
-	return d, nil
+	return agg, nil
 }
 
-func (r *driverRepository) Update(uuid uuid.UUID, driver *dto.UpdateInput) error {
+func (r *driverRepository) Update(ctx context.Context, uuid uuid.UUID, input *dto.UpdateInput) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
 	query := `
         UPDATE drivers 
-        SET name=$2, tax_id=$3, driver_license=$4, date_of_birth=$5, update_at=$6
-    	WHERE uuid= $1`
+        SET name=$2, email=$3, tax_id=$4, driver_license=$5, license_category=$6, date_of_birth=$7, update_at=$8,
+            version=version+1
+    	WHERE uuid= $1 AND tenant_id = $10 AND ($9 = 0 OR version = $9)`
+
+	dr, err := driver.NewDriver(input.Name, input.Email, input.TaxID, input.DriverLicense, input.LicenseCategory, input.DateOfBirth.String)
+	if err != nil {
+		return err
+	}
 
 	args := []interface{}{
 		uuid,
-		driver.Name,
-		driver.TaxID,
-		driver.DriverLicense,
-		driver.DateOfBirth,
+		dr.Name,
+		dr.Email,
+		dr.TaxID,
+		dr.DriverLicense,
+		dr.LicenseCategory,
+		dr.DateOfBirth,
 		time.Now(),
+		input.ExpectedVersion,
+		tenantID,
+	}
+	res, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return driverError(err)
+	}
+	return r.expectVersioned(ctx, res, uuid, tenantID)
+}
+
+func (r *driverRepository) HardDelete(ctx context.Context, uuid uuid.UUID, expectedVersion int) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	_, err := r.db.Exec(query, args...)
+	query := "DELETE FROM drivers WHERE uuid = :UUID AND tenant_id = :TenantID AND (:Version = 0 OR version = :Version)"
+	res, err := r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"UUID": uuid, "TenantID": tenantID, "Version": expectedVersion})
 	if err != nil {
 		return err
 	}
-	return nil
+	return r.expectVersioned(ctx, res, uuid, tenantID)
 }
 
-func (r *driverRepository) HardDelete(uuid uuid.UUID) error {
-	query := "DELETE FROM drivers WHERE uuid = :UUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"UUID": uuid})
-	return err
+func (r *driverRepository) SoftDelete(ctx context.Context, uuid uuid.UUID, expectedVersion int) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	query := "UPDATE drivers SET deleted_at=now(), version=version+1 WHERE uuid = :UUID AND tenant_id = :TenantID AND (:Version = 0 OR version = :Version)"
+	res, err := r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"UUID": uuid, "TenantID": tenantID, "Version": expectedVersion})
+	if err != nil {
+		return err
+	}
+	return r.expectVersioned(ctx, res, uuid, tenantID)
 }
 
-func (r *driverRepository) SoftDelete(uuid uuid.UUID) error {
-	query := "UPDATE drivers SET deleted_at=now() WHERE uuid = :UUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"UUID": uuid})
-	return err
+func (r *driverRepository) UnDelete(ctx context.Context, uuid uuid.UUID, expectedVersion int) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	query := "UPDATE drivers SET deleted_at=null, version=version+1 WHERE uuid = :UUID AND tenant_id = :TenantID AND (:Version = 0 OR version = :Version)"
+	res, err := r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"UUID": uuid, "TenantID": tenantID, "Version": expectedVersion})
+	if err != nil {
+		return err
+	}
+	return r.expectVersioned(ctx, res, uuid, tenantID)
+}
+
+func (r *driverRepository) expectVersioned(ctx context.Context, res sql.Result, uid, tenantID uuid.UUID) error {
+	return database.ExpectVersioned(ctx, r.conn(ctx), res, "drivers", uid, tenantID, driver.ErrNotFound, driver.ErrVersionMismatch)
 }
 
-func (r *driverRepository) UnDelete(uuid uuid.UUID) error {
-	query := "UPDATE drivers SET deleted_at=null WHERE uuid = :UUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"UUID": uuid})
+func (r *driverRepository) UnRelate(ctx context.Context, driverUUID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	query := "UPDATE drivers_vehicles SET unassigned_at=now() WHERE driver_uuid = :DriverUUID AND tenant_id = :TenantID AND unassigned_at IS NULL"
+	_, err = r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"DriverUUID": driverUUID, "TenantID": tenantID})
 	return err
 }
 
-func (r *driverRepository) UnRelate(driverUUID uuid.UUID) error {
-	query := "DELETE FROM drivers_vehicles WHERE driver_uuid = :DriverUUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"DriverUUID": driverUUID})
+// driverError translates constraint violations into domain errors.
+func driverError(err error) error {
+	if constraint, ok := database.UniqueViolation(err); ok {
+		switch constraint {
+		case "drivers_email_key":
+			return driver.ErrEmailTaken
+		case "drivers_tax_id_key":
+			return driver.ErrTaxIDTaken
+		}
+	}
 	return err
 }
@@ -1,20 +1,22 @@
 package driver
 
 import (
+	"context"
+
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
 	"github.com/moura95/go-ddd/internal/dtos/driver"
-	"github.com/moura95/go-ddd/internal/dtos/driver_vehicle"
 )
 
 type IDriverRepository interface {
-	GetAll() ([]driver.Output, error)
-	Create(input driver.CreateInput) error
-	Subscribe(driver driver_vehicle.Input) error
-	UnSubscribe(vehicle driver_vehicle.Input) error
-	GetByID(uuid.UUID) (*driver_vehicle.Output, error)
-	Update(uuid.UUID, *driver.UpdateInput) error
-	HardDelete(uuid.UUID) error
-	SoftDelete(uuid.UUID) error
-	UnDelete(uuid.UUID) error
-	UnRelate(uuid2 uuid.UUID) error
+	GetAll(ctx context.Context, filter driver.ListInput) ([]driver.Output, int, error)
+	Create(ctx context.Context, input driver.CreateInput) (uuid.UUID, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error)
+	Update(ctx context.Context, uid uuid.UUID, input *driver.UpdateInput) error
+	// The delete and recover writes fail with ErrVersionMismatch unless the
+	// row is at expectedVersion; zero skips the check.
+	HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnRelate(ctx context.Context, driverUUID uuid.UUID) error
 }
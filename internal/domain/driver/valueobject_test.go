@@ -0,0 +1,100 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCPF(t *testing.T) {
+	valid := map[string]string{
+		"12345678909":     "12345678909",
+		"529.982.247-25":  "52998224725",
+		" 111.444.777-35": "11144477735",
+	}
+	for raw, want := range valid {
+		cpf, err := NewCPF(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, want, cpf.String())
+	}
+	assert.Equal(t, "529.982.247-25", CPF("52998224725").Formatted())
+
+	for _, raw := range []string{"", "12345678901", "1234567890", "11111111111", "529.982.247-2A"} {
+		_, err := NewCPF(raw)
+		assert.ErrorIs(t, err, ErrInvalidTaxID, raw)
+	}
+}
+
+func TestNewCNH(t *testing.T) {
+	for _, raw := range []string{"02650306461", "98765432109", "00000000119"} {
+		cnh, err := NewCNH(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, raw, cnh.String())
+	}
+	for _, raw := range []string{"", "ABC12345", "02650306460", "11111111111", "0265030646"} {
+		_, err := NewCNH(raw)
+		assert.ErrorIs(t, err, ErrInvalidDriverLicense, raw)
+	}
+}
+
+func TestNewEmail(t *testing.T) {
+	email, err := NewEmail("  Driver.One@Example.com ")
+	assert.NoError(t, err)
+	assert.Equal(t, "driver.one@example.com", email.String())
+
+	for _, raw := range []string{"", "driver", "driver@example", "Driver <driver@example.com>", "a b@example.com"} {
+		_, err := NewEmail(raw)
+		assert.ErrorIs(t, err, ErrInvalidEmail, raw)
+	}
+}
+
+func TestParseDateOfBirth(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	_, err := ParseDateOfBirth("2006-06-15")
+	assert.NoError(t, err)
+
+	_, err = ParseDateOfBirth("2006-06-16")
+	assert.ErrorIs(t, err, ErrUnderMinimumAge)
+
+	_, err = ParseDateOfBirth("2030-01-01")
+	assert.ErrorIs(t, err, ErrInvalidDateOfBirth)
+
+	_, err = ParseDateOfBirth("1990-02-30")
+	assert.ErrorIs(t, err, ErrInvalidDateOfBirth)
+
+	_, err = ParseDateOfBirth("15/06/1990")
+	assert.ErrorIs(t, err, ErrInvalidDateOfBirth)
+}
+
+func TestNewLicenseCategory(t *testing.T) {
+	category, err := NewLicenseCategory(" d, a ")
+	assert.NoError(t, err)
+	assert.Equal(t, "AD", category.String())
+
+	for _, raw := range []string{"", "F", "AB1", "Á"} {
+		_, err := NewLicenseCategory(raw)
+		assert.ErrorIs(t, err, ErrInvalidLicenseCategory, raw)
+	}
+}
+
+func TestLicenseCategoryCovers(t *testing.T) {
+	cases := map[string]string{
+		"A":  "A",
+		"B":  "B",
+		"C":  "BC",
+		"D":  "BCD",
+		"E":  "BCDE",
+		"AE": "ABCDE",
+	}
+	for license, covered := range cases {
+		for _, vehicle := range []string{"A", "B", "C", "D", "E"} {
+			want := strings.Contains(covered, vehicle)
+			assert.Equal(t, want, LicenseCategory(license).Covers(vehicle), license+" covers "+vehicle)
+		}
+	}
+	assert.False(t, LicenseCategory("").Covers("B"))
+}
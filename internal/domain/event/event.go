@@ -0,0 +1,47 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a fact raised by a domain type about a change to itself. Events
+// travel through the outbox, so Payload must marshal to JSON.
+type Event struct {
+	Uuid          uuid.UUID
+	Name          string
+	AggregateType string
+	AggregateUUID uuid.UUID
+	Payload       interface{}
+	OccurredAt    time.Time
+}
+
+func New(name, aggregateType string, aggregateUUID uuid.UUID, payload interface{}) Event {
+	return Event{
+		Uuid:          uuid.New(),
+		Name:          name,
+		AggregateType: aggregateType,
+		AggregateUUID: aggregateUUID,
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// Recorder is embedded by domain types to collect the events they raise until
+// a service pulls them into the outbox.
+type Recorder struct {
+	events []Event
+}
+
+func (r *Recorder) Record(e Event) {
+	r.events = append(r.events, e)
+}
+
+// PullEvents returns the recorded events and forgets them, so each event is
+// written to the outbox once.
+func (r *Recorder) PullEvents() []Event {
+	events := r.events
+	r.events = nil
+	return events
+}
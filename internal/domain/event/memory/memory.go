@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/event"
+)
+
+type IOutboxRepositoryMemory interface {
+	event.IOutboxRepository
+	// Messages returns every stored message, dispatched or not.
+	Messages() []dto.Message
+	Snapshot() (restore func())
+}
+
+type outboxMessage struct {
+	dto.Message
+	availableAt time.Time
+}
+
+// outboxRepositoryMemory is shared by the services and the relay goroutine,
+// so unlike the other memory repositories it guards its state.
+type outboxRepositoryMemory struct {
+	mu       sync.Mutex
+	messages []outboxMessage
+}
+
+func NewOutboxRepositoryMemory() IOutboxRepositoryMemory {
+	return &outboxRepositoryMemory{}
+}
+
+func (m *outboxRepositoryMemory) Append(ctx context.Context, events ...event.Event) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range events {
+		payload, err := json.Marshal(e.Payload)
+		if err != nil {
+			return err
+		}
+		m.messages = append(m.messages, outboxMessage{
+			Message: dto.Message{
+				Uuid:          e.Uuid,
+				TenantID:      tenantID,
+				Name:          e.Name,
+				AggregateType: e.AggregateType,
+				AggregateUUID: e.AggregateUUID,
+				Payload:       payload,
+				OccurredAt:    e.OccurredAt,
+			},
+			availableAt: e.OccurredAt,
+		})
+	}
+	return nil
+}
+
+func (m *outboxRepositoryMemory) ClaimPending(ctx context.Context, limit int) ([]dto.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	pending := []dto.Message{}
+	for _, msg := range m.messages {
+		if len(pending) == limit {
+			break
+		}
+		if !msg.DispatchedAt.Valid && !msg.availableAt.After(now) {
+			pending = append(pending, msg.Message)
+		}
+	}
+	return pending, nil
+}
+
+func (m *outboxRepositoryMemory) MarkDispatched(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	return m.update(uid, func(msg *outboxMessage) {
+		msg.Attempts++
+		msg.LastError = sql.NullString{}
+		msg.DispatchedAt = sql.NullTime{Time: at, Valid: true}
+	})
+}
+
+func (m *outboxRepositoryMemory) MarkFailed(ctx context.Context, uid uuid.UUID, reason string, retryAt time.Time) error {
+	return m.update(uid, func(msg *outboxMessage) {
+		msg.Attempts++
+		msg.LastError = sql.NullString{String: reason, Valid: true}
+		msg.availableAt = retryAt
+	})
+}
+
+func (m *outboxRepositoryMemory) update(uid uuid.UUID, fn func(msg *outboxMessage)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.messages {
+		if m.messages[i].Uuid == uid {
+			fn(&m.messages[i])
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *outboxRepositoryMemory) Messages() []dto.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]dto.Message, len(m.messages))
+	for i, msg := range m.messages {
+		out[i] = msg.Message
+	}
+	return out
+}
+
+func (m *outboxRepositoryMemory) Snapshot() func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := append([]outboxMessage(nil), m.messages...)
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.messages = saved
+	}
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/event"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type outboxRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewOutboxRepository(db *sqlx.DB, log *zap.SugaredLogger) event.IOutboxRepository {
+	return &outboxRepository{db: db, logger: log}
+}
+
+func (r *outboxRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+func (r *outboxRepository) Append(ctx context.Context, events ...event.Event) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	query := `
+        INSERT INTO outbox (uuid, tenant_id, name, aggregate_type, aggregate_uuid, payload, occurred_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	for _, e := range events {
+		payload, err := json.Marshal(e.Payload)
+		if err != nil {
+			return err
+		}
+		_, err = r.conn(ctx).ExecContext(ctx, query, e.Uuid, tenantID, e.Name, e.AggregateType, e.AggregateUUID, string(payload), e.OccurredAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *outboxRepository) ClaimPending(ctx context.Context, limit int) ([]dto.Message, error) {
+	query := `
+        SELECT uuid, tenant_id, name, aggregate_type, aggregate_uuid, payload, occurred_at, attempts, last_error, dispatched_at
+        FROM outbox
+        WHERE dispatched_at IS NULL AND available_at <= now()
+        ORDER BY id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `
+	messages := []dto.Message{}
+	if err := r.conn(ctx).SelectContext(ctx, &messages, query, limit); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *outboxRepository) MarkDispatched(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	query := "UPDATE outbox SET dispatched_at = $2, attempts = attempts + 1, last_error = NULL WHERE uuid = $1"
+	_, err := r.conn(ctx).ExecContext(ctx, query, uid, at)
+	return err
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, uid uuid.UUID, reason string, retryAt time.Time) error {
+	query := "UPDATE outbox SET attempts = attempts + 1, last_error = $2, available_at = $3 WHERE uuid = $1"
+	_, err := r.conn(ctx).ExecContext(ctx, query, uid, reason, retryAt)
+	return err
+}
@@ -0,0 +1,24 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/event"
+)
+
+// IOutboxRepository stores events next to the state change that raised them
+// and hands them to the relay until they are dispatched.
+type IOutboxRepository interface {
+	// Append joins the unit of work carried by ctx, so the events commit or
+	// roll back together with the change. The events belong to the tenant of
+	// ctx; Append fails without one.
+	Append(ctx context.Context, events ...Event) error
+	// ClaimPending returns up to limit undispatched messages that are due,
+	// oldest first, locking them against other relays until ctx's
+	// transaction ends.
+	ClaimPending(ctx context.Context, limit int) ([]event.Message, error)
+	MarkDispatched(ctx context.Context, uid uuid.UUID, at time.Time) error
+	MarkFailed(ctx context.Context, uid uuid.UUID, reason string, retryAt time.Time) error
+}
@@ -0,0 +1,16 @@
+package tenant
+
+import (
+	"errors"
+
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+)
+
+var (
+	ErrNotFound    = domainerr.NotFound("tenant_not_found", "tenant not found")
+	ErrInvalidName = domainerr.Validation("invalid_name", "name is required and must have at most 255 characters")
+
+	// ErrNoTenant is a programming error: a tenant-scoped repository was
+	// called with a context no tenant was resolved for.
+	ErrNoTenant = errors.New("no tenant on context")
+)
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+)
+
+type ITenantRepositoryMemory interface {
+	tenant.ITenantRepository
+	Snapshot() (restore func())
+}
+
+type tenantRepositoryMemory struct {
+	tenants []tenant.Tenant
+}
+
+func NewTenantRepositoryMemory() ITenantRepositoryMemory {
+	return &tenantRepositoryMemory{}
+}
+
+func (m *tenantRepositoryMemory) Create(ctx context.Context, t *tenant.Tenant) error {
+	m.tenants = append(m.tenants, *t)
+	return nil
+}
+
+func (m *tenantRepositoryMemory) GetByID(ctx context.Context, uid uuid.UUID) (*tenant.Tenant, error) {
+	for _, t := range m.tenants {
+		if t.Uuid == uid {
+			return &t, nil
+		}
+	}
+	return nil, tenant.ErrNotFound
+}
+
+func (m *tenantRepositoryMemory) GetAll(ctx context.Context, filter dto.ListInput) ([]tenant.Tenant, int, error) {
+	filter.Normalize()
+	sorted := append([]tenant.Tenant(nil), m.tenants...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	start, end := filter.Window(len(sorted))
+	return sorted[start:end], len(sorted), nil
+}
+
+func (m *tenantRepositoryMemory) Snapshot() func() {
+	saved := append([]tenant.Tenant(nil), m.tenants...)
+	return func() { m.tenants = saved }
+}
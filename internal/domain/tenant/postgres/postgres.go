@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type tenantRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewTenantRepository(db *sqlx.DB, log *zap.SugaredLogger) tenant.ITenantRepository {
+	return &tenantRepository{db: db, logger: log}
+}
+
+func (r *tenantRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const tenantColumns = "uuid, name, created_at"
+
+type tenantRow struct {
+	Uuid      uuid.UUID `db:"uuid"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r *tenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "INSERT INTO tenants (uuid, name, created_at) VALUES ($1, $2, $3)", t.Uuid, t.Name, t.CreatedAt)
+	return err
+}
+
+func (r *tenantRepository) GetByID(ctx context.Context, uid uuid.UUID) (*tenant.Tenant, error) {
+	var row tenantRow
+	err := r.conn(ctx).GetContext(ctx, &row, "SELECT "+tenantColumns+" FROM tenants WHERE uuid = $1", uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tenant.ErrNotFound
+		}
+		return nil, err
+	}
+	return &tenant.Tenant{Uuid: row.Uuid, Name: row.Name, CreatedAt: row.CreatedAt}, nil
+}
+
+func (r *tenantRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]tenant.Tenant, int, error) {
+	filter.Normalize()
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM tenants"); err != nil {
+		return []tenant.Tenant{}, 0, err
+	}
+	var rows []tenantRow
+	query := "SELECT " + tenantColumns + " FROM tenants ORDER BY name, uuid LIMIT $1 OFFSET $2"
+	if err := r.conn(ctx).SelectContext(ctx, &rows, query, filter.PageSize, filter.Offset()); err != nil {
+		return []tenant.Tenant{}, 0, err
+	}
+	tenants := make([]tenant.Tenant, len(rows))
+	for i, row := range rows {
+		tenants[i] = tenant.Tenant{Uuid: row.Uuid, Name: row.Name, CreatedAt: row.CreatedAt}
+	}
+	return tenants, total, nil
+}
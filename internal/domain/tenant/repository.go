@@ -0,0 +1,15 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+)
+
+type ITenantRepository interface {
+	Create(ctx context.Context, t *Tenant) error
+	GetByID(ctx context.Context, uid uuid.UUID) (*Tenant, error)
+	// GetAll lists tenants by name.
+	GetAll(ctx context.Context, filter dto.ListInput) ([]Tenant, int, error)
+}
@@ -0,0 +1,60 @@
+package tenant
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+)
+
+// Default owns the rows created before fleets were split into tenants. It
+// is the operator's own tenant, the only one allowed to manage the others,
+// and the tenant of every request while auth is disabled.
+var Default = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+const MaxNameLength = 255
+
+// Tenant is a client company whose fleet is kept apart from the others.
+type Tenant struct {
+	Uuid      uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewTenant(name string) (*Tenant, error) {
+	var fields domainerr.Fields
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxNameLength {
+		fields.Add("name", ErrInvalidName)
+	}
+	if err := fields.Err(); err != nil {
+		return nil, err
+	}
+	return &Tenant{Uuid: uuid.New(), Name: name, CreatedAt: time.Now()}, nil
+}
+
+type tenantKey struct{}
+
+// WithID scopes ctx to the tenant id; the tenant-aware repositories only see
+// and write that tenant's rows.
+func WithID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the tenant stored by WithID, if any.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(tenantKey{}).(uuid.UUID)
+	return id, ok && id != uuid.Nil
+}
+
+// Require returns the tenant of ctx, failing with ErrNoTenant rather than
+// letting a query run unscoped.
+func Require(ctx context.Context) (uuid.UUID, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return uuid.Nil, ErrNoTenant
+	}
+	return id, nil
+}
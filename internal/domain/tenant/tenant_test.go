@@ -0,0 +1,33 @@
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTenant(t *testing.T) {
+	tn, err := NewTenant(" Acme Logistics ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme Logistics", tn.Name)
+	assert.NotEqual(t, uuid.Nil, tn.Uuid)
+
+	for _, name := range []string{"", "   ", strings.Repeat("a", MaxNameLength+1)} {
+		_, err = NewTenant(name)
+		assert.ErrorIs(t, err, ErrInvalidName)
+	}
+}
+
+func TestRequireFailsClosed(t *testing.T) {
+	_, err := Require(context.Background())
+	assert.ErrorIs(t, err, ErrNoTenant)
+	_, err = Require(WithID(context.Background(), uuid.Nil))
+	assert.ErrorIs(t, err, ErrNoTenant)
+
+	id, err := Require(WithID(context.Background(), Default))
+	assert.NoError(t, err)
+	assert.Equal(t, Default, id)
+}
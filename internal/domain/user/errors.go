@@ -0,0 +1,22 @@
+package user
+
+import "github.com/moura95/go-ddd/internal/domain/domainerr"
+
+var (
+	ErrNotFound   = domainerr.NotFound("user_not_found", "user not found")
+	ErrEmailTaken = domainerr.Conflict("user_email_taken", "email is already registered to another user")
+	ErrLastAdmin  = domainerr.Conflict("last_admin", "the last admin cannot lose the admin role")
+
+	ErrInvalidEmail      = domainerr.Validation("invalid_email", "invalid email")
+	ErrPasswordTooShort  = domainerr.Validation("password_too_short", "password must have at least 8 characters")
+	ErrPasswordTooLong   = domainerr.Validation("password_too_long", "password must have at most 72 bytes")
+	ErrUnknownRole       = domainerr.Validation("unknown_role", "role must be admin, dispatcher or viewer")
+	ErrUnknownPermission = domainerr.Validation("unknown_permission", "unknown permission")
+
+	// ErrInvalidCredentials does not say whether the email or the password
+	// was wrong, so logins cannot be used to find registered emails.
+	ErrInvalidCredentials = domainerr.Unauthorized("invalid_credentials", "invalid email or password")
+	ErrInvalidToken       = domainerr.Unauthorized("invalid_token", "token is invalid or expired")
+	ErrTokenRevoked       = domainerr.Unauthorized("token_revoked", "token has been revoked")
+	ErrForbidden          = domainerr.Forbidden("forbidden", "you are not allowed to do this")
+)
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+)
+
+type IUserRepositoryMemory interface {
+	user.IUserRepository
+	Snapshot() (restore func())
+}
+
+type userRepositoryMemory struct {
+	users  []user.User
+	tokens []user.RefreshToken
+}
+
+func NewUserRepositoryMemory() IUserRepositoryMemory {
+	return &userRepositoryMemory{}
+}
+
+func (m *userRepositoryMemory) Create(ctx context.Context, u *user.User) error {
+	for _, existing := range m.users {
+		if existing.Email == u.Email {
+			return user.ErrEmailTaken
+		}
+	}
+	stored := *u
+	stored.Roles = append([]user.Role(nil), u.Roles...)
+	m.users = append(m.users, stored)
+	return nil
+}
+
+func (m *userRepositoryMemory) GetByID(ctx context.Context, uid uuid.UUID) (*user.User, error) {
+	for _, u := range m.users {
+		if u.Uuid == uid {
+			return &u, nil
+		}
+	}
+	return nil, user.ErrNotFound
+}
+
+func (m *userRepositoryMemory) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, user.ErrNotFound
+}
+
+func (m *userRepositoryMemory) GetAll(ctx context.Context, filter dto.ListInput) ([]user.User, int, error) {
+	filter.Normalize()
+	matched := []user.User{}
+	for _, u := range m.users {
+		if u.TenantID == filter.TenantID {
+			matched = append(matched, u)
+		}
+	}
+	start, end := filter.Window(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+func (m *userRepositoryMemory) AddRole(ctx context.Context, uid uuid.UUID, role user.Role, grantedBy string) error {
+	for i := range m.users {
+		if m.users[i].Uuid != uid {
+			continue
+		}
+		for _, r := range m.users[i].Roles {
+			if r == role {
+				return nil
+			}
+		}
+		m.users[i].Roles = append(append([]user.Role(nil), m.users[i].Roles...), role)
+		return nil
+	}
+	return user.ErrNotFound
+}
+
+func (m *userRepositoryMemory) RemoveRole(ctx context.Context, uid uuid.UUID, role user.Role) error {
+	for i := range m.users {
+		if m.users[i].Uuid != uid {
+			continue
+		}
+		kept := []user.Role{}
+		for _, r := range m.users[i].Roles {
+			if r != role {
+				kept = append(kept, r)
+			}
+		}
+		m.users[i].Roles = kept
+	}
+	return nil
+}
+
+func (m *userRepositoryMemory) CountWithRole(ctx context.Context, tenantID uuid.UUID, role user.Role) (int, error) {
+	n := 0
+	for _, u := range m.users {
+		if u.Active && u.TenantID == tenantID && hasRole(u.Roles, role) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *userRepositoryMemory) CreateRefreshToken(ctx context.Context, t *user.RefreshToken) error {
+	m.tokens = append(m.tokens, *t)
+	return nil
+}
+
+func (m *userRepositoryMemory) GetRefreshToken(ctx context.Context, hash string) (*user.RefreshToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == hash {
+			return &t, nil
+		}
+	}
+	return nil, user.ErrInvalidToken
+}
+
+func (m *userRepositoryMemory) RevokeRefreshToken(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	for i := range m.tokens {
+		if m.tokens[i].Uuid == uid && !m.tokens[i].Revoked() {
+			m.tokens[i].RevokedAt = at
+			return nil
+		}
+	}
+	return user.ErrTokenRevoked
+}
+
+func (m *userRepositoryMemory) RevokeFamily(ctx context.Context, familyUUID uuid.UUID, at time.Time) error {
+	for i := range m.tokens {
+		if m.tokens[i].FamilyUUID == familyUUID && !m.tokens[i].Revoked() {
+			m.tokens[i].RevokedAt = at
+		}
+	}
+	return nil
+}
+
+func (m *userRepositoryMemory) Snapshot() func() {
+	users := append([]user.User(nil), m.users...)
+	tokens := append([]user.RefreshToken(nil), m.tokens...)
+	return func() { m.users, m.tokens = users, tokens }
+}
+
+func hasRole(roles []user.Role, role user.Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type userRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewUserRepository(db *sqlx.DB, log *zap.SugaredLogger) user.IUserRepository {
+	return &userRepository{db: db, logger: log}
+}
+
+func (r *userRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const (
+	userColumns         = "uuid, tenant_id, email, password_hash, ARRAY(SELECT role FROM user_roles WHERE user_uuid = users.uuid ORDER BY role) AS roles, active, created_at, update_at"
+	refreshTokenColumns = "uuid, user_uuid, family_uuid, token_hash, expires_at, revoked_at, created_at"
+)
+
+type userRow struct {
+	Uuid         uuid.UUID      `db:"uuid"`
+	TenantID     uuid.UUID      `db:"tenant_id"`
+	Email        string         `db:"email"`
+	PasswordHash string         `db:"password_hash"`
+	Roles        pq.StringArray `db:"roles"`
+	Active       bool           `db:"active"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"update_at"`
+}
+
+func (row userRow) toUser() user.User {
+	roles := make([]user.Role, len(row.Roles))
+	for i, role := range row.Roles {
+		roles[i] = user.Role(role)
+	}
+	return user.User{
+		Uuid:         row.Uuid,
+		TenantID:     row.TenantID,
+		Email:        row.Email,
+		PasswordHash: row.PasswordHash,
+		Roles:        roles,
+		Active:       row.Active,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
+
+type refreshTokenRow struct {
+	Uuid       uuid.UUID    `db:"uuid"`
+	UserUUID   uuid.UUID    `db:"user_uuid"`
+	FamilyUUID uuid.UUID    `db:"family_uuid"`
+	TokenHash  string       `db:"token_hash"`
+	ExpiresAt  time.Time    `db:"expires_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+func (r *userRepository) Create(ctx context.Context, u *user.User) error {
+	query := `
+        INSERT INTO users (uuid, tenant_id, email, password_hash, active, created_at, update_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err := r.conn(ctx).ExecContext(ctx, query, u.Uuid, u.TenantID, u.Email, u.PasswordHash, u.Active, u.CreatedAt, u.UpdatedAt)
+	if constraint, ok := database.UniqueViolation(err); ok && constraint == "users_email_key" {
+		return user.ErrEmailTaken
+	}
+	if err != nil {
+		return err
+	}
+	for _, role := range u.Roles {
+		if err := r.AddRole(ctx, u.Uuid, role, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, uid uuid.UUID) (*user.User, error) {
+	return r.get(ctx, "uuid", uid)
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.get(ctx, "email", email)
+}
+
+func (r *userRepository) get(ctx context.Context, column string, value interface{}) (*user.User, error) {
+	var row userRow
+	err := r.conn(ctx).GetContext(ctx, &row, "SELECT "+userColumns+" FROM users WHERE "+column+" = $1", value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrNotFound
+		}
+		return nil, err
+	}
+	u := row.toUser()
+	return &u, nil
+}
+
+func (r *userRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]user.User, int, error) {
+	filter.Normalize()
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM users WHERE tenant_id = $1", filter.TenantID); err != nil {
+		return []user.User{}, 0, err
+	}
+	var rows []userRow
+	query := "SELECT " + userColumns + " FROM users WHERE tenant_id = $1 ORDER BY email LIMIT $2 OFFSET $3"
+	if err := r.conn(ctx).SelectContext(ctx, &rows, query, filter.TenantID, filter.PageSize, filter.Offset()); err != nil {
+		return []user.User{}, 0, err
+	}
+	users := make([]user.User, len(rows))
+	for i, row := range rows {
+		users[i] = row.toUser()
+	}
+	return users, total, nil
+}
+
+func (r *userRepository) AddRole(ctx context.Context, uid uuid.UUID, role user.Role, grantedBy string) error {
+	query := `
+        INSERT INTO user_roles (user_uuid, role, granted_by)
+        VALUES ($1, $2, NULLIF($3, ''))
+        ON CONFLICT DO NOTHING
+    `
+	_, err := r.conn(ctx).ExecContext(ctx, query, uid, string(role), grantedBy)
+	if _, ok := database.ForeignKeyViolation(err); ok {
+		return user.ErrNotFound
+	}
+	return err
+}
+
+func (r *userRepository) RemoveRole(ctx context.Context, uid uuid.UUID, role user.Role) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "DELETE FROM user_roles WHERE user_uuid = $1 AND role = $2", uid, string(role))
+	return err
+}
+
+func (r *userRepository) CountWithRole(ctx context.Context, tenantID uuid.UUID, role user.Role) (int, error) {
+	query := `
+        SELECT count(*) FROM user_roles
+        JOIN users ON users.uuid = user_roles.user_uuid
+        WHERE user_roles.role = $1 AND users.active AND users.tenant_id = $2
+    `
+	var n int
+	err := r.conn(ctx).GetContext(ctx, &n, query, string(role), tenantID)
+	return n, err
+}
+
+func (r *userRepository) CreateRefreshToken(ctx context.Context, t *user.RefreshToken) error {
+	query := `
+        INSERT INTO refresh_tokens (uuid, user_uuid, family_uuid, token_hash, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := r.conn(ctx).ExecContext(ctx, query, t.Uuid, t.UserUUID, t.FamilyUUID, t.TokenHash, t.ExpiresAt, t.CreatedAt)
+	return err
+}
+
+func (r *userRepository) GetRefreshToken(ctx context.Context, hash string) (*user.RefreshToken, error) {
+	var row refreshTokenRow
+	err := r.conn(ctx).GetContext(ctx, &row, "SELECT "+refreshTokenColumns+" FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE", hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrInvalidToken
+		}
+		return nil, err
+	}
+	return &user.RefreshToken{
+		Uuid:       row.Uuid,
+		UserUUID:   row.UserUUID,
+		FamilyUUID: row.FamilyUUID,
+		TokenHash:  row.TokenHash,
+		ExpiresAt:  row.ExpiresAt,
+		RevokedAt:  row.RevokedAt.Time,
+		CreatedAt:  row.CreatedAt,
+	}, nil
+}
+
+func (r *userRepository) RevokeRefreshToken(ctx context.Context, uid uuid.UUID, at time.Time) error {
+	res, err := r.conn(ctx).ExecContext(ctx, "UPDATE refresh_tokens SET revoked_at = $2 WHERE uuid = $1 AND revoked_at IS NULL", uid, at)
+	if err != nil {
+		return err
+	}
+	return database.ExpectAffected(res, user.ErrTokenRevoked)
+}
+
+func (r *userRepository) RevokeFamily(ctx context.Context, familyUUID uuid.UUID, at time.Time) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "UPDATE refresh_tokens SET revoked_at = $2 WHERE family_uuid = $1 AND revoked_at IS NULL", familyUUID, at)
+	return err
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+)
+
+type IUserRepository interface {
+	// Create stores u with its roles.
+	Create(ctx context.Context, u *User) error
+	GetByID(ctx context.Context, uid uuid.UUID) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetAll(ctx context.Context, filter dto.ListInput) ([]User, int, error)
+
+	// AddRole grants role to the user; granting a role twice is a no-op.
+	AddRole(ctx context.Context, uid uuid.UUID, role Role, grantedBy string) error
+	// RemoveRole is a no-op when the user does not have role.
+	RemoveRole(ctx context.Context, uid uuid.UUID, role Role) error
+	// CountWithRole counts the active users of the tenant holding role.
+	CountWithRole(ctx context.Context, tenantID uuid.UUID, role Role) (int, error)
+
+	CreateRefreshToken(ctx context.Context, t *RefreshToken) error
+	// GetRefreshToken returns the token stored under hash, revoked or not,
+	// locking it until the surrounding unit of work ends so two refreshes
+	// with the same token cannot both rotate it.
+	GetRefreshToken(ctx context.Context, hash string) (*RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, uid uuid.UUID, at time.Time) error
+	// RevokeFamily revokes every token of the family not revoked yet.
+	RevokeFamily(ctx context.Context, familyUUID uuid.UUID, at time.Time) error
+}
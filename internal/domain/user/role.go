@@ -0,0 +1,89 @@
+package user
+
+// Role is a named set of permissions granted to users.
+type Role string
+
+const (
+	// RoleAdmin may do everything, including hard deletes and managing users.
+	RoleAdmin Role = "admin"
+	// RoleDispatcher may read the fleet and assign and unassign drivers.
+	RoleDispatcher Role = "dispatcher"
+	// RoleViewer may only read.
+	RoleViewer Role = "viewer"
+)
+
+// Permission is one operation a route or service call requires.
+type Permission string
+
+const (
+	PermDriverRead       Permission = "driver:read"
+	PermDriverWrite      Permission = "driver:write"
+	PermDriverHardDelete Permission = "driver:hard_delete"
+
+	PermVehicleRead       Permission = "vehicle:read"
+	PermVehicleWrite      Permission = "vehicle:write"
+	PermVehicleHardDelete Permission = "vehicle:hard_delete"
+
+	PermAssignmentRead  Permission = "assignment:read"
+	PermAssignmentWrite Permission = "assignment:write"
+
+	PermAuditRead     Permission = "audit:read"
+	PermWebhookManage Permission = "webhook:manage"
+	PermUserManage    Permission = "user:manage"
+	PermAPIKeyManage  Permission = "apikey:manage"
+	PermSystemRead    Permission = "system:read"
+	// PermTenantManage is only honoured for admins of the default tenant.
+	PermTenantManage Permission = "tenant:manage"
+)
+
+var readPermissions = []Permission{PermDriverRead, PermVehicleRead, PermAssignmentRead, PermAuditRead}
+
+// rolePermissions maps every known role to what it grants.
+var rolePermissions = map[Role][]Permission{
+	RoleViewer:     readPermissions,
+	RoleDispatcher: append(append([]Permission{}, readPermissions...), PermAssignmentWrite),
+	RoleAdmin: append(append([]Permission{}, readPermissions...),
+		PermDriverWrite, PermDriverHardDelete,
+		PermVehicleWrite, PermVehicleHardDelete,
+		PermAssignmentWrite,
+		PermWebhookManage, PermUserManage, PermAPIKeyManage, PermSystemRead,
+		PermTenantManage,
+	),
+}
+
+// Roles lists the known roles.
+var Roles = []Role{RoleAdmin, RoleDispatcher, RoleViewer}
+
+func NewRole(raw string) (Role, error) {
+	role := Role(raw)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", ErrUnknownRole
+	}
+	return role, nil
+}
+
+// NewPermission parses a known permission; admins hold every one of them.
+func NewPermission(raw string) (Permission, error) {
+	perm := Permission(raw)
+	if !Can([]Role{RoleAdmin}, perm) {
+		return "", ErrUnknownPermission
+	}
+	return perm, nil
+}
+
+// Permissions returns what role grants; unknown roles grant nothing.
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// Can reports whether any of roles grants perm.
+func Can(roles []Role, perm Permission) bool {
+	for _, role := range roles {
+		for _, p := range role.Permissions() {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolePermissions(t *testing.T) {
+	viewer := []Role{RoleViewer}
+	assert.True(t, Can(viewer, PermDriverRead))
+	assert.False(t, Can(viewer, PermDriverWrite))
+	assert.False(t, Can(viewer, PermAssignmentWrite))
+
+	dispatcher := []Role{RoleDispatcher}
+	assert.True(t, Can(dispatcher, PermAssignmentWrite))
+	assert.False(t, Can(dispatcher, PermDriverWrite))
+	assert.False(t, Can(dispatcher, PermVehicleHardDelete))
+
+	admin := []Role{RoleAdmin}
+	for _, perm := range []Permission{PermDriverHardDelete, PermVehicleHardDelete, PermUserManage, PermAssignmentWrite} {
+		assert.True(t, Can(admin, perm), perm)
+	}
+
+	assert.False(t, Can(nil, PermDriverRead))
+	assert.False(t, Can([]Role{"root"}, PermDriverRead))
+}
+
+func TestNewRole(t *testing.T) {
+	for _, role := range Roles {
+		parsed, err := NewRole(string(role))
+		assert.NoError(t, err)
+		assert.Equal(t, role, parsed)
+	}
+	_, err := NewRole("root")
+	assert.ErrorIs(t, err, ErrUnknownRole)
+
+	_, err = NewUser("admin@example.com", "correct horse", "root")
+	assert.ErrorIs(t, err, ErrUnknownRole)
+	u, err := NewUser("admin@example.com", "correct horse", "dispatcher")
+	assert.NoError(t, err)
+	assert.True(t, u.Can(PermAssignmentWrite))
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is one link of a session's rotation chain. Only the hash of
+// the token is stored. Every refresh revokes the token it used and issues
+// the next one in the same family, so presenting a revoked token means it
+// leaked and the whole family is revoked.
+type RefreshToken struct {
+	Uuid       uuid.UUID
+	UserUUID   uuid.UUID
+	FamilyUUID uuid.UUID
+	TokenHash  string
+	ExpiresAt  time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// NewRefreshToken starts a family when familyUUID is uuid.Nil. The plain
+// token is returned for the client and is not kept anywhere.
+func NewRefreshToken(userUUID, familyUUID uuid.UUID, ttl time.Duration) (*RefreshToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	plain := hex.EncodeToString(b)
+	if familyUUID == uuid.Nil {
+		familyUUID = uuid.New()
+	}
+	now := time.Now()
+	return &RefreshToken{
+		Uuid:       uuid.New(),
+		UserUUID:   userUUID,
+		FamilyUUID: familyUUID,
+		TokenHash:  HashToken(plain),
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}, plain, nil
+}
+
+// HashToken is the lookup key stored for a plain refresh token.
+func HashToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *RefreshToken) Revoked() bool {
+	return !t.RevokedAt.IsZero()
+}
+
+func (t *RefreshToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/moura95/go-ddd/internal/domain/driver"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength is the most bcrypt looks at; longer passwords would
+	// be silently truncated.
+	MaxPasswordLength = 72
+)
+
+// User is an account that can log in to the API.
+type User struct {
+	Uuid uuid.UUID
+	// TenantID is the tenant the user works for; NewUser leaves it for the
+	// caller to set.
+	TenantID     uuid.UUID
+	Email        string
+	PasswordHash string
+	Roles        []Role
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewUser builds an active user with password hashed by bcrypt.
+func NewUser(email, password string, roles ...string) (*User, error) {
+	var fields domainerr.Fields
+	normalized, err := driver.NewEmail(email)
+	if err != nil {
+		fields.Add("email", ErrInvalidEmail)
+	}
+	parsed := make([]Role, 0, len(roles))
+	for _, raw := range roles {
+		role, err := NewRole(raw)
+		if err != nil {
+			fields.Add("roles", ErrUnknownRole)
+			break
+		}
+		parsed = append(parsed, role)
+	}
+	if len(password) < MinPasswordLength {
+		fields.Add("password", ErrPasswordTooShort)
+	} else if len(password) > MaxPasswordLength {
+		fields.Add("password", ErrPasswordTooLong)
+	}
+	if err := fields.Err(); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	return &User{
+		Uuid:         uuid.New(),
+		Email:        normalized.String(),
+		PasswordHash: string(hash),
+		Roles:        parsed,
+		Active:       true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// Can reports whether the user's roles grant perm.
+func (u *User) Can(perm Permission) bool {
+	return Can(u.Roles, perm)
+}
+
+// CheckPassword returns ErrInvalidCredentials unless password matches and
+// the user is active.
+func (u *User) CheckPassword(password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) || (err == nil && !u.Active) {
+		return ErrInvalidCredentials
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUserHashesThePassword(t *testing.T) {
+	u, err := NewUser(" Admin@Example.com ", "correct horse")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@example.com", u.Email)
+	assert.NotContains(t, u.PasswordHash, "correct horse")
+	assert.True(t, u.Active)
+
+	assert.NoError(t, u.CheckPassword("correct horse"))
+	assert.ErrorIs(t, u.CheckPassword("wrong horse"), ErrInvalidCredentials)
+
+	u.Active = false
+	assert.ErrorIs(t, u.CheckPassword("correct horse"), ErrInvalidCredentials)
+}
+
+func TestNewUserValidates(t *testing.T) {
+	_, err := NewUser("not an email", "short")
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+	assert.ErrorIs(t, err, ErrPasswordTooShort)
+
+	_, err = NewUser("admin@example.com", strings.Repeat("x", MaxPasswordLength+1))
+	assert.ErrorIs(t, err, ErrPasswordTooLong)
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	userUUID := uuid.New()
+	first, plain, err := NewRefreshToken(userUUID, uuid.Nil, time.Hour)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, first.FamilyUUID, "a new family is started")
+	assert.Equal(t, HashToken(plain), first.TokenHash)
+	assert.NotEqual(t, plain, first.TokenHash)
+	assert.False(t, first.Revoked())
+	assert.False(t, first.Expired(time.Now()))
+	assert.True(t, first.Expired(time.Now().Add(time.Hour)))
+
+	next, nextPlain, err := NewRefreshToken(userUUID, first.FamilyUUID, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, first.FamilyUUID, next.FamilyUUID)
+	assert.NotEqual(t, plain, nextPlain)
+}
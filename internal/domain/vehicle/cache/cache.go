@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	infracache "github.com/moura95/go-ddd/internal/infra/cache"
+	"github.com/moura95/go-ddd/internal/infra/database"
+)
+
+// Namespaces the hits and misses are counted under.
+const (
+	NamespaceGet  = "vehicle"
+	NamespaceList = "vehicle_list"
+)
+
+const listGeneration = "vehicle:list"
+
+// vehicleRepository caches GetByID and GetAll of another vehicle repository
+// the same way the driver cache does. Vehicles are part of the driver
+// aggregate, so its writes also retire cached drivers.
+type vehicleRepository struct {
+	vehicle.IVehicleRepository
+	cache *infracache.Cache
+}
+
+func NewVehicleRepository(inner vehicle.IVehicleRepository, c *infracache.Cache) vehicle.IVehicleRepository {
+	return &vehicleRepository{IVehicleRepository: inner, cache: c}
+}
+
+type page struct {
+	Vehicles []dto.Output
+	Total    int
+}
+
+func (r *vehicleRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if database.InUnitOfWork(ctx) {
+		return r.IVehicleRepository.GetAll(ctx, filter)
+	}
+	filter.Normalize()
+	key := "vehicle:list:" + infracache.TenantScope(ctx) + ":" + r.cache.Generation(ctx, listGeneration) + ":" + infracache.Hash(filter)
+
+	var cached page
+	if r.cache.Get(ctx, NamespaceList, key, &cached) {
+		return cached.Vehicles, cached.Total, nil
+	}
+	vehicles, total, err := r.IVehicleRepository.GetAll(ctx, filter)
+	if err != nil {
+		return vehicles, total, err
+	}
+	r.cache.Set(ctx, key, page{Vehicles: vehicles, Total: total}, r.cache.ListTTL())
+	return vehicles, total, nil
+}
+
+func (r *vehicleRepository) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if database.InUnitOfWork(ctx) {
+		return r.IVehicleRepository.GetByID(ctx, uid)
+	}
+	key := vehicleKey(ctx, uid)
+
+	var cached dto.Output
+	if r.cache.Get(ctx, NamespaceGet, key, &cached) {
+		return &cached, nil
+	}
+	out, err := r.IVehicleRepository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Set(ctx, key, out, r.cache.TTL())
+	return out, nil
+}
+
+func (r *vehicleRepository) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	uid, err := r.IVehicleRepository.Create(ctx, input)
+	if err == nil {
+		r.invalidate(ctx, uid)
+	}
+	return uid, err
+}
+
+func (r *vehicleRepository) Update(ctx context.Context, input *dto.UpdateInput) error {
+	return r.written(ctx, input.Uuid, r.IVehicleRepository.Update(ctx, input))
+}
+
+func (r *vehicleRepository) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return r.written(ctx, uid, r.IVehicleRepository.HardDelete(ctx, uid, expectedVersion))
+}
+
+func (r *vehicleRepository) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return r.written(ctx, uid, r.IVehicleRepository.SoftDelete(ctx, uid, expectedVersion))
+}
+
+func (r *vehicleRepository) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return r.written(ctx, uid, r.IVehicleRepository.UnDelete(ctx, uid, expectedVersion))
+}
+
+func (r *vehicleRepository) UnRelate(ctx context.Context, vehicleUUID uuid.UUID) error {
+	return r.written(ctx, vehicleUUID, r.IVehicleRepository.UnRelate(ctx, vehicleUUID))
+}
+
+func (r *vehicleRepository) written(ctx context.Context, uid uuid.UUID, err error) error {
+	if err == nil {
+		r.invalidate(ctx, uid)
+	}
+	return err
+}
+
+func (r *vehicleRepository) invalidate(ctx context.Context, uid uuid.UUID) {
+	drop := func() {
+		r.cache.Delete(ctx, vehicleKey(ctx, uid))
+		r.cache.Bump(ctx, listGeneration)
+		r.cache.Bump(ctx, infracache.DriverRelations)
+	}
+	drop()
+	database.AfterCommit(ctx, drop)
+}
+
+func vehicleKey(ctx context.Context, uid uuid.UUID) string {
+	return "vehicle:" + infracache.TenantScope(ctx) + ":" + uid.String()
+}
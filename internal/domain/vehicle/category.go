@@ -0,0 +1,18 @@
+package vehicle
+
+import "strings"
+
+// Category is the CNH category (A to E) required to drive the vehicle.
+type Category string
+
+func NewCategory(raw string) (Category, error) {
+	c := strings.ToUpper(strings.TrimSpace(raw))
+	if len(c) != 1 || c[0] < 'A' || c[0] > 'E' {
+		return "", ErrInvalidCategory
+	}
+	return Category(c), nil
+}
+
+func (c Category) String() string {
+	return string(c)
+}
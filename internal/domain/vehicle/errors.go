@@ -0,0 +1,17 @@
+package vehicle
+
+import "github.com/moura95/go-ddd/internal/domain/domainerr"
+
+var (
+	ErrNotFound          = domainerr.NotFound("vehicle_not_found", "vehicle not found")
+	ErrLicensePlateTaken = domainerr.Conflict("vehicle_license_plate_taken", "license plate is already registered to another vehicle")
+	ErrRenavamTaken      = domainerr.Conflict("vehicle_renavam_taken", "RENAVAM is already registered to another vehicle")
+	ErrVersionMismatch   = domainerr.PreconditionFailed("vehicle_version_mismatch", "vehicle was modified by another request")
+
+	ErrInvalidBrand        = domainerr.Validation("invalid_brand", "invalid brand")
+	ErrInvalidModel        = domainerr.Validation("invalid_model", "invalid model")
+	ErrInvalidLicensePlate = domainerr.Validation("invalid_license_plate", "license plate must be in ABC1234 or ABC1D23 format")
+	ErrInvalidYear         = domainerr.Validation("invalid_year_of_manufacture", "year of manufacture is out of range")
+	ErrInvalidRenavam      = domainerr.Validation("invalid_renavam", "invalid RENAVAM")
+	ErrInvalidCategory     = domainerr.Validation("invalid_category", "category must be one of A, B, C, D or E")
+)
@@ -0,0 +1,46 @@
+package vehicle
+
+import (
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/event"
+)
+
+const AggregateType = "vehicle"
+
+const (
+	EventCreated     = "VehicleCreated"
+	EventUpdated     = "VehicleUpdated"
+	EventSoftDeleted = "VehicleSoftDeleted"
+	EventRestored    = "VehicleRestored"
+	EventDeleted     = "VehicleDeleted"
+)
+
+type payload struct {
+	Uuid              uuid.UUID `json:"uuid"`
+	Brand             string    `json:"brand"`
+	Model             string    `json:"model"`
+	YearOfManufacture uint      `json:"year_of_manufacture"`
+	LicensePlate      string    `json:"license_plate"`
+	Renavam           string    `json:"renavam"`
+	Category          string    `json:"category"`
+	Color             string    `json:"color"`
+	DeletedAt         string    `json:"deleted_at"`
+	Version           int       `json:"version"`
+}
+
+// Raise records one of the vehicle events with the vehicle's current state as
+// its payload.
+func (v *Vehicle) Raise(name string) {
+	v.Record(event.New(name, AggregateType, v.Uuid, payload{
+		Uuid:              v.Uuid,
+		Brand:             v.Brand,
+		Model:             v.Model,
+		YearOfManufacture: v.YearOfManufacture,
+		LicensePlate:      v.LicensePlate,
+		Renavam:           v.Renavam.String,
+		Category:          v.Category.String,
+		Color:             v.Color,
+		DeletedAt:         v.DeletedAt.String,
+		Version:           v.Version,
+	}))
+}
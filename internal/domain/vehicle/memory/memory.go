@@ -1,40 +1,35 @@
 package memory
 
 import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
-	"github.com/moura95/go-ddd/internal/aggregate"
 	"github.com/moura95/go-ddd/internal/domain/vehicle"
-
-	"time"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
 )
 
 type IVehicleRepositoryMemory interface {
-	GetAll() ([]vehicle.Vehicle, error)
-	Create(vehicle.Vehicle) error
-	Subscribe(aggregate.DriverVehicleAggregate) error
-	UnSubscribe(vehicleAggregate aggregate.DriverVehicleAggregate) error
-	GetByID(uuid.UUID) (*vehicle.Vehicle, error)
-	Update(*vehicle.Vehicle) error
-	HardDelete(uuid.UUID) error
-	SoftDelete(uuid.UUID) error
-	UnDelete(uuid.UUID) error
-	UnRelate(vehicleUUID uuid.UUID) error
+	GetAll(ctx context.Context, filter dto.ListInput) ([]vehicle.Vehicle, int, error)
+	Create(ctx context.Context, vehicle vehicle.Vehicle) error
+	GetByID(ctx context.Context, uid uuid.UUID) (*vehicle.Vehicle, error)
+	GetByLicensePlate(ctx context.Context, plates []string) (*vehicle.Vehicle, error)
+	// Update treats input.Version as the expected version; zero skips the check.
+	Update(ctx context.Context, vehicle *vehicle.Vehicle) error
+	HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnRelate(ctx context.Context, vehicleUUID uuid.UUID) error
+	Snapshot() (restore func())
 }
 
 type VehicleRepositoryMemory struct {
 	vehicles []vehicle.Vehicle
 }
 
-func (v VehicleRepositoryMemory) Subscribe(vehicleAggregate aggregate.DriverVehicleAggregate) error {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (v VehicleRepositoryMemory) UnSubscribe(aggregate.DriverVehicleAggregate) error {
-	//TODO implement me
-	panic("implement me")
-}
-
 func NewVehicleRepositoryMemory() IVehicleRepositoryMemory {
 	return &VehicleRepositoryMemory{vehicles: []vehicle.Vehicle{
 		{
@@ -46,6 +41,7 @@ func NewVehicleRepositoryMemory() IVehicleRepositoryMemory {
 			Color:             "Blue",
 			CreatedAt:         time.Now(),
 			UpdatedAt:         time.Now(),
+			Version:           1,
 		},
 		{
 			Uuid:              uuid.MustParse("457a8df2-782f-4f22-8233-623b694096a1"),
@@ -56,70 +52,165 @@ func NewVehicleRepositoryMemory() IVehicleRepositoryMemory {
 			Color:             "Black",
 			CreatedAt:         time.Now(),
 			UpdatedAt:         time.Now(),
+			Version:           1,
 		}}}
 }
 
-func (v VehicleRepositoryMemory) GetAll() ([]vehicle.Vehicle, error) {
-	return v.vehicles, nil
+func (v *VehicleRepositoryMemory) GetAll(ctx context.Context, filter dto.ListInput) ([]vehicle.Vehicle, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+
+	matched := make([]vehicle.Vehicle, 0, len(v.vehicles))
+	for _, ve := range v.vehicles {
+		if ve.DeletedAt.Valid {
+			continue
+		}
+		if filter.Brand != "" && !containsFold(ve.Brand, filter.Brand) {
+			continue
+		}
+		if filter.Model != "" && !containsFold(ve.Model, filter.Model) {
+			continue
+		}
+		if filter.Color != "" && !strings.EqualFold(ve.Color, filter.Color) {
+			continue
+		}
+		if filter.YearFrom > 0 && ve.YearOfManufacture < filter.YearFrom {
+			continue
+		}
+		if filter.YearTo > 0 && ve.YearOfManufacture > filter.YearTo {
+			continue
+		}
+		if filter.LicensePlatePrefix != "" &&
+			!strings.HasPrefix(strings.ToUpper(ve.LicensePlate), vehicle.NormalizePlate(filter.LicensePlatePrefix)) {
+			continue
+		}
+		matched = append(matched, ve)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if filter.Descending() {
+			a, b = b, a
+		}
+		switch filter.SortBy {
+		case dto.SortByBrand:
+			return a.Brand < b.Brand
+		case dto.SortByModel:
+			return a.Model < b.Model
+		case dto.SortByYearOfManufacture:
+			return a.YearOfManufacture < b.YearOfManufacture
+		case dto.SortByLicensePlate:
+			return a.LicensePlate < b.LicensePlate
+		case dto.SortByUpdatedAt:
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		default:
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	})
+
+	start, end := filter.Window(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
 
-func (v VehicleRepositoryMemory) Create(vehicle vehicle.Vehicle) error {
+func (v *VehicleRepositoryMemory) Create(ctx context.Context, vehicle vehicle.Vehicle) error {
 	v.vehicles = append(v.vehicles, vehicle)
 	return nil
 }
 
-func (v VehicleRepositoryMemory) GetByID(u uuid.UUID) (*vehicle.Vehicle, error) {
+func (v *VehicleRepositoryMemory) GetByID(ctx context.Context, u uuid.UUID) (*vehicle.Vehicle, error) {
 	for _, vehicle := range v.vehicles {
 		if vehicle.Uuid == u {
 			return &vehicle, nil
 		}
 	}
-	return nil, nil
+	return nil, vehicle.ErrNotFound
+}
+
+func (v *VehicleRepositoryMemory) GetByLicensePlate(ctx context.Context, plates []string) (*vehicle.Vehicle, error) {
+	for _, ve := range v.vehicles {
+		for _, plate := range plates {
+			if ve.LicensePlate == plate {
+				return &ve, nil
+			}
+		}
+	}
+	return nil, vehicle.ErrNotFound
 }
 
-func (v VehicleRepositoryMemory) Update(vehicle *vehicle.Vehicle) error {
+func (v *VehicleRepositoryMemory) Update(ctx context.Context, input *vehicle.Vehicle) error {
 	for i, ve := range v.vehicles {
-		if ve.Uuid == vehicle.Uuid {
-			v.vehicles[i] = *vehicle
+		if ve.Uuid == input.Uuid {
+			if !versionMatches(ve, input.Version) {
+				return vehicle.ErrVersionMismatch
+			}
+			updated := *input
+			updated.Version = ve.Version + 1
+			v.vehicles[i] = updated
 			return nil
 		}
 	}
-	return nil
+	return vehicle.ErrNotFound
 }
 
-func (v VehicleRepositoryMemory) HardDelete(u uuid.UUID) error {
-	for i, vehicle := range v.vehicles {
-		if vehicle.Uuid == u {
+func (v *VehicleRepositoryMemory) HardDelete(ctx context.Context, u uuid.UUID, expectedVersion int) error {
+	for i, ve := range v.vehicles {
+		if ve.Uuid == u {
+			if !versionMatches(ve, expectedVersion) {
+				return vehicle.ErrVersionMismatch
+			}
 			v.vehicles = append(v.vehicles[:i], v.vehicles[i+1:]...)
 			return nil
 		}
 	}
-	return nil
+	return vehicle.ErrNotFound
 }
 
-func (v VehicleRepositoryMemory) SoftDelete(u uuid.UUID) error {
-	for i, vehicle := range v.vehicles {
-		if vehicle.Uuid == u {
-			vehicle.DeletedAt.String = time.Now().String()
-			v.vehicles[i] = vehicle
+func (v *VehicleRepositoryMemory) SoftDelete(ctx context.Context, u uuid.UUID, expectedVersion int) error {
+	for i, ve := range v.vehicles {
+		if ve.Uuid == u {
+			if !versionMatches(ve, expectedVersion) {
+				return vehicle.ErrVersionMismatch
+			}
+			ve.DeletedAt = sql.NullString{String: time.Now().String(), Valid: true}
+			ve.Version++
+			v.vehicles[i] = ve
 			return nil
 		}
 	}
-	return nil
+	return vehicle.ErrNotFound
 }
 
-func (v VehicleRepositoryMemory) UnDelete(u uuid.UUID) error {
-	for i, vehicle := range v.vehicles {
-		if vehicle.Uuid == u {
-			vehicle.DeletedAt.String = ""
-			v.vehicles[i] = vehicle
+func (v *VehicleRepositoryMemory) UnDelete(ctx context.Context, u uuid.UUID, expectedVersion int) error {
+	for i, ve := range v.vehicles {
+		if ve.Uuid == u {
+			if !versionMatches(ve, expectedVersion) {
+				return vehicle.ErrVersionMismatch
+			}
+			ve.DeletedAt = sql.NullString{}
+			ve.Version++
+			v.vehicles[i] = ve
 			return nil
 		}
 	}
+	return vehicle.ErrNotFound
+}
+
+func versionMatches(ve vehicle.Vehicle, expected int) bool {
+	return expected == 0 || ve.Version == expected
+}
+
+func (v *VehicleRepositoryMemory) UnRelate(ctx context.Context, vehicleUUID uuid.UUID) error {
+	// assignments live in the assignment repository
 	return nil
 }
 
-func (v VehicleRepositoryMemory) UnRelate(vehicleUUID uuid.UUID) error {
-	//TODO implement me
-	panic("implement me")
+func (v *VehicleRepositoryMemory) Snapshot() func() {
+	saved := append([]vehicle.Vehicle(nil), v.vehicles...)
+	return func() { v.vehicles = saved }
 }
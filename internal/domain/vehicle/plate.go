@@ -0,0 +1,90 @@
+package vehicle
+
+import (
+	"regexp"
+	"strings"
+)
+
+type PlateFormat int
+
+const (
+	// PlateFormatLegacy is the pre-2018 Brazilian format, e.g. ABC1234.
+	PlateFormatLegacy PlateFormat = iota
+	// PlateFormatMercosul is the Mercosul format, e.g. ABC1D23.
+	PlateFormatMercosul
+)
+
+var (
+	legacyPlate   = regexp.MustCompile(`^[A-Z]{3}[0-9]{4}$`)
+	mercosulPlate = regexp.MustCompile(`^[A-Z]{3}[0-9][A-Z][0-9]{2}$`)
+	plateNoise    = strings.NewReplacer("-", "", " ", "", ".", "")
+)
+
+// LicensePlate is an upper-cased plate without punctuation, in either the
+// legacy or the Mercosul format.
+type LicensePlate string
+
+func NewLicensePlate(raw string) (LicensePlate, error) {
+	normalized := NormalizePlate(raw)
+	if !legacyPlate.MatchString(normalized) && !mercosulPlate.MatchString(normalized) {
+		return "", ErrInvalidLicensePlate
+	}
+	return LicensePlate(normalized), nil
+}
+
+// NormalizePlate spells raw, a plate or a part of one, the way plates are
+// stored: upper-cased, without dashes, spaces or dots.
+func NormalizePlate(raw string) string {
+	return strings.ToUpper(plateNoise.Replace(strings.TrimSpace(raw)))
+}
+
+func (p LicensePlate) String() string {
+	return string(p)
+}
+
+func (p LicensePlate) Format() PlateFormat {
+	if mercosulPlate.MatchString(string(p)) {
+		return PlateFormatMercosul
+	}
+	return PlateFormatLegacy
+}
+
+// Mercosul returns the plate in Mercosul format. Legacy plates are converted
+// by replacing the fifth character's digit with the letter of the same index
+// (0 -> A ... 9 -> J), as DENATRAN does when a vehicle is re-plated.
+func (p LicensePlate) Mercosul() LicensePlate {
+	if p.Format() == PlateFormatMercosul {
+		return p
+	}
+	b := []byte(p)
+	b[4] = 'A' + (b[4] - '0')
+	return LicensePlate(b)
+}
+
+// Legacy returns the plate in legacy format. It reports false for Mercosul
+// plates whose fifth letter has no digit equivalent (K-Z), which were issued
+// new and never existed in the old format.
+func (p LicensePlate) Legacy() (LicensePlate, bool) {
+	if p.Format() == PlateFormatLegacy {
+		return p, true
+	}
+	b := []byte(p)
+	if b[4] > 'J' {
+		return "", false
+	}
+	b[4] = '0' + (b[4] - 'A')
+	return LicensePlate(b), true
+}
+
+// Equivalents returns every spelling under which the same vehicle may have
+// been registered.
+func (p LicensePlate) Equivalents() []LicensePlate {
+	plates := []LicensePlate{p}
+	if p.Format() == PlateFormatLegacy {
+		return append(plates, p.Mercosul())
+	}
+	if legacy, ok := p.Legacy(); ok {
+		plates = append(plates, legacy)
+	}
+	return plates
+}
@@ -1,15 +1,20 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
 	"github.com/moura95/go-ddd/internal/domain/vehicle"
 	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/database"
 	"go.uber.org/zap"
 )
 
@@ -22,92 +27,266 @@ func NewVehicleRepository(db *sqlx.DB, log *zap.SugaredLogger) vehicle.IVehicleR
 	return &vehicleRepository{db: db, logger: log}
 }
 
-func (r *vehicleRepository) GetAll() ([]dto.Output, error) {
-	var vehicles []dto.Output
-	query := "SELECT * FROM vehicles WHERE deleted_at is null"
-	if err := r.db.Select(&vehicles, query); err != nil {
-		return nil, err
+// conn returns the transaction carried by ctx or the pool. As in the driver
+// repository, tenant filtering is up to each method.
+func (r *vehicleRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const vehicleColumns = "uuid, brand, model, year_of_manufacture, license_plate, renavam, category, color, deleted_at, version, created_at, update_at"
+
+var vehicleSortColumns = map[string]string{
+	dto.SortByBrand:             "brand",
+	dto.SortByModel:             "model",
+	dto.SortByYearOfManufacture: "year_of_manufacture",
+	dto.SortByLicensePlate:      "license_plate",
+	dto.SortByCreatedAt:         "created_at",
+	dto.SortByUpdatedAt:         "update_at",
+}
+
+func (r *vehicleRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+
+	conditions := []string{"tenant_id = $1", "deleted_at is null"}
+	args := []interface{}{tenantID}
+	if filter.Brand != "" {
+		args = append(args, database.Contains(filter.Brand))
+		conditions = append(conditions, fmt.Sprintf("brand ILIKE $%d ESCAPE '\\'", len(args)))
+	}
+	if filter.Model != "" {
+		args = append(args, database.Contains(filter.Model))
+		conditions = append(conditions, fmt.Sprintf("model ILIKE $%d ESCAPE '\\'", len(args)))
+	}
+	if filter.Color != "" {
+		args = append(args, database.EscapeLike(filter.Color))
+		conditions = append(conditions, fmt.Sprintf("color ILIKE $%d ESCAPE '\\'", len(args)))
+	}
+	if filter.YearFrom > 0 {
+		args = append(args, filter.YearFrom)
+		conditions = append(conditions, fmt.Sprintf("year_of_manufacture >= $%d", len(args)))
+	}
+	if filter.YearTo > 0 {
+		args = append(args, filter.YearTo)
+		conditions = append(conditions, fmt.Sprintf("year_of_manufacture <= $%d", len(args)))
+	}
+	if filter.LicensePlatePrefix != "" {
+		args = append(args, database.EscapeLike(vehicle.NormalizePlate(filter.LicensePlatePrefix))+"%")
+		conditions = append(conditions, fmt.Sprintf("upper(license_plate) LIKE $%d ESCAPE '\\'", len(args)))
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM vehicles"+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn, ok := vehicleSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = "created_at"
+	}
+	order := "ASC"
+	if filter.Descending() {
+		order = "DESC"
+	}
+
+	args = append(args, filter.PageSize, filter.Offset())
+	query := fmt.Sprintf("SELECT %s FROM vehicles%s ORDER BY %s %s, uuid LIMIT $%d OFFSET $%d",
+		vehicleColumns, where, sortColumn, order, len(args)-1, len(args))
+
+	vehicles := []dto.Output{}
+	if err := r.conn(ctx).SelectContext(ctx, &vehicles, query, args...); err != nil {
+		return nil, 0, err
 	}
-	return vehicles, nil
+	return vehicles, total, nil
 }
 
-func (r *vehicleRepository) Create(dto dto.CreateInput) error {
-	ve := vehicle.NewVehicle(dto.Brand, dto.Model, dto.LicensePlate, dto.Color, dto.YearOfManufacture)
-	err := ve.Validate()
+func (r *vehicleRepository) Create(ctx context.Context, dto dto.CreateInput) (uuid.UUID, error) {
+	tenantID, err := tenant.Require(ctx)
 	if err != nil {
-		log.Fatal(err)
+		return uuid.Nil, err
+	}
+	ve, err := vehicle.NewVehicle(dto.Brand, dto.Model, dto.LicensePlate, dto.Color, dto.Renavam, dto.Category, dto.YearOfManufacture)
+	if err != nil {
+		return uuid.Nil, err
 	}
 	query := `
-        INSERT INTO vehicles (brand, model, year_of_manufacture, license_plate, color)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO vehicles (uuid, tenant_id, brand, model, year_of_manufacture, license_plate, renavam, category, color)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
     `
 	args := []interface{}{
+		ve.Uuid,
+		tenantID,
 		ve.Brand,
 		ve.Model,
 		ve.YearOfManufacture,
 		ve.LicensePlate,
+		ve.Renavam,
+		ve.Category,
 		ve.Color,
 	}
-	_, err = r.db.Exec(query, args...)
+	_, err = r.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return uuid.Nil, vehicleError(err)
 	}
 
-	return nil
+	return ve.Uuid, nil
+}
+func (r *vehicleRepository) GetByID(ctx context.Context, uuid uuid.UUID) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var v dto.Output
+	err = r.conn(ctx).GetContext(ctx, &v, "SELECT "+vehicleColumns+" FROM vehicles WHERE uuid = $1 AND tenant_id = $2", uuid, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, vehicle.ErrNotFound
+		}
+		return nil, err
+	}
+	return &v, nil
 }
-func (r *vehicleRepository) GetByID(uuid uuid.UUID) (*dto.Output, error) {
+
+func (r *vehicleRepository) GetByLicensePlate(ctx context.Context, plates []string) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var v dto.Output
-	err := r.db.Get(&v, "SELECT * FROM vehicles WHERE uuid = $1", uuid)
+	query := "SELECT " + vehicleColumns + " FROM vehicles WHERE license_plate = ANY($1) AND tenant_id = $2 ORDER BY deleted_at NULLS FIRST LIMIT 1"
+	err = r.conn(ctx).GetContext(ctx, &v, query, pq.Array(plates), tenantID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil // Driver not found
+			return nil, vehicle.ErrNotFound
 		}
 		return nil, err
 	}
 	return &v, nil
 }
 
-func (r *vehicleRepository) Update(vehicle *dto.UpdateInput) error {
+func (r *vehicleRepository) Update(ctx context.Context, input *dto.UpdateInput) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
 	query := `
         UPDATE vehicles 
-        SET brand=$2, model=$3, year_of_manufacture=$4, license_plate=$5, color=$6, update_at=$7
-        WHERE uuid=$1
+        SET brand=$2, model=$3, year_of_manufacture=$4, license_plate=$5, renavam=$6, category=$7, color=$8, update_at=$9,
+            version=version+1
+        WHERE uuid=$1 AND tenant_id = $11 AND ($10 = 0 OR version = $10)
     `
+	ve, err := vehicle.NewVehicle(input.Brand, input.Model, input.LicensePlate, input.Color, input.Renavam, input.Category, input.YearOfManufacture)
+	if err != nil {
+		return err
+	}
+
 	args := []interface{}{
-		vehicle.Uuid,
-		vehicle.Brand,
-		vehicle.Model,
-		vehicle.YearOfManufacture,
-		vehicle.LicensePlate,
-		vehicle.Color,
+		input.Uuid,
+		ve.Brand,
+		ve.Model,
+		ve.YearOfManufacture,
+		ve.LicensePlate,
+		ve.Renavam,
+		ve.Category,
+		ve.Color,
 		time.Now(),
+		input.ExpectedVersion,
+		tenantID,
+	}
+	res, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return vehicleError(err)
+	}
+	return r.expectVersioned(ctx, res, input.Uuid, tenantID)
+}
+
+func (r *vehicleRepository) HardDelete(ctx context.Context, uuid uuid.UUID, expectedVersion int) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	query := "DELETE FROM vehicles WHERE uuid = :UUID AND tenant_id = :TenantID AND (:Version = 0 OR version = :Version)"
+	res, err := r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"UUID": uuid, "TenantID": tenantID, "Version": expectedVersion})
+	if err != nil {
+		return err
+	}
+	return r.expectVersioned(ctx, res, uuid, tenantID)
+}
+func (r *vehicleRepository) SoftDelete(ctx context.Context, uuid uuid.UUID, expectedVersion int) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	_, err := r.db.Exec(query, args...)
+	query := "UPDATE vehicles SET deleted_at=now(), version=version+1 WHERE uuid = :UUID AND tenant_id = :TenantID AND (:Version = 0 OR version = :Version)"
+	res, err := r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"UUID": uuid, "TenantID": tenantID, "Version": expectedVersion})
 	if err != nil {
 		return err
 	}
-	return nil
+	return r.expectVersioned(ctx, res, uuid, tenantID)
 }
 
-func (r *vehicleRepository) HardDelete(uuid uuid.UUID) error {
-	query := "DELETE FROM vehicles WHERE uuid = :UUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"UUID": uuid})
-	return err
+func (r *vehicleRepository) UnDelete(ctx context.Context, uuid uuid.UUID, expectedVersion int) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	query := "UPDATE vehicles SET deleted_at=null, version=version+1 WHERE uuid = :UUID AND tenant_id = :TenantID AND (:Version = 0 OR version = :Version)"
+	res, err := r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"UUID": uuid, "TenantID": tenantID, "Version": expectedVersion})
+	if err != nil {
+		return err
+	}
+	return r.expectVersioned(ctx, res, uuid, tenantID)
+}
+
+// expectVersioned checks the write went through and then touches the
+// vehicle's drivers, whose representations embed it.
+func (r *vehicleRepository) expectVersioned(ctx context.Context, res sql.Result, uid, tenantID uuid.UUID) error {
+	err := database.ExpectVersioned(ctx, r.conn(ctx), res, "vehicles", uid, tenantID, vehicle.ErrNotFound, vehicle.ErrVersionMismatch)
+	if err != nil {
+		return err
+	}
+	return r.touchDrivers(ctx, uid, tenantID)
 }
-func (r *vehicleRepository) SoftDelete(uuid uuid.UUID) error {
-	query := "UPDATE vehicles SET deleted_at=now() WHERE uuid = :UUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"UUID": uuid})
+
+// touchDrivers bumps the version of every driver currently assigned to the
+// vehicle, so their ETags change along with the vehicle they list.
+func (r *vehicleRepository) touchDrivers(ctx context.Context, vehicleUUID, tenantID uuid.UUID) error {
+	query := `
+        UPDATE drivers SET version = version + 1
+        WHERE tenant_id = $2 AND uuid IN (
+            SELECT driver_uuid FROM drivers_vehicles
+            WHERE vehicle_uuid = $1 AND tenant_id = $2 AND unassigned_at IS NULL)
+    `
+	_, err := r.conn(ctx).ExecContext(ctx, query, vehicleUUID, tenantID)
 	return err
 }
 
-func (r *vehicleRepository) UnDelete(uuid uuid.UUID) error {
-	query := "UPDATE vehicles SET deleted_at=null WHERE uuid = :UUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"UUID": uuid})
+func (r *vehicleRepository) UnRelate(ctx context.Context, vehicleUUID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.touchDrivers(ctx, vehicleUUID, tenantID); err != nil {
+		return err
+	}
+	query := "UPDATE drivers_vehicles SET unassigned_at=now() WHERE vehicle_uuid = :VehicleUUID AND tenant_id = :TenantID AND unassigned_at IS NULL"
+	_, err = r.conn(ctx).NamedExecContext(ctx, query, map[string]interface{}{"VehicleUUID": vehicleUUID, "TenantID": tenantID})
 	return err
 }
 
-func (r *vehicleRepository) UnRelate(vehicleUUID uuid.UUID) error {
-	query := "DELETE FROM drivers_vehicles WHERE vehicle_uuid = :VehicleUUID"
-	_, err := r.db.NamedExec(query, map[string]interface{}{"VehicleUUID": vehicleUUID})
+// vehicleError translates constraint violations into domain errors.
+func vehicleError(err error) error {
+	if constraint, ok := database.UniqueViolation(err); ok {
+		switch constraint {
+		case "vehicles_license_plate_key":
+			return vehicle.ErrLicensePlateTaken
+		case "vehicles_renavam_key":
+			return vehicle.ErrRenavamTaken
+		}
+	}
 	return err
 }
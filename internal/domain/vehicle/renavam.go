@@ -0,0 +1,44 @@
+package vehicle
+
+// Renavam is the 11-digit national vehicle registry number
+// (Registro Nacional de Veículos Automotores).
+type Renavam string
+
+var renavamWeights = [10]int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+func NewRenavam(raw string) (Renavam, error) {
+	var digits []byte
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, byte(r))
+		case r == ' ' || r == '.' || r == '-':
+		default:
+			return "", ErrInvalidRenavam
+		}
+	}
+	// numbers issued before 2013 had 9 digits and are left-padded with zeros
+	if len(digits) == 9 {
+		digits = append([]byte("00"), digits...)
+	}
+	if len(digits) != 11 {
+		return "", ErrInvalidRenavam
+	}
+
+	sum := 0
+	for i, w := range renavamWeights {
+		sum += int(digits[i]-'0') * w
+	}
+	check := sum * 10 % 11
+	if check == 10 {
+		check = 0
+	}
+	if int(digits[10]-'0') != check {
+		return "", ErrInvalidRenavam
+	}
+	return Renavam(digits), nil
+}
+
+func (r Renavam) String() string {
+	return string(r)
+}
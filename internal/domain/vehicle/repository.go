@@ -1,17 +1,20 @@
 package vehicle
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/moura95/go-ddd/internal/dtos/vehicle"
 )
 
 type IVehicleRepository interface {
-	GetAll() ([]vehicle.Output, error)
-	Create(input vehicle.CreateInput) error
-	GetByID(uuid.UUID) (*vehicle.Output, error)
-	Update(input *vehicle.UpdateInput) error
-	HardDelete(uuid.UUID) error
-	SoftDelete(uuid.UUID) error
-	UnDelete(uuid.UUID) error
-	UnRelate(uuid.UUID) error
+	GetAll(ctx context.Context, filter vehicle.ListInput) ([]vehicle.Output, int, error)
+	Create(ctx context.Context, input vehicle.CreateInput) (uuid.UUID, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*vehicle.Output, error)
+	GetByLicensePlate(ctx context.Context, plates []string) (*vehicle.Output, error)
+	Update(ctx context.Context, input *vehicle.UpdateInput) error
+	HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnRelate(ctx context.Context, vehicleUUID uuid.UUID) error
 }
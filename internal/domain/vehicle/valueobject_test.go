@@ -0,0 +1,72 @@
+package vehicle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLicensePlate(t *testing.T) {
+	valid := map[string]string{
+		"ABC1234":   "ABC1234",
+		"abc-1234":  "ABC1234",
+		" abc1d23 ": "ABC1D23",
+		"BRA.2E19":  "BRA2E19",
+	}
+	for raw, want := range valid {
+		plate, err := NewLicensePlate(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, want, plate.String())
+	}
+	for _, raw := range []string{"", "ABC123", "AB12345", "ABC12D3", "ABCD123", "ABC1D2E"} {
+		_, err := NewLicensePlate(raw)
+		assert.ErrorIs(t, err, ErrInvalidLicensePlate, raw)
+	}
+}
+
+func TestNormalizePlate(t *testing.T) {
+	assert.Equal(t, "ABC1", NormalizePlate(" abc-1"))
+	assert.Equal(t, "BRA2E", NormalizePlate("bra.2 e"))
+	assert.Equal(t, "A_%", NormalizePlate("a_%"), "only punctuation is dropped")
+}
+
+func TestLicensePlateConversion(t *testing.T) {
+	legacy := LicensePlate("ABC1234")
+	assert.Equal(t, PlateFormatLegacy, legacy.Format())
+	assert.Equal(t, LicensePlate("ABC1C34"), legacy.Mercosul())
+	assert.Equal(t, []LicensePlate{"ABC1234", "ABC1C34"}, legacy.Equivalents())
+
+	mercosul := LicensePlate("ABC1J34")
+	assert.Equal(t, PlateFormatMercosul, mercosul.Format())
+	back, ok := mercosul.Legacy()
+	assert.True(t, ok)
+	assert.Equal(t, LicensePlate("ABC1934"), back)
+
+	newOnly := LicensePlate("ABC1K34")
+	_, ok = newOnly.Legacy()
+	assert.False(t, ok)
+	assert.Equal(t, []LicensePlate{"ABC1K34"}, newOnly.Equivalents())
+}
+
+func TestNewRenavam(t *testing.T) {
+	for raw, want := range map[string]string{"63957540300": "63957540300", "639575404": "00639575404"} {
+		renavam, err := NewRenavam(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, want, renavam.String())
+	}
+	for _, raw := range []string{"", "63957540301", "1234", "6395754030A"} {
+		_, err := NewRenavam(raw)
+		assert.ErrorIs(t, err, ErrInvalidRenavam, raw)
+	}
+}
+
+func TestNewCategory(t *testing.T) {
+	category, err := NewCategory(" c ")
+	assert.NoError(t, err)
+	assert.Equal(t, "C", category.String())
+
+	for _, raw := range []string{"", "F", "AB"} {
+		_, err := NewCategory(raw)
+		assert.ErrorIs(t, err, ErrInvalidCategory, raw)
+	}
+}
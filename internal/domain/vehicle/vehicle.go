@@ -2,45 +2,109 @@ package vehicle
 
 import (
 	"database/sql"
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/moura95/go-ddd/internal/domain/event"
 )
 
+// MinYearOfManufacture is the oldest model year accepted for a fleet vehicle.
+// The newest is next year, since manufacturers release models a year ahead.
+const MinYearOfManufacture = 1900
+
+// now is swapped in tests to pin the reference year.
+var now = time.Now
+
 type Vehicle struct {
 	Uuid              uuid.UUID
 	Brand             string
 	Model             string
 	YearOfManufacture uint
 	LicensePlate      string
+	Renavam           sql.NullString
+	Category          sql.NullString
 	Color             string
 	DeletedAt         sql.NullString
+	Version           int
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
+
+	event.Recorder
 }
 
-func NewVehicle(brand, model, licensePlate, color string, yearOfManufacture uint) *Vehicle {
-	return &Vehicle{
+// NewVehicle builds a vehicle, validates it and normalizes its plate and
+// RENAVAM. Every invalid field is returned at once.
+func NewVehicle(brand, model, licensePlate, color, renavam, category string, yearOfManufacture uint) (*Vehicle, error) {
+	v := &Vehicle{
 		Uuid:              uuid.New(),
+		Version:           1,
 		Brand:             brand,
 		Model:             model,
 		YearOfManufacture: yearOfManufacture,
 		LicensePlate:      licensePlate,
-		Color:             color,
+		Renavam: sql.NullString{
+			String: renavam,
+			Valid:  renavam != "",
+		},
+		Category: sql.NullString{
+			String: category,
+			Valid:  category != "",
+		},
+		Color: color,
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
 	}
+	v.normalize()
+	return v, nil
+}
+
+func MaxYearOfManufacture() uint {
+	return uint(now().Year() + 1)
 }
 
 func (v *Vehicle) Validate() error {
-	if v.Brand == "" {
-		return errors.New("invalid brand")
+	var fields domainerr.Fields
+	if strings.TrimSpace(v.Brand) == "" {
+		fields.Add("brand", ErrInvalidBrand)
+	}
+	if strings.TrimSpace(v.Model) == "" {
+		fields.Add("model", ErrInvalidModel)
 	}
-	if v.Model == "" {
-		return errors.New("invalid model")
+	if _, err := NewLicensePlate(v.LicensePlate); err != nil {
+		fields.Add("license_plate", ErrInvalidLicensePlate)
 	}
-	if v.LicensePlate == "" {
-		return errors.New("invalid license plate")
+	if v.YearOfManufacture < MinYearOfManufacture || v.YearOfManufacture > MaxYearOfManufacture() {
+		fields.Add("year_of_manufacture", ErrInvalidYear)
 	}
-	return nil
+	if v.Renavam.Valid {
+		if _, err := NewRenavam(v.Renavam.String); err != nil {
+			fields.Add("renavam", ErrInvalidRenavam)
+		}
+	}
+	if v.Category.Valid {
+		if _, err := NewCategory(v.Category.String); err != nil {
+			fields.Add("category", ErrInvalidCategory)
+		}
+	}
+	return fields.Err()
+
+}
 
+// normalize rewrites already validated fields into their canonical form.
+func (v *Vehicle) normalize() {
+	v.Brand = strings.TrimSpace(v.Brand)
+	v.Model = strings.TrimSpace(v.Model)
+	v.Color = strings.TrimSpace(v.Color)
+	if plate, err := NewLicensePlate(v.LicensePlate); err == nil {
+		v.LicensePlate = plate.String()
+	}
+	if renavam, err := NewRenavam(v.Renavam.String); err == nil {
+		v.Renavam.String = renavam.String()
+	}
+	if category, err := NewCategory(v.Category.String); err == nil {
+		v.Category.String = category.String()
+	}
 }
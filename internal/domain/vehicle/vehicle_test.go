@@ -1,56 +1,62 @@
 package vehicle
 
 import (
-	"fmt"
-	"github.com/magiconair/properties/assert"
+	"errors"
 	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
 )
 
 func TestNewVehicle(t *testing.T) {
-	vehicle := NewVehicle("Scania", "R500", "ABC123", "Blue", 2020)
+	vehicle, err := NewVehicle("Scania", "R500", "ABC1234", "Blue", "", "", 2020)
+	assert.Equal(t, err, nil)
 	assert.Equal(t, "Scania", vehicle.Brand)
 	assert.Equal(t, "R500", vehicle.Model)
-	assert.Equal(t, "ABC123", vehicle.LicensePlate)
+	assert.Equal(t, "ABC1234", vehicle.LicensePlate)
 	assert.Equal(t, "Blue", vehicle.Color)
-	err := vehicle.validate()
-
-	if err != nil {
-		fmt.Println("Success TestNewVehicleWithOutBrand")
-	}
 }
 
 func TestNewVehicleWithOutBrand(t *testing.T) {
-	vehicle := NewVehicle("", "R500", "ABC123", "Blue", 2020)
-	assert.Equal(t, "", vehicle.Brand)
-	assert.Equal(t, "R500", vehicle.Model)
-	assert.Equal(t, "ABC123", vehicle.LicensePlate)
-	assert.Equal(t, "Blue", vehicle.Color)
-	err := vehicle.validate()
-	if err.Error() == "invalid brand" {
-		fmt.Println("Success TestNewVehicleWithOutBrand")
-	}
+	vehicle, err := NewVehicle("", "R500", "ABC1234", "Blue", "", "", 2020)
+	assert.Equal(t, vehicle == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidBrand), true)
 }
 
 func TestNewVehicleWithOutModel(t *testing.T) {
-	vehicle := NewVehicle("Scania", "", "ABC123", "Blue", 2020)
-	assert.Equal(t, "Scania", vehicle.Brand)
-	assert.Equal(t, "", vehicle.Model)
-	assert.Equal(t, "ABC123", vehicle.LicensePlate)
-	assert.Equal(t, "Blue", vehicle.Color)
-	err := vehicle.validate()
-	if err.Error() == "invalid model" {
-		fmt.Println("Success TestNewVehicleWithOutModel")
-	}
+	vehicle, err := NewVehicle("Scania", "", "ABC1234", "Blue", "", "", 2020)
+	assert.Equal(t, vehicle == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidModel), true)
 }
 
 func TestNewVehicleWithOutLicensePlate(t *testing.T) {
-	vehicle := NewVehicle("Scania", "R500", "", "Blue", 2020)
-	assert.Equal(t, "Scania", vehicle.Brand)
-	assert.Equal(t, "R500", vehicle.Model)
-	assert.Equal(t, "", vehicle.LicensePlate)
-	assert.Equal(t, "Blue", vehicle.Color)
-	err := vehicle.validate()
-	if err.Error() == "invalid license plate" {
-		fmt.Println("Success TestNewVehicleWithOutLicensePlate")
-	}
+	vehicle, err := NewVehicle("Scania", "R500", "", "Blue", "", "", 2020)
+	assert.Equal(t, vehicle == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidLicensePlate), true)
+}
+
+func TestNewVehicleReportsEveryInvalidField(t *testing.T) {
+	_, err := NewVehicle("", "", "", "Blue", "123", "", 1800)
+	e, ok := domainerr.As(err)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, e.Kind, domainerr.KindValidation)
+	assert.Equal(t, len(e.Fields), 5)
+}
+
+func TestNewVehicleNormalizesPlateAndRenavam(t *testing.T) {
+	vehicle, err := NewVehicle(" Volvo ", "FH16", "abc-1d23", "Black", "639.575.404", "c", 2019)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, vehicle.Brand, "Volvo")
+	assert.Equal(t, vehicle.LicensePlate, "ABC1D23")
+	assert.Equal(t, vehicle.Renavam.String, "00639575404")
+	assert.Equal(t, vehicle.Renavam.Valid, true)
+	assert.Equal(t, vehicle.Category.String, "C")
+}
+
+func TestNewVehicleYearRange(t *testing.T) {
+	_, err := NewVehicle("Volvo", "FH16", "ABC1234", "Black", "", "", MaxYearOfManufacture()+1)
+	assert.Equal(t, errors.Is(err, ErrInvalidYear), true)
+
+	_, err = NewVehicle("Volvo", "FH16", "ABC1234", "Black", "", "", MaxYearOfManufacture())
+	assert.Equal(t, err, nil)
 }
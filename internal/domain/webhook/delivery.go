@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+)
+
+type Status string
+
+const (
+	// StatusPending deliveries have not been attempted yet.
+	StatusPending Status = "pending"
+	// StatusFailed deliveries failed at least once and wait for a retry.
+	StatusFailed    Status = "failed"
+	StatusSucceeded Status = "succeeded"
+	// StatusDead deliveries ran out of attempts; they stay in the log until
+	// someone sends them again.
+	StatusDead Status = "dead"
+)
+
+const (
+	firstRetryDelay = 10 * time.Second
+	maxRetryDelay   = time.Hour
+)
+
+// Delivery is one event on its way to one subscription, and the log of how
+// the attempts went.
+type Delivery struct {
+	Uuid             uuid.UUID
+	TenantID         uuid.UUID
+	SubscriptionUUID uuid.UUID
+	EventUUID        uuid.UUID
+	EventName        string
+	Payload          []byte
+	Status           Status
+	Attempts         int
+	LastStatusCode   int
+	LastError        string
+	NextAttemptAt    time.Time
+	DeliveredAt      time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewDelivery(tenantID, subscriptionUUID, eventUUID uuid.UUID, eventName string, payload []byte) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		Uuid:             uuid.New(),
+		TenantID:         tenantID,
+		SubscriptionUUID: subscriptionUUID,
+		EventUUID:        eventUUID,
+		EventName:        eventName,
+		Payload:          payload,
+		Status:           StatusPending,
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// Succeed records an attempt the receiver acknowledged.
+func (d *Delivery) Succeed(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = StatusSucceeded
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = at
+	d.UpdatedAt = at
+}
+
+// Fail records a failed attempt and schedules the next one, or moves the
+// delivery to the dead letters once maxAttempts were made. statusCode is 0
+// when no response was received.
+func (d *Delivery) Fail(statusCode int, reason string, at time.Time, maxAttempts int) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+	d.UpdatedAt = at
+	if d.Attempts >= maxAttempts {
+		d.Status = StatusDead
+		return
+	}
+	d.Status = StatusFailed
+	d.NextAttemptAt = at.Add(RetryDelay(d.Attempts))
+}
+
+// Redeliver queues a dead or succeeded delivery to be sent again right away.
+// Its attempt count restarts so it gets the full retry schedule.
+func (d *Delivery) Redeliver(at time.Time) error {
+	if d.Status != StatusDead && d.Status != StatusSucceeded {
+		return ErrNotRedeliverable
+	}
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	d.UpdatedAt = at
+	return nil
+}
+
+// RetryDelay is the wait after the given failed attempt: ten seconds doubled
+// per attempt, capped at one hour.
+func RetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 12 {
+		return maxRetryDelay
+	}
+	d := firstRetryDelay << (attempt - 1)
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
+
+// DeliveryFrom rebuilds a delivery from its stored form.
+func DeliveryFrom(out dto.DeliveryOutput) *Delivery {
+	return &Delivery{
+		Uuid:             out.Uuid,
+		TenantID:         out.TenantID,
+		SubscriptionUUID: out.SubscriptionUUID,
+		EventUUID:        out.EventUUID,
+		EventName:        out.EventName,
+		Payload:          out.Payload,
+		Status:           Status(out.Status),
+		Attempts:         out.Attempts,
+		LastStatusCode:   int(out.LastStatusCode.Int64),
+		LastError:        out.LastError.String,
+		NextAttemptAt:    out.NextAttemptAt,
+		DeliveredAt:      out.DeliveredAt.Time,
+		CreatedAt:        out.CreatedAt,
+		UpdatedAt:        out.UpdatedAt,
+	}
+}
@@ -0,0 +1,15 @@
+package webhook
+
+import "github.com/moura95/go-ddd/internal/domain/domainerr"
+
+var (
+	ErrNotFound         = domainerr.NotFound("webhook_not_found", "webhook not found")
+	ErrDeliveryNotFound = domainerr.NotFound("webhook_delivery_not_found", "webhook delivery not found")
+	ErrNotRedeliverable = domainerr.Conflict("webhook_delivery_in_progress", "only dead or delivered deliveries can be sent again")
+	ErrLeaseLost        = domainerr.Conflict("webhook_delivery_lease_lost", "webhook delivery was claimed again by another dispatcher")
+
+	ErrInvalidURL    = domainerr.Validation("invalid_url", "url must be an absolute http or https url")
+	ErrNoEvents      = domainerr.Validation("events_required", "at least one event must be subscribed")
+	ErrUnknownEvent  = domainerr.Validation("unknown_event", "events must be known event names or *")
+	ErrSecretTooWeak = domainerr.Validation("secret_too_short", "secret must have at least 16 characters")
+)
@@ -0,0 +1,289 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/webhook"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+)
+
+type IWebhookRepositoryMemory interface {
+	webhook.IWebhookRepository
+	Snapshot() (restore func())
+}
+
+// webhookRepositoryMemory is used by the relay and the dispatcher goroutines
+// at the same time, so it guards its state.
+type webhookRepositoryMemory struct {
+	mu            sync.Mutex
+	subscriptions []webhook.Subscription
+	deliveries    []webhook.Delivery
+}
+
+func NewWebhookRepositoryMemory() IWebhookRepositoryMemory {
+	return &webhookRepositoryMemory{}
+}
+
+func (m *webhookRepositoryMemory) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, err := webhook.NewSubscription(input.URL, input.Secret, input.Events)
+	if err != nil {
+		return nil, err
+	}
+	s.TenantID = tenantID
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions = append(m.subscriptions, *s)
+	out := toOutput(*s)
+	return &out, nil
+}
+
+func (m *webhookRepositoryMemory) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i := m.indexOf(tenantID, uid); i >= 0 {
+		out := toOutput(m.subscriptions[i])
+		return &out, nil
+	}
+	return nil, webhook.ErrNotFound
+}
+
+func (m *webhookRepositoryMemory) GetAll(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []dto.Output{}, 0, err
+	}
+	filter.Normalize()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	matched := []dto.Output{}
+	for _, s := range m.subscriptions {
+		if s.TenantID == tenantID {
+			matched = append(matched, toOutput(s))
+		}
+	}
+	start, end := filter.Window(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+func (m *webhookRepositoryMemory) Update(ctx context.Context, input dto.UpdateInput) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.indexOf(tenantID, input.Uuid)
+	if i < 0 {
+		return webhook.ErrNotFound
+	}
+	s := m.subscriptions[i]
+	s.URL = input.URL
+	s.Events = webhook.NormalizeEvents(input.Events)
+	s.Active = input.Active
+	if input.Secret != "" {
+		s.Secret = input.Secret
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	s.UpdatedAt = time.Now()
+	m.subscriptions[i] = s
+	return nil
+}
+
+func (m *webhookRepositoryMemory) Delete(ctx context.Context, uid uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.indexOf(tenantID, uid)
+	if i < 0 {
+		return webhook.ErrNotFound
+	}
+	m.subscriptions = append(m.subscriptions[:i:i], m.subscriptions[i+1:]...)
+	kept := m.deliveries[:0:0]
+	for _, d := range m.deliveries {
+		if d.SubscriptionUUID != uid {
+			kept = append(kept, d)
+		}
+	}
+	m.deliveries = kept
+	return nil
+}
+
+func (m *webhookRepositoryMemory) ListSubscribed(ctx context.Context, tenantID uuid.UUID, name string) ([]dto.Output, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := []dto.Output{}
+	for _, s := range m.subscriptions {
+		if s.TenantID == tenantID && s.Active && s.Matches(name) {
+			out = append(out, toOutput(s))
+		}
+	}
+	return out, nil
+}
+
+func (m *webhookRepositoryMemory) CreateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.deliveries {
+		if existing.SubscriptionUUID == d.SubscriptionUUID && existing.EventUUID == d.EventUUID {
+			return nil
+		}
+	}
+	m.deliveries = append(m.deliveries, *d)
+	return nil
+}
+
+func (m *webhookRepositoryMemory) GetDelivery(ctx context.Context, subscriptionUUID, uid uuid.UUID) (*dto.DeliveryOutput, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range m.deliveries {
+		if d.Uuid == uid && d.SubscriptionUUID == subscriptionUUID && d.TenantID == tenantID {
+			out := toDeliveryOutput(d)
+			return &out, nil
+		}
+	}
+	return nil, webhook.ErrDeliveryNotFound
+}
+
+func (m *webhookRepositoryMemory) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]dto.DeliveryOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	due := []int{}
+	for i, d := range m.deliveries {
+		if (d.Status == webhook.StatusPending || d.Status == webhook.StatusFailed) && !d.NextAttemptAt.After(now) {
+			due = append(due, i)
+		}
+	}
+	sort.SliceStable(due, func(a, b int) bool {
+		return m.deliveries[due[a]].NextAttemptAt.Before(m.deliveries[due[b]].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	out := make([]dto.DeliveryOutput, 0, len(due))
+	for _, i := range due {
+		m.deliveries[i].NextAttemptAt = leaseUntil
+		out = append(out, toDeliveryOutput(m.deliveries[i]))
+	}
+	return out, nil
+}
+
+func (m *webhookRepositoryMemory) SaveDelivery(ctx context.Context, d *webhook.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.deliveries {
+		if m.deliveries[i].Uuid == d.Uuid {
+			m.deliveries[i] = *d
+			return nil
+		}
+	}
+	return webhook.ErrDeliveryNotFound
+}
+
+func (m *webhookRepositoryMemory) SaveClaimedDelivery(ctx context.Context, d *webhook.Delivery, leaseUntil time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.deliveries {
+		if m.deliveries[i].Uuid == d.Uuid && m.deliveries[i].NextAttemptAt.Equal(leaseUntil) {
+			m.deliveries[i] = *d
+			return nil
+		}
+	}
+	return webhook.ErrLeaseLost
+}
+
+func (m *webhookRepositoryMemory) ListDeliveries(ctx context.Context, subscriptionUUID uuid.UUID, filter dto.DeliveryListInput) ([]dto.DeliveryOutput, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	matched := []dto.DeliveryOutput{}
+	// newest first, like the postgres repository
+	for i := len(m.deliveries) - 1; i >= 0; i-- {
+		d := m.deliveries[i]
+		if d.SubscriptionUUID != subscriptionUUID || d.TenantID != tenantID || (filter.Status != "" && string(d.Status) != filter.Status) {
+			continue
+		}
+		matched = append(matched, toDeliveryOutput(d))
+	}
+	start, end := filter.Window(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+func (m *webhookRepositoryMemory) Snapshot() func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscriptions := append([]webhook.Subscription(nil), m.subscriptions...)
+	deliveries := append([]webhook.Delivery(nil), m.deliveries...)
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.subscriptions, m.deliveries = subscriptions, deliveries
+	}
+}
+
+func (m *webhookRepositoryMemory) indexOf(tenantID, uid uuid.UUID) int {
+	for i, s := range m.subscriptions {
+		if s.Uuid == uid && s.TenantID == tenantID {
+			return i
+		}
+	}
+	return -1
+}
+
+func toOutput(s webhook.Subscription) dto.Output {
+	return dto.Output{
+		Uuid:      s.Uuid,
+		TenantID:  s.TenantID,
+		URL:       s.URL,
+		Secret:    s.Secret,
+		Events:    append([]string(nil), s.Events...),
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func toDeliveryOutput(d webhook.Delivery) dto.DeliveryOutput {
+	return dto.DeliveryOutput{
+		Uuid:             d.Uuid,
+		TenantID:         d.TenantID,
+		SubscriptionUUID: d.SubscriptionUUID,
+		EventUUID:        d.EventUUID,
+		EventName:        d.EventName,
+		Payload:          d.Payload,
+		Status:           string(d.Status),
+		Attempts:         d.Attempts,
+		LastStatusCode:   sql.NullInt64{Int64: int64(d.LastStatusCode), Valid: d.LastStatusCode != 0},
+		LastError:        sql.NullString{String: d.LastError, Valid: d.LastError != ""},
+		NextAttemptAt:    d.NextAttemptAt,
+		DeliveredAt:      sql.NullTime{Time: d.DeliveredAt, Valid: !d.DeliveredAt.IsZero()},
+		CreatedAt:        d.CreatedAt,
+		UpdatedAt:        d.UpdatedAt,
+	}
+}
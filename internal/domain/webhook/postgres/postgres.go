@@ -0,0 +1,278 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/webhook"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type webhookRepository struct {
+	db     *sqlx.DB
+	logger *zap.SugaredLogger
+}
+
+func NewWebhookRepository(db *sqlx.DB, log *zap.SugaredLogger) webhook.IWebhookRepository {
+	return &webhookRepository{db: db, logger: log}
+}
+
+// conn returns the transaction carried by ctx, so the repository joins an
+// ongoing unit of work, or the pool otherwise.
+func (r *webhookRepository) conn(ctx context.Context) database.Querier {
+	return database.Traced(database.Executor(ctx, r.db))
+}
+
+const (
+	subscriptionColumns = "uuid, tenant_id, url, secret, events, active, created_at, update_at"
+	deliveryColumns     = "uuid, tenant_id, subscription_uuid, event_uuid, event_name, payload, status, attempts, last_status_code, last_error, next_attempt_at, delivered_at, created_at, update_at"
+)
+
+func (r *webhookRepository) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, err := webhook.NewSubscription(input.URL, input.Secret, input.Events)
+	if err != nil {
+		return nil, err
+	}
+	s.TenantID = tenantID
+
+	query := `
+        INSERT INTO webhook_subscriptions (uuid, tenant_id, url, secret, events, active, created_at, update_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err = r.conn(ctx).ExecContext(ctx, query, s.Uuid, s.TenantID, s.URL, s.Secret, pq.Array(s.Events), s.Active, s.CreatedAt, s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Output{
+		Uuid:      s.Uuid,
+		TenantID:  s.TenantID,
+		URL:       s.URL,
+		Secret:    s.Secret,
+		Events:    s.Events,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}, nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var s dto.Output
+	err = r.conn(ctx).GetContext(ctx, &s, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE uuid = $1 AND tenant_id = $2", uid, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhook.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []dto.Output{}, 0, err
+	}
+	filter.Normalize()
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM webhook_subscriptions WHERE tenant_id = $1", tenantID); err != nil {
+		return []dto.Output{}, 0, err
+	}
+
+	query := "SELECT " + subscriptionColumns + " FROM webhook_subscriptions WHERE tenant_id = $1 ORDER BY created_at, uuid LIMIT $2 OFFSET $3"
+	subscriptions := []dto.Output{}
+	if err := r.conn(ctx).SelectContext(ctx, &subscriptions, query, tenantID, filter.PageSize, filter.Offset()); err != nil {
+		return []dto.Output{}, 0, err
+	}
+	return subscriptions, total, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, input dto.UpdateInput) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	s := webhook.Subscription{
+		Uuid:   input.Uuid,
+		URL:    input.URL,
+		Secret: input.Secret,
+		Events: webhook.NormalizeEvents(input.Events),
+		Active: input.Active,
+	}
+	if s.Secret == "" {
+		current, err := r.GetByID(ctx, input.Uuid)
+		if err != nil {
+			return err
+		}
+		s.Secret = current.Secret
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE webhook_subscriptions
+        SET url=$2, secret=$3, events=$4, active=$5, update_at=$6
+        WHERE uuid=$1 AND tenant_id=$7`
+	res, err := r.conn(ctx).ExecContext(ctx, query, s.Uuid, s.URL, s.Secret, pq.Array(s.Events), s.Active, time.Now(), tenantID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, webhook.ErrNotFound)
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, uid uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.conn(ctx).ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE uuid = $1 AND tenant_id = $2", uid, tenantID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, webhook.ErrNotFound)
+}
+
+func (r *webhookRepository) ListSubscribed(ctx context.Context, tenantID uuid.UUID, name string) ([]dto.Output, error) {
+	query := "SELECT " + subscriptionColumns + ` FROM webhook_subscriptions
+        WHERE tenant_id = $1 AND active AND ($2 = ANY(events) OR $3 = ANY(events))
+        ORDER BY created_at`
+	subscriptions := []dto.Output{}
+	if err := r.conn(ctx).SelectContext(ctx, &subscriptions, query, tenantID, name, webhook.AllEvents); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	query := `
+        INSERT INTO webhook_deliveries (uuid, tenant_id, subscription_uuid, event_uuid, event_name, payload, status, attempts, next_attempt_at, created_at, update_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (subscription_uuid, event_uuid) DO NOTHING
+    `
+	_, err := r.conn(ctx).ExecContext(ctx, query, d.Uuid, d.TenantID, d.SubscriptionUUID, d.EventUUID, d.EventName, string(d.Payload),
+		string(d.Status), d.Attempts, d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+	return err
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, subscriptionUUID, uid uuid.UUID) (*dto.DeliveryOutput, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var d dto.DeliveryOutput
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE subscription_uuid = $1 AND uuid = $2 AND tenant_id = $3"
+	if err := r.conn(ctx).GetContext(ctx, &d, query, subscriptionUUID, uid, tenantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]dto.DeliveryOutput, error) {
+	query := `
+        UPDATE webhook_deliveries SET next_attempt_at = $4
+        WHERE uuid IN (
+            SELECT uuid FROM webhook_deliveries
+            WHERE status IN ($1, $2) AND next_attempt_at <= $3
+            ORDER BY next_attempt_at
+            LIMIT $5
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + deliveryColumns
+	deliveries := []dto.DeliveryOutput{}
+	err := r.conn(ctx).SelectContext(ctx, &deliveries, query,
+		string(webhook.StatusPending), string(webhook.StatusFailed), now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) SaveDelivery(ctx context.Context, d *webhook.Delivery) error {
+	return r.saveDelivery(ctx, d, "", webhook.ErrDeliveryNotFound)
+}
+
+func (r *webhookRepository) SaveClaimedDelivery(ctx context.Context, d *webhook.Delivery, leaseUntil time.Time) error {
+	return r.saveDelivery(ctx, d, " AND next_attempt_at=$9", webhook.ErrLeaseLost, leaseUntil)
+}
+
+// saveDelivery updates d's row when it also matches cond, whose arguments
+// start at $9, and returns notMatched when it does not.
+func (r *webhookRepository) saveDelivery(ctx context.Context, d *webhook.Delivery, cond string, notMatched error, condArgs ...interface{}) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status=$2, attempts=$3, last_status_code=NULLIF($4, 0), last_error=NULLIF($5, ''),
+            next_attempt_at=$6, delivered_at=$7, update_at=$8
+        WHERE uuid=$1` + cond
+	var deliveredAt sql.NullTime
+	if !d.DeliveredAt.IsZero() {
+		deliveredAt = sql.NullTime{Time: d.DeliveredAt, Valid: true}
+	}
+	args := append([]interface{}{d.Uuid, string(d.Status), d.Attempts, d.LastStatusCode, d.LastError,
+		d.NextAttemptAt, deliveredAt, d.UpdatedAt}, condArgs...)
+	res, err := r.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res, notMatched)
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, subscriptionUUID uuid.UUID, filter dto.DeliveryListInput) ([]dto.DeliveryOutput, int, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Normalize()
+
+	where := " WHERE subscription_uuid = $1 AND tenant_id = $2"
+	args := []interface{}{subscriptionUUID, tenantID}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += " AND status = $3"
+	}
+
+	var total int
+	if err := r.conn(ctx).GetContext(ctx, &total, "SELECT count(*) FROM webhook_deliveries"+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.PageSize, filter.Offset())
+	query := fmt.Sprintf("SELECT %s FROM webhook_deliveries%s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+		deliveryColumns, where, len(args)-1, len(args))
+	deliveries := []dto.DeliveryOutput{}
+	if err := r.conn(ctx).SelectContext(ctx, &deliveries, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func expectAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/webhook"
+)
+
+// IWebhookRepository stores subscriptions and their deliveries. Unless
+// noted otherwise its methods are scoped to the tenant of ctx and fail
+// without one.
+type IWebhookRepository interface {
+	Create(ctx context.Context, input webhook.CreateInput) (*webhook.Output, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*webhook.Output, error)
+	GetAll(ctx context.Context, filter webhook.ListInput) ([]webhook.Output, int, error)
+	Update(ctx context.Context, input webhook.UpdateInput) error
+	// Delete removes the subscription and its delivery log.
+	Delete(ctx context.Context, uid uuid.UUID) error
+	// ListSubscribed returns the active subscriptions of tenantID whose
+	// filter matches the event called name. It is called by the outbox
+	// relay, for every tenant, so it takes the tenant as an argument.
+	ListSubscribed(ctx context.Context, tenantID uuid.UUID, name string) ([]webhook.Output, error)
+
+	// CreateDelivery queues d for its own tenant. Queuing the same event
+	// twice for one subscription is a no-op, so a re-published event is sent
+	// once.
+	CreateDelivery(ctx context.Context, d *Delivery) error
+	GetDelivery(ctx context.Context, subscriptionUUID, uid uuid.UUID) (*webhook.DeliveryOutput, error)
+	// ClaimDueDeliveries returns up to limit pending or failed deliveries due
+	// by now, of every tenant, and pushes their next attempt to leaseUntil,
+	// so other dispatchers skip them while they are being sent.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]webhook.DeliveryOutput, error)
+	// SaveDelivery stores d whichever tenant it belongs to; callers load it
+	// through a tenant-scoped method or a claim first.
+	SaveDelivery(ctx context.Context, d *Delivery) error
+	// SaveClaimedDelivery stores d only while its next attempt is still the
+	// leaseUntil it was claimed with, and returns ErrLeaseLost otherwise, so a
+	// dispatcher whose lease ran out cannot overwrite the one that took over.
+	SaveClaimedDelivery(ctx context.Context, d *Delivery, leaseUntil time.Time) error
+	ListDeliveries(ctx context.Context, subscriptionUUID uuid.UUID, filter webhook.DeliveryListInput) ([]webhook.DeliveryOutput, int, error)
+}
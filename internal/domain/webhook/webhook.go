@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/assignment"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+)
+
+// AllEvents subscribes a webhook to every event, including ones added later.
+const AllEvents = "*"
+
+const (
+	MinSecretLength = 16
+	secretPrefix    = "whsec_"
+)
+
+// KnownEvents are the event names a subscription may filter on.
+var KnownEvents = []string{
+	aggregate.EventDriverCreated,
+	aggregate.EventDriverUpdated,
+	aggregate.EventDriverSoftDeleted,
+	aggregate.EventDriverRestored,
+	aggregate.EventDriverDeleted,
+	vehicle.EventCreated,
+	vehicle.EventUpdated,
+	vehicle.EventSoftDeleted,
+	vehicle.EventRestored,
+	vehicle.EventDeleted,
+	assignment.EventDriverAssigned,
+	assignment.EventDriverUnassigned,
+}
+
+// Subscription asks for the events it filters on to be POSTed to URL, signed
+// with Secret.
+type Subscription struct {
+	Uuid uuid.UUID
+	// TenantID is the tenant whose events the subscription receives.
+	TenantID  uuid.UUID
+	URL       string
+	Secret    string
+	Events    []string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewSubscription builds an active subscription. An empty secret is replaced
+// by a generated one, which the caller must hand back to the client.
+func NewSubscription(rawURL, secret string, events []string) (*Subscription, error) {
+	s := &Subscription{
+		Uuid:      uuid.New(),
+		URL:       strings.TrimSpace(rawURL),
+		Secret:    strings.TrimSpace(secret),
+		Events:    NormalizeEvents(events),
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if s.Secret == "" {
+		generated, err := NewSecret()
+		if err != nil {
+			return nil, err
+		}
+		s.Secret = generated
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Subscription) Validate() error {
+	var fields domainerr.Fields
+	if u, err := url.Parse(s.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fields.Add("url", ErrInvalidURL)
+	}
+	if len(s.Secret) < MinSecretLength {
+		fields.Add("secret", ErrSecretTooWeak)
+	}
+	if len(s.Events) == 0 {
+		fields.Add("events", ErrNoEvents)
+	}
+	for _, name := range s.Events {
+		if name != AllEvents && !known(name) {
+			fields.Add("events", ErrUnknownEvent)
+			break
+		}
+	}
+	return fields.Err()
+}
+
+// Matches reports whether the subscription wants the event called name.
+func (s *Subscription) Matches(name string) bool {
+	for _, e := range s.Events {
+		if e == AllEvents || e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEvents trims the names and drops blanks and duplicates.
+func NormalizeEvents(events []string) []string {
+	out := make([]string, 0, len(events))
+	seen := map[string]bool{}
+	for _, e := range events {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	return out
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+func known(name string) bool {
+	for _, e := range KnownEvents {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/magiconair/properties/assert"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+)
+
+func TestNewSubscription(t *testing.T) {
+	s, err := NewSubscription(" https://tms.example.com/hooks ", "", []string{aggregate.EventDriverCreated, " ", aggregate.EventDriverCreated})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s.URL, "https://tms.example.com/hooks")
+	assert.Equal(t, s.Events, []string{aggregate.EventDriverCreated})
+	assert.Equal(t, s.Active, true)
+	// a secret is generated when none is given
+	assert.Equal(t, strings.HasPrefix(s.Secret, secretPrefix), true)
+	assert.Equal(t, len(s.Secret) >= MinSecretLength, true)
+}
+
+func TestNewSubscriptionInvalid(t *testing.T) {
+	s, err := NewSubscription("ftp://tms.example.com", "short", nil)
+	assert.Equal(t, s == nil, true)
+	assert.Equal(t, errors.Is(err, ErrInvalidURL), true)
+	assert.Equal(t, errors.Is(err, ErrSecretTooWeak), true)
+	assert.Equal(t, errors.Is(err, ErrNoEvents), true)
+
+	_, err = NewSubscription("https://tms.example.com", "", []string{"DriverRenamed"})
+	assert.Equal(t, errors.Is(err, ErrUnknownEvent), true)
+}
+
+func TestMatches(t *testing.T) {
+	s, _ := NewSubscription("https://tms.example.com", "", []string{vehicle.EventCreated})
+	assert.Equal(t, s.Matches(vehicle.EventCreated), true)
+	assert.Equal(t, s.Matches(vehicle.EventDeleted), false)
+
+	all, _ := NewSubscription("https://tms.example.com", "", []string{AllEvents})
+	assert.Equal(t, all.Matches(vehicle.EventDeleted), true)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"DriverCreated"}`)
+	signature := Sign("whsec_0123456789abcdef", 1700000000, body)
+	assert.Equal(t, strings.HasPrefix(signature, "sha256="), true)
+	assert.Equal(t, Verify("whsec_0123456789abcdef", 1700000000, body, signature), true)
+	assert.Equal(t, Verify("whsec_0123456789abcdef", 1700000001, body, signature), false)
+	assert.Equal(t, Verify("whsec_fedcba9876543210", 1700000000, body, signature), false)
+}
+
+func TestDeliveryRetriesThenDies(t *testing.T) {
+	d := NewDelivery(uuid.New(), uuid.New(), uuid.New(), aggregate.EventDriverCreated, []byte(`{}`))
+	at := time.Now()
+
+	d.Fail(500, "unexpected status 500", at, 3)
+	assert.Equal(t, d.Status, StatusFailed)
+	assert.Equal(t, d.NextAttemptAt, at.Add(10*time.Second))
+
+	d.Fail(0, "connection refused", at, 3)
+	assert.Equal(t, d.Status, StatusFailed)
+	assert.Equal(t, d.NextAttemptAt, at.Add(20*time.Second))
+
+	assert.Equal(t, errors.Is(d.Redeliver(at), ErrNotRedeliverable), true)
+
+	d.Fail(502, "unexpected status 502", at, 3)
+	assert.Equal(t, d.Status, StatusDead)
+	assert.Equal(t, d.Attempts, 3)
+
+	assert.Equal(t, d.Redeliver(at), nil)
+	assert.Equal(t, d.Status, StatusPending)
+	assert.Equal(t, d.Attempts, 0)
+
+	d.Succeed(204, at)
+	assert.Equal(t, d.Status, StatusSucceeded)
+	assert.Equal(t, d.LastError, "")
+	assert.Equal(t, d.DeliveredAt, at)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, RetryDelay(1), 10*time.Second)
+	assert.Equal(t, RetryDelay(4), 80*time.Second)
+	assert.Equal(t, RetryDelay(10), time.Hour)
+	assert.Equal(t, RetryDelay(50), time.Hour)
+}
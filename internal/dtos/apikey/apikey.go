@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+)
+
+// Output describes a key without its secret. LastUsedAt and RevokedAt are
+// zero when the key was never used or is not revoked.
+type Output struct {
+	Uuid       uuid.UUID
+	TenantID   uuid.UUID
+	Name       string
+	Prefix     string
+	Scopes     []string
+	CreatedBy  uuid.UUID
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// Created is a newly issued key; Key is returned this once only.
+type Created struct {
+	Output
+	Key string
+}
+
+// CreateInput issues a key; a zero ExpiresAt takes the configured lifetime.
+type CreateInput struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// ListInput pages through the keys of one tenant, newest first.
+type ListInput struct {
+	pagination.Input
+	TenantID uuid.UUID
+}
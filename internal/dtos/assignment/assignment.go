@@ -0,0 +1,27 @@
+package assignment
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Output struct {
+	Uuid         uuid.UUID    `db:"uuid"`
+	DriverUUID   uuid.UUID    `db:"driver_uuid"`
+	VehicleUUID  uuid.UUID    `db:"vehicle_uuid"`
+	Primary      bool         `db:"is_primary"`
+	AssignedAt   time.Time    `db:"assigned_at"`
+	UnassignedAt sql.NullTime `db:"unassigned_at"`
+	AssignedBy   string       `db:"assigned_by"`
+	Notes        string       `db:"notes"`
+}
+
+type CreateInput struct {
+	DriverUUID  uuid.UUID
+	VehicleUUID uuid.UUID
+	Primary     bool
+	AssignedBy  string
+	Notes       string
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+)
+
+type Output struct {
+	Uuid       uuid.UUID       `db:"uuid"`
+	EntityType string          `db:"entity_type"`
+	EntityUUID uuid.UUID       `db:"entity_uuid"`
+	Action     string          `db:"action"`
+	Before     json.RawMessage `db:"before"`
+	After      json.RawMessage `db:"after"`
+	Actor      string          `db:"actor"`
+	APIKey     sql.NullString  `db:"api_key_uuid"`
+	RequestID  sql.NullString  `db:"request_id"`
+	IP         sql.NullString  `db:"ip"`
+	RemoteIP   sql.NullString  `db:"remote_ip"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+// ListInput pages through an entity's history; entries come newest first.
+type ListInput struct {
+	pagination.Input
+}
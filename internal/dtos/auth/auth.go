@@ -0,0 +1,17 @@
+package auth
+
+import "time"
+
+type LoginInput struct {
+	Email    string
+	Password string
+}
+
+// Tokens is what a login or refresh hands the client. The refresh token is
+// single use: refreshing returns a new one and revokes it.
+type Tokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
@@ -2,35 +2,74 @@ package driver
 
 import (
 	"database/sql"
-	"github.com/google/uuid"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	"github.com/moura95/go-ddd/internal/dtos/patch"
 )
 
 type Output struct {
-	Uuid          uuid.UUID      `db:"uuid"`
-	Name          string         `db:"name"`
-	Email         string         `db:"email"`
-	TaxID         string         `db:"tax_id"`
-	DriverLicense string         `db:"driver_license"`
-	DateOfBirth   sql.NullString `db:"date_of_birth"`
-	DeletedAt     sql.NullString `db:"deleted_at"`
-	CreatedAt     time.Time      `db:"created_at"`
-	UpdatedAt     time.Time      `db:"update_at"`
+	Uuid            uuid.UUID      `db:"uuid"`
+	Name            string         `db:"name"`
+	Email           string         `db:"email"`
+	TaxID           string         `db:"tax_id"`
+	DriverLicense   string         `db:"driver_license"`
+	LicenseCategory sql.NullString `db:"license_category"`
+	DateOfBirth     sql.NullString `db:"date_of_birth"`
+	DeletedAt       sql.NullString `db:"deleted_at"`
+	Version         int            `db:"version"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       time.Time      `db:"update_at"`
 }
 
 type CreateInput struct {
-	Name          string
-	Email         string
-	TaxID         string
-	DriverLicense string
-	DateOfBirth   sql.NullString
+	Name            string
+	Email           string
+	TaxID           string
+	DriverLicense   string
+	LicenseCategory string
+	DateOfBirth     sql.NullString
 }
 
 type UpdateInput struct {
-	Uuid          uuid.UUID
+	Uuid            uuid.UUID
+	Name            string
+	Email           string
+	TaxID           string
+	DriverLicense   string
+	LicenseCategory string
+	DateOfBirth     sql.NullString
+	// ExpectedVersion guards the write against concurrent changes; zero
+	// skips the check.
+	ExpectedVersion int
+}
+
+// PatchInput is a merge patch against a driver; members left absent keep
+// their stored value.
+type PatchInput struct {
+	Uuid            uuid.UUID
+	Name            patch.Field[string]
+	Email           patch.Field[string]
+	TaxID           patch.Field[string]
+	DriverLicense   patch.Field[string]
+	LicenseCategory patch.Field[string]
+	DateOfBirth     patch.Field[string]
+	ExpectedVersion int
+}
+
+// Sortable fields accepted by ListInput.SortBy.
+const (
+	SortByName      = "name"
+	SortByEmail     = "email"
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+)
+
+type ListInput struct {
+	pagination.Input
 	Name          string
 	Email         string
 	TaxID         string
 	DriverLicense string
-	DateOfBirth   sql.NullString
 }
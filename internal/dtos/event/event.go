@@ -0,0 +1,23 @@
+package event
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is an event as stored in the outbox.
+type Message struct {
+	Uuid          uuid.UUID       `db:"uuid"`
+	TenantID      uuid.UUID       `db:"tenant_id"`
+	Name          string          `db:"name"`
+	AggregateType string          `db:"aggregate_type"`
+	AggregateUUID uuid.UUID       `db:"aggregate_uuid"`
+	Payload       json.RawMessage `db:"payload"`
+	OccurredAt    time.Time       `db:"occurred_at"`
+	Attempts      int             `db:"attempts"`
+	LastError     sql.NullString  `db:"last_error"`
+	DispatchedAt  sql.NullTime    `db:"dispatched_at"`
+}
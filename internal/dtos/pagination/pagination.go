@@ -0,0 +1,58 @@
+package pagination
+
+import "strings"
+
+const (
+	DefaultPage     = 1
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+type Input struct {
+	Page      int
+	PageSize  int
+	SortBy    string
+	SortOrder string
+}
+
+// Normalize applies the defaults and bounds shared by every paginated listing.
+func (p *Input) Normalize() {
+	if p.Page < 1 {
+		p.Page = DefaultPage
+	}
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+	p.SortBy = strings.ToLower(strings.TrimSpace(p.SortBy))
+	p.SortOrder = strings.ToLower(strings.TrimSpace(p.SortOrder))
+	if p.SortOrder != OrderDesc {
+		p.SortOrder = OrderAsc
+	}
+}
+
+func (p Input) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+func (p Input) Descending() bool {
+	return p.SortOrder == OrderDesc
+}
+
+// Window returns the [start, end) bounds of the current page over total items.
+func (p Input) Window(total int) (int, int) {
+	start := p.Offset()
+	if start > total {
+		start = total
+	}
+	end := start + p.PageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
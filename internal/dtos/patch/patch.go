@@ -0,0 +1,55 @@
+// Package patch carries RFC 7396 (JSON Merge Patch) members through the
+// update DTOs.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MediaType is the content type a merge patch document is sent with.
+const MediaType = "application/merge-patch+json"
+
+// Field is one member of a merge patch document. A member that is missing
+// from the document leaves the target untouched, an explicit null removes
+// it, and any other value replaces it.
+type Field[T any] struct {
+	Present bool
+	Null    bool
+	Value   T
+}
+
+// Set returns a Field that replaces the target with value.
+func Set[T any](value T) Field[T] {
+	return Field[T]{Present: true, Value: value}
+}
+
+// Null returns a Field that removes the target.
+func Null[T any]() Field[T] {
+	return Field[T]{Present: true, Null: true}
+}
+
+// UnmarshalJSON is only called for members present in the document, which is
+// what lets absent and null be told apart.
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Present = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// Merge applies the member to current. Removing a member yields the zero
+// value, so required fields cleared with null fail domain validation.
+func (f Field[T]) Merge(current T) T {
+	switch {
+	case !f.Present:
+		return current
+	case f.Null:
+		var zero T
+		return zero
+	default:
+		return f.Value
+	}
+}
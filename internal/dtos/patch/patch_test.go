@@ -0,0 +1,35 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type doc struct {
+	Name  Field[string] `json:"name"`
+	Email Field[string] `json:"email"`
+	Year  Field[uint]   `json:"year"`
+}
+
+func TestFieldTellsAbsentFromNull(t *testing.T) {
+	var d doc
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "Ana", "email": null}`), &d))
+
+	assert.Equal(t, Set("Ana"), d.Name)
+	assert.Equal(t, Null[string](), d.Email)
+	assert.Equal(t, Field[uint]{}, d.Year)
+}
+
+func TestFieldMerge(t *testing.T) {
+	assert.Equal(t, "old", Field[string]{}.Merge("old"))
+	assert.Equal(t, "", Null[string]().Merge("old"))
+	assert.Equal(t, "new", Set("new").Merge("old"))
+	assert.Equal(t, uint(2021), Set(uint(2021)).Merge(2019))
+}
+
+func TestFieldRejectsWrongType(t *testing.T) {
+	var d doc
+	assert.Error(t, json.Unmarshal([]byte(`{"year": "soon"}`), &d))
+}
@@ -0,0 +1,26 @@
+package tenant
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+)
+
+type Output struct {
+	Uuid      uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// CreateInput creates a tenant with its first admin, who signs in to set up
+// the rest of its users.
+type CreateInput struct {
+	Name          string
+	AdminEmail    string
+	AdminPassword string
+}
+
+type ListInput struct {
+	pagination.Input
+}
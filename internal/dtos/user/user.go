@@ -0,0 +1,31 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+)
+
+// Output is a user without its password hash.
+type Output struct {
+	Uuid      uuid.UUID
+	TenantID  uuid.UUID
+	Email     string
+	Roles     []string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CreateInput struct {
+	Email    string
+	Password string
+	Roles    []string
+}
+
+// ListInput pages through the users of one tenant, by email.
+type ListInput struct {
+	pagination.Input
+	TenantID uuid.UUID
+}
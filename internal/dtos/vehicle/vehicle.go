@@ -2,8 +2,11 @@ package vehicle
 
 import (
 	"database/sql"
-	"github.com/google/uuid"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	"github.com/moura95/go-ddd/internal/dtos/patch"
 )
 
 type Output struct {
@@ -12,8 +15,11 @@ type Output struct {
 	Model             string         `db:"model"`
 	YearOfManufacture uint           `db:"year_of_manufacture"`
 	LicensePlate      string         `db:"license_plate"`
+	Renavam           sql.NullString `db:"renavam"`
+	Category          sql.NullString `db:"category"`
 	Color             string         `db:"color"`
 	DeletedAt         sql.NullString `db:"deleted_at"`
+	Version           int            `db:"version"`
 	CreatedAt         time.Time      `db:"created_at"`
 	UpdatedAt         time.Time      `db:"update_at"`
 }
@@ -23,6 +29,8 @@ type CreateInput struct {
 	Model             string `db:"model"`
 	YearOfManufacture uint   `db:"year_of_manufacture"`
 	LicensePlate      string `db:"license_plate"`
+	Renavam           string `db:"renavam"`
+	Category          string `db:"category"`
 	Color             string `db:"color"`
 }
 
@@ -32,5 +40,42 @@ type UpdateInput struct {
 	Model             string    `db:"model"`
 	YearOfManufacture uint      `db:"year_of_manufacture"`
 	LicensePlate      string    `db:"license_plate"`
+	Renavam           string    `db:"renavam"`
+	Category          string    `db:"category"`
 	Color             string    `db:"color"`
+	ExpectedVersion   int       `db:"version"`
+}
+
+// PatchInput is a merge patch against a vehicle; members left absent keep
+// their stored value.
+type PatchInput struct {
+	Uuid              uuid.UUID
+	Brand             patch.Field[string]
+	Model             patch.Field[string]
+	YearOfManufacture patch.Field[uint]
+	LicensePlate      patch.Field[string]
+	Renavam           patch.Field[string]
+	Category          patch.Field[string]
+	Color             patch.Field[string]
+	ExpectedVersion   int
+}
+
+// Sortable fields accepted by ListInput.SortBy.
+const (
+	SortByBrand             = "brand"
+	SortByModel             = "model"
+	SortByYearOfManufacture = "year_of_manufacture"
+	SortByLicensePlate      = "license_plate"
+	SortByCreatedAt         = "created_at"
+	SortByUpdatedAt         = "updated_at"
+)
+
+type ListInput struct {
+	pagination.Input
+	Brand              string
+	Model              string
+	Color              string
+	YearFrom           uint
+	YearTo             uint
+	LicensePlatePrefix string
 }
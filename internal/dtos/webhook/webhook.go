@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+)
+
+type Output struct {
+	Uuid      uuid.UUID      `db:"uuid"`
+	TenantID  uuid.UUID      `db:"tenant_id"`
+	URL       string         `db:"url"`
+	Secret    string         `db:"secret"`
+	Events    pq.StringArray `db:"events"`
+	Active    bool           `db:"active"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"update_at"`
+}
+
+type CreateInput struct {
+	URL    string
+	Secret string
+	Events []string
+}
+
+// UpdateInput replaces a subscription. An empty Secret keeps the current one.
+type UpdateInput struct {
+	Uuid   uuid.UUID
+	URL    string
+	Secret string
+	Events []string
+	Active bool
+}
+
+type ListInput struct {
+	pagination.Input
+}
+
+type DeliveryOutput struct {
+	Uuid             uuid.UUID       `db:"uuid"`
+	TenantID         uuid.UUID       `db:"tenant_id"`
+	SubscriptionUUID uuid.UUID       `db:"subscription_uuid"`
+	EventUUID        uuid.UUID       `db:"event_uuid"`
+	EventName        string          `db:"event_name"`
+	Payload          json.RawMessage `db:"payload"`
+	Status           string          `db:"status"`
+	Attempts         int             `db:"attempts"`
+	LastStatusCode   sql.NullInt64   `db:"last_status_code"`
+	LastError        sql.NullString  `db:"last_error"`
+	NextAttemptAt    time.Time       `db:"next_attempt_at"`
+	DeliveredAt      sql.NullTime    `db:"delivered_at"`
+	CreatedAt        time.Time       `db:"created_at"`
+	UpdatedAt        time.Time       `db:"update_at"`
+}
+
+// DeliveryListInput pages through a subscription's delivery log, newest
+// first, optionally keeping only one status.
+type DeliveryListInput struct {
+	pagination.Input
+	Status string
+}
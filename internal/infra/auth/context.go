@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/moura95/go-ddd/internal/domain/user"
+)
+
+type claimsKey struct{}
+
+// WithClaims stores the claims of the authenticated caller in ctx.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFrom returns the claims stored by WithClaims, if any.
+func ClaimsFrom(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// Require returns user.ErrInvalidToken when ctx carries no caller and
+// user.ErrForbidden when the caller lacks perm.
+func Require(ctx context.Context, perm user.Permission) error {
+	claims, ok := ClaimsFrom(ctx)
+	if !ok {
+		return user.ErrInvalidToken
+	}
+	if !claims.Can(perm) {
+		return user.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+
+	// MinSecretLength is the HS256 key size, so the secret is no weaker
+	// than the hash.
+	MinSecretLength = 32
+
+	defaultIssuer    = "go-ddd"
+	defaultAccessTTL = 15 * time.Minute
+)
+
+// Claims are what an access token asserts about its bearer.
+// Roles are the ones held when the token was issued, so a role change
+// reaches the caller with the next refresh.
+type Claims struct {
+	UserUUID uuid.UUID
+	// TenantUUID is the tenant whose fleet the caller works on.
+	TenantUUID uuid.UUID
+	Email      string
+	Roles      []user.Role
+	ExpiresAt  time.Time
+
+	// APIKeyUUID is set when the caller used an API key rather than an
+	// access token. The key acts for UserUUID, its creator, but may only
+	// use Scopes.
+	APIKeyUUID uuid.UUID
+	Scopes     []user.Permission
+}
+
+// Can reports whether the claimed roles, or the scopes of an API key,
+// grant perm.
+func (c *Claims) Can(perm user.Permission) bool {
+	if c.APIKeyUUID != uuid.Nil {
+		for _, scope := range c.Scopes {
+			if scope == perm {
+				return true
+			}
+		}
+		return false
+	}
+	return user.Can(c.Roles, perm)
+}
+
+type accessClaims struct {
+	Email  string      `json:"email"`
+	Tenant string      `json:"tenant"`
+	Roles  []user.Role `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// Signer issues and verifies the short-lived access tokens. They are JWTs
+// signed with HS256 and a shared secret or with RS256 and a key pair.
+type Signer struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	issuer    string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewSigner reads the algorithm and keys from the config. RS256 reads the
+// private key from AUTH_JWT_PRIVATE_KEY_FILE and verifies with its public
+// half unless AUTH_JWT_PUBLIC_KEY_FILE names another one.
+func NewSigner(cfg cfg.Config) (*Signer, error) {
+	s := &Signer{issuer: cfg.AuthJWTIssuer, ttl: cfg.AuthAccessTokenTTL, now: time.Now}
+	if s.issuer == "" {
+		s.issuer = defaultIssuer
+	}
+	if s.ttl <= 0 {
+		s.ttl = defaultAccessTTL
+	}
+
+	switch cfg.AuthJWTAlgorithm {
+	case "", AlgorithmHS256:
+		if len(cfg.AuthJWTSecret) < MinSecretLength {
+			return nil, fmt.Errorf("AUTH_JWT_SECRET must have at least %d characters", MinSecretLength)
+		}
+		s.method = jwt.SigningMethodHS256
+		s.signKey, s.verifyKey = []byte(cfg.AuthJWTSecret), []byte(cfg.AuthJWTSecret)
+	case AlgorithmRS256:
+		raw, err := os.ReadFile(cfg.AuthJWTPrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read AUTH_JWT_PRIVATE_KEY_FILE %w", err)
+		}
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse AUTH_JWT_PRIVATE_KEY_FILE %w", err)
+		}
+		public := &private.PublicKey
+		if cfg.AuthJWTPublicKeyFile != "" {
+			raw, err := os.ReadFile(cfg.AuthJWTPublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read AUTH_JWT_PUBLIC_KEY_FILE %w", err)
+			}
+			if public, err = jwt.ParseRSAPublicKeyFromPEM(raw); err != nil {
+				return nil, fmt.Errorf("failed to parse AUTH_JWT_PUBLIC_KEY_FILE %w", err)
+			}
+		}
+		s.method = jwt.SigningMethodRS256
+		s.signKey, s.verifyKey = private, public
+	default:
+		return nil, fmt.Errorf("unknown AUTH_JWT_ALGORITHM %q", cfg.AuthJWTAlgorithm)
+	}
+	return s, nil
+}
+
+// Issue returns an access token for u and when it expires.
+func (s *Signer) Issue(u *user.User) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+	claims := accessClaims{
+		Email:  u.Email,
+		Tenant: u.TenantID.String(),
+		Roles:  u.Roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    s.issuer,
+			Subject:   u.Uuid.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(s.method, claims).SignedString(s.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Verify checks the signature, algorithm, issuer and expiry of token and
+// returns user.ErrInvalidToken when any of them is off.
+func (s *Signer) Verify(token string) (*Claims, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{s.method.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil {
+		return nil, user.ErrInvalidToken
+	}
+	uid, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, user.ErrInvalidToken
+	}
+	// tokens issued before tenants existed carry none; their users all
+	// belong to the default tenant
+	tenantID := tenant.Default
+	if claims.Tenant != "" {
+		if tenantID, err = uuid.Parse(claims.Tenant); err != nil {
+			return nil, user.ErrInvalidToken
+		}
+	}
+	return &Claims{
+		UserUUID:   uid,
+		TenantUUID: tenantID,
+		Email:      claims.Email,
+		Roles:      claims.Roles,
+		ExpiresAt:  claims.ExpiresAt.Time,
+	}, nil
+}
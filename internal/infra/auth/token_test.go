@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func testUser() *user.User {
+	return &user.User{Uuid: uuid.New(), TenantID: uuid.New(), Email: "admin@example.com", Roles: []user.Role{user.RoleAdmin}, Active: true}
+}
+
+func TestSignerHS256RoundTrip(t *testing.T) {
+	signer, err := NewSigner(cfg.Config{AuthJWTSecret: secret, AuthAccessTokenTTL: time.Minute})
+	assert.NoError(t, err)
+	u := testUser()
+
+	token, expiresAt, err := signer.Issue(u)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, time.Second)
+
+	claims, err := signer.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, u.Uuid, claims.UserUUID)
+	assert.Equal(t, u.TenantID, claims.TenantUUID)
+	assert.Equal(t, u.Email, claims.Email)
+	assert.Equal(t, u.Roles, claims.Roles)
+	assert.True(t, claims.Can(user.PermDriverHardDelete))
+}
+
+func TestSignerRejectsBadTokens(t *testing.T) {
+	signer, err := NewSigner(cfg.Config{AuthJWTSecret: secret, AuthAccessTokenTTL: time.Minute})
+	assert.NoError(t, err)
+	token, _, err := signer.Issue(testUser())
+	assert.NoError(t, err)
+
+	other, err := NewSigner(cfg.Config{AuthJWTSecret: secret + "-other"})
+	assert.NoError(t, err)
+	_, err = other.Verify(token)
+	assert.ErrorIs(t, err, user.ErrInvalidToken, "signed with another secret")
+
+	otherIssuer, err := NewSigner(cfg.Config{AuthJWTSecret: secret, AuthJWTIssuer: "someone-else"})
+	assert.NoError(t, err)
+	_, err = otherIssuer.Verify(token)
+	assert.ErrorIs(t, err, user.ErrInvalidToken, "issued by someone else")
+
+	signer.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = signer.Verify(token)
+	assert.ErrorIs(t, err, user.ErrInvalidToken, "expired")
+
+	_, err = signer.Verify("not.a.token")
+	assert.ErrorIs(t, err, user.ErrInvalidToken)
+}
+
+func TestSignerRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	assert.NoError(t, os.WriteFile(path, pemBytes, 0o600))
+
+	signer, err := NewSigner(cfg.Config{AuthJWTAlgorithm: AlgorithmRS256, AuthJWTPrivateKeyFile: path})
+	assert.NoError(t, err)
+	token, _, err := signer.Issue(testUser())
+	assert.NoError(t, err)
+	_, err = signer.Verify(token)
+	assert.NoError(t, err)
+
+	// a token must not be accepted under another algorithm than configured
+	hs, err := NewSigner(cfg.Config{AuthJWTSecret: secret})
+	assert.NoError(t, err)
+	_, err = hs.Verify(token)
+	assert.ErrorIs(t, err, user.ErrInvalidToken)
+}
+
+func TestNewSignerRejectsWeakConfig(t *testing.T) {
+	_, err := NewSigner(cfg.Config{AuthJWTSecret: "short"})
+	assert.Error(t, err)
+	_, err = NewSigner(cfg.Config{AuthJWTAlgorithm: "none", AuthJWTSecret: secret})
+	assert.Error(t, err)
+	_, err = NewSigner(cfg.Config{AuthJWTAlgorithm: AlgorithmRS256, AuthJWTPrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+}
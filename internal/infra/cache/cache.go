@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTTL     = 5 * time.Minute
+	defaultListTTL = 30 * time.Second
+	defaultLRUSize = 1000
+	// redisTimeout bounds every Redis call, so an unreachable server costs a
+	// request little before the fallback takes over.
+	redisTimeout = 250 * time.Millisecond
+)
+
+// DriverRelations is the generation of everything a driver aggregate is
+// built from besides the driver row: its vehicles and its assignments.
+// Writes to those bump it, which retires every cached driver aggregate and,
+// since they move the drivers' versions too, every cached driver page.
+const DriverRelations = "driver:relations"
+
+// Cache stores JSON encoded values in a Store and counts hits and misses per
+// namespace. Backend failures are logged and treated as misses: the cache
+// never fails a request.
+type Cache struct {
+	store   Store
+	ttl     time.Duration
+	listTTL time.Duration
+	logger  *zap.SugaredLogger
+
+	mu     sync.Mutex
+	counts map[string]*Counters
+}
+
+// Counters are the hits and misses of one namespace.
+type Counters struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+func New(store Store, cfg cfg.Config, log *zap.SugaredLogger) *Cache {
+	c := &Cache{
+		store:   store,
+		ttl:     cfg.CacheTTL,
+		listTTL: cfg.CacheListTTL,
+		logger:  log,
+		counts:  map[string]*Counters{},
+	}
+	if c.ttl <= 0 {
+		c.ttl = defaultTTL
+	}
+	if c.listTTL <= 0 {
+		c.listTTL = defaultListTTL
+	}
+	return c
+}
+
+// NewFromConfig uses Redis at cfg.RedisAddress, falling back to an
+// in-process LRU while Redis is down, or the LRU alone when no address is
+// configured.
+func NewFromConfig(cfg cfg.Config, log *zap.SugaredLogger) *Cache {
+	size := cfg.CacheLRUSize
+	if size <= 0 {
+		size = defaultLRUSize
+	}
+	lru := NewLRUStore(size)
+	if cfg.RedisAddress == "" {
+		log.Infof("Cache using in-process LRU, REDIS_ADDRESS is not set")
+		return New(lru, cfg, log)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:         cfg.RedisAddress,
+		Password:     cfg.RedisPassword,
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+		MaxRetries:   -1,
+	})
+	return New(NewFallbackStore(NewRedisStore(client), lru, log), cfg, log)
+}
+
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *Cache) ListTTL() time.Duration {
+	return c.listTTL
+}
+
+// Get decodes the value at key into dest and reports whether it was found.
+// The lookup is counted under namespace.
+func (c *Cache) Get(ctx context.Context, namespace, key string, dest interface{}) bool {
+	raw, err := c.store.Get(ctx, key)
+	if err == nil {
+		if err = json.Unmarshal(raw, dest); err == nil {
+			c.count(namespace, true)
+			return true
+		}
+	}
+	if !errors.Is(err, ErrMiss) {
+		logging.FromContext(ctx, c.logger).Warnf("Failed Cache Get %s: %s", key, err)
+	}
+	c.count(namespace, false)
+	return false
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	raw, err := json.Marshal(value)
+	if err == nil {
+		err = c.store.Set(ctx, key, raw, ttl)
+	}
+	if err != nil {
+		logging.FromContext(ctx, c.logger).Warnf("Failed Cache Set %s: %s", key, err)
+	}
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) {
+	if err := c.store.Delete(ctx, keys...); err != nil {
+		logging.FromContext(ctx, c.logger).Warnf("Failed Cache Delete %v: %s", keys, err)
+	}
+}
+
+// Generation returns a tag for the current generation of name. Keys that
+// embed it are retired all at once by Bump, which is how list pages are
+// invalidated.
+func (c *Cache) Generation(ctx context.Context, name string) string {
+	n, err := c.store.Counter(ctx, "gen:"+name)
+	if err != nil {
+		logging.FromContext(ctx, c.logger).Warnf("Failed Cache Generation %s: %s", name, err)
+	}
+	return "g" + strconv.FormatInt(n, 10)
+}
+
+func (c *Cache) Bump(ctx context.Context, name string) {
+	if _, err := c.store.Incr(ctx, "gen:"+name); err != nil {
+		logging.FromContext(ctx, c.logger).Warnf("Failed Cache Bump %s: %s", name, err)
+	}
+}
+
+// Stats returns a copy of the counters by namespace.
+func (c *Cache) Stats() map[string]Counters {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]Counters, len(c.counts))
+	for namespace, counts := range c.counts {
+		out[namespace] = *counts
+	}
+	return out
+}
+
+func (c *Cache) count(namespace string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts, ok := c.counts[namespace]
+	if !ok {
+		counts = &Counters{}
+		c.counts[namespace] = counts
+	}
+	if hit {
+		counts.Hits++
+	} else {
+		counts.Misses++
+	}
+}
+
+// Hash returns a short stable digest of v's JSON form, for building keys
+// out of list filters.
+func Hash(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		raw = []byte(fmt.Sprintf("%#v", v))
+	}
+	sum := sha1.Sum(raw)
+	return hex.EncodeToString(sum[:10])
+}
+
+// TenantScope returns the tenant of ctx for prefixing keys, so tenants never
+// read each other's entries even for rows they could guess the uuid of.
+func TenantScope(ctx context.Context) string {
+	id, _ := tenant.FromContext(ctx)
+	return id.String()
+}
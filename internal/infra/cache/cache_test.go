@@ -0,0 +1,173 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moura95/go-ddd/internal/infra/cache"
+	"github.com/moura95/go-ddd/internal/infra/cache/redistest"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newRedisStore(t *testing.T) (cache.Store, *redistest.Server) {
+	server, err := redistest.NewServer()
+	assert.NoError(t, err)
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{
+		Addr:         server.Addr(),
+		DialTimeout:  100 * time.Millisecond,
+		ReadTimeout:  100 * time.Millisecond,
+		WriteTimeout: 100 * time.Millisecond,
+		MaxRetries:   -1,
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	return cache.NewRedisStore(client), server
+}
+
+func TestLRUStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewLRUStore(2)
+
+	assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, store.Set(ctx, "b", []byte("2"), time.Minute))
+	_, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, err = store.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+	value, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(value))
+}
+
+func TestLRUStoreExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewLRUStore(10)
+
+	assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
+
+func TestLRUStoreCountersSurviveEviction(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewLRUStore(1)
+
+	_, err := store.Incr(ctx, "gen")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, store.Set(ctx, "b", []byte("2"), time.Minute))
+
+	n, err := store.Counter(ctx, "gen")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	store, server := newRedisStore(t)
+
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+	value, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(value))
+	assert.Equal(t, []string{"go-ddd:a"}, server.Keys())
+
+	assert.NoError(t, store.Delete(ctx, "a"))
+	_, err = store.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+
+	n, err := store.Counter(ctx, "gen")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	n, err = store.Incr(ctx, "gen")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestFallbackStoreServesWhileRedisIsDown(t *testing.T) {
+	ctx := context.Background()
+	primary, server := newRedisStore(t)
+	lru := cache.NewLRUStore(10)
+	store := cache.NewFallbackStore(primary, lru, zap.NewNop().Sugar())
+
+	assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+	_, err := lru.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss, "values go to redis while it is up")
+
+	server.Close()
+
+	assert.NoError(t, store.Set(ctx, "b", []byte("2"), time.Minute))
+	value, err := store.Get(ctx, "b")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", string(value))
+
+	_, err = store.Incr(ctx, "gen")
+	assert.NoError(t, err)
+	n, err := store.Counter(ctx, "gen")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestFallbackStoreReplaysInvalidationsWhenRedisRecovers(t *testing.T) {
+	ctx := context.Background()
+	primary, server := newRedisStore(t)
+	store := cache.NewFallbackStore(primary, cache.NewLRUStore(10), zap.NewNop().Sugar())
+	cache.SetRetryPrimaryAfter(store, 10*time.Millisecond)
+
+	assert.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+	_, err := store.Incr(ctx, "gen")
+	assert.NoError(t, err)
+
+	server.Close()
+	assert.NoError(t, store.Delete(ctx, "a"))
+	_, err = store.Incr(ctx, "gen")
+	assert.NoError(t, err)
+	_, err = store.Incr(ctx, "gen")
+	assert.NoError(t, err)
+
+	assert.NoError(t, server.Restart())
+	assert.Eventually(t, func() bool {
+		_, err := store.Get(ctx, "a")
+		return errors.Is(err, cache.ErrMiss)
+	}, time.Second, 20*time.Millisecond)
+	_, err = primary.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss, "other replicas reading redis see the delete")
+
+	n, err := primary.Counter(ctx, "gen")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n, "redis' generation moves past the one cached under")
+}
+
+func TestCacheCountsHitsAndMisses(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(cache.NewLRUStore(10), cfg.Config{}, zap.NewNop().Sugar())
+	assert.Equal(t, 5*time.Minute, c.TTL())
+
+	var got map[string]int
+	assert.False(t, c.Get(ctx, "things", "k", &got))
+	c.Set(ctx, "k", map[string]int{"a": 1}, c.TTL())
+	assert.True(t, c.Get(ctx, "things", "k", &got))
+	assert.Equal(t, 1, got["a"])
+
+	assert.Equal(t, cache.Counters{Hits: 1, Misses: 1}, c.Stats()["things"])
+}
+
+func TestCacheGenerationBump(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(cache.NewLRUStore(10), cfg.Config{}, zap.NewNop().Sugar())
+
+	before := c.Generation(ctx, "list")
+	c.Bump(ctx, "list")
+	assert.NotEqual(t, before, c.Generation(ctx, "list"))
+}
@@ -0,0 +1,9 @@
+package cache
+
+import "time"
+
+// SetRetryPrimaryAfter shortens how long a fallback store waits before trying
+// primary again, so tests need not sleep for the production interval.
+func SetRetryPrimaryAfter(store Store, d time.Duration) {
+	store.(*fallbackStore).retryAfter = d
+}
@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// retryPrimaryAfter is how long the fallback is used after the primary
+// failed before the primary is tried again.
+const retryPrimaryAfter = 5 * time.Second
+
+// fallbackStore serves from primary and switches to fallback while primary
+// is failing. Invalidations made during an outage are remembered and replayed
+// onto primary before it serves again, so neither this process nor the other
+// replicas read entries that were invalidated while it was down.
+type fallbackStore struct {
+	primary    Store
+	fallback   Store
+	logger     *zap.SugaredLogger
+	retryAfter time.Duration
+
+	mu        sync.Mutex
+	downUntil time.Time
+	// missedDeletes and missedIncrs are the keys deleted and the counters
+	// incremented while primary was down.
+	missedDeletes map[string]bool
+	missedIncrs   map[string]bool
+}
+
+func NewFallbackStore(primary, fallback Store, log *zap.SugaredLogger) Store {
+	return &fallbackStore{
+		primary:       primary,
+		fallback:      fallback,
+		logger:        log,
+		retryAfter:    retryPrimaryAfter,
+		missedDeletes: map[string]bool{},
+		missedIncrs:   map[string]bool{},
+	}
+}
+
+func (s *fallbackStore) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := s.do(ctx, func(store Store) error {
+		var err error
+		value, err = store.Get(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+func (s *fallbackStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.do(ctx, func(store Store) error {
+		return store.Set(ctx, key, value, ttl)
+	})
+}
+
+// Delete always clears the fallback too, so it holds nothing stale the next
+// time primary goes down.
+func (s *fallbackStore) Delete(ctx context.Context, keys ...string) error {
+	err := s.fallback.Delete(ctx, keys...)
+	if s.primaryUp(ctx) {
+		perr := s.primary.Delete(ctx, keys...)
+		if perr == nil {
+			return nil
+		}
+		s.markDown(perr)
+	}
+	s.miss(keys, nil)
+	return err
+}
+
+func (s *fallbackStore) Counter(ctx context.Context, key string) (int64, error) {
+	var n int64
+	err := s.do(ctx, func(store Store) error {
+		var err error
+		n, err = store.Counter(ctx, key)
+		return err
+	})
+	return n, err
+}
+
+// Incr also advances the fallback's counter, for the same reason as Delete,
+// and only once even when primary fails.
+func (s *fallbackStore) Incr(ctx context.Context, key string) (int64, error) {
+	n, err := s.fallback.Incr(ctx, key)
+	if s.primaryUp(ctx) {
+		pn, perr := s.primary.Incr(ctx, key)
+		if perr == nil {
+			return pn, nil
+		}
+		s.markDown(perr)
+	}
+	s.miss(nil, []string{key})
+	return n, err
+}
+
+func (s *fallbackStore) do(ctx context.Context, fn func(store Store) error) error {
+	if s.primaryUp(ctx) {
+		err := fn(s.primary)
+		if err == nil || errors.Is(err, ErrMiss) {
+			return err
+		}
+		s.markDown(err)
+	}
+	return fn(s.fallback)
+}
+
+// primaryUp reports whether primary should be tried. The first call after an
+// outage replays the missed invalidations onto primary, holding the lock so
+// nothing reads from primary before they are applied.
+func (s *fallbackStore) primaryUp(ctx context.Context) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !time.Now().After(s.downUntil) {
+		return false
+	}
+	if len(s.missedDeletes) == 0 && len(s.missedIncrs) == 0 {
+		return true
+	}
+	if err := s.replay(ctx); err != nil {
+		s.markDownLocked(err)
+		return false
+	}
+	s.logger.Infof("Cache backend recovered, replayed %d deletes and %d counter bumps", len(s.missedDeletes), len(s.missedIncrs))
+	s.missedDeletes = map[string]bool{}
+	s.missedIncrs = map[string]bool{}
+	return true
+}
+
+// replay applies the missed invalidations. Bumping a counter once is enough
+// however often it was bumped during the outage: primary's counters only
+// grow, so the new value has never been used as a key.
+func (s *fallbackStore) replay(ctx context.Context) error {
+	if len(s.missedDeletes) > 0 {
+		keys := make([]string, 0, len(s.missedDeletes))
+		for key := range s.missedDeletes {
+			keys = append(keys, key)
+		}
+		if err := s.primary.Delete(ctx, keys...); err != nil {
+			return err
+		}
+	}
+	for key := range s.missedIncrs {
+		if _, err := s.primary.Incr(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fallbackStore) miss(keys, counters []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		s.missedDeletes[key] = true
+	}
+	for _, key := range counters {
+		s.missedIncrs[key] = true
+	}
+}
+
+func (s *fallbackStore) markDown(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markDownLocked(err)
+}
+
+func (s *fallbackStore) markDownLocked(err error) {
+	if time.Now().After(s.downUntil) {
+		s.logger.Warnf("Cache backend unavailable, using in-process cache for %s: %s", s.retryAfter, err)
+	}
+	s.downUntil = time.Now().Add(s.retryAfter)
+}
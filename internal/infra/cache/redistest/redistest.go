@@ -0,0 +1,228 @@
+// Package redistest provides an in-memory stand-in for Redis, so the Redis
+// backed cache can be tested without a server.
+package redistest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type item struct {
+	value     string
+	expiresAt time.Time
+}
+
+// Server speaks the subset of RESP the cache uses: PING, GET, SET with EX or
+// PX, DEL and INCR.
+type Server struct {
+	addr string
+
+	mu       sync.Mutex
+	listener net.Listener
+	data     map[string]item
+	conns    map[net.Conn]bool
+}
+
+// NewServer listens on a random local port.
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{addr: listener.Addr().String(), listener: listener, data: map[string]item{}, conns: map[net.Conn]bool{}}
+	go s.serve(listener)
+	return s, nil
+}
+
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Close stops the server and drops open connections, like a Redis outage.
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+// Restart listens again on the same address after Close, keeping the data,
+// like Redis coming back from an outage.
+func (s *Server) Restart() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+	go s.serve(listener)
+	return nil
+}
+
+// Keys returns the live keys.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := []string{}
+	for key, it := range s.data {
+		if s.live(it) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *Server) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = true
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+// arity is the minimum number of arguments of each command, its name
+// included.
+var arity = map[string]int{"PING": 1, "GET": 2, "SET": 3, "DEL": 2, "INCR": 2}
+
+func (s *Server) exec(args []string) string {
+	if len(args) == 0 {
+		return "-ERR empty command\r\n"
+	}
+	if min, ok := arity[strings.ToUpper(args[0])]; ok && len(args) < min {
+		return fmt.Sprintf("-ERR wrong number of arguments for '%s' command\r\n", args[0])
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		it, ok := s.data[args[1]]
+		if !ok || !s.live(it) {
+			return "$-1\r\n"
+		}
+		return bulk(it.value)
+	case "SET":
+		it := item{value: args[2]}
+		for i := 3; i+1 < len(args); i += 2 {
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return "-ERR value is not an integer or out of range\r\n"
+			}
+			switch strings.ToUpper(args[i]) {
+			case "EX":
+				it.expiresAt = time.Now().Add(time.Duration(n) * time.Second)
+			case "PX":
+				it.expiresAt = time.Now().Add(time.Duration(n) * time.Millisecond)
+			}
+		}
+		s.data[args[1]] = it
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.data[key]; ok {
+				delete(s.data, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "INCR":
+		n := 0
+		if it, ok := s.data[args[1]]; ok && s.live(it) {
+			var err error
+			if n, err = strconv.Atoi(it.value); err != nil {
+				return "-ERR value is not an integer or out of range\r\n"
+			}
+		}
+		n++
+		s.data[args[1]] = item{value: strconv.Itoa(n)}
+		return fmt.Sprintf(":%d\r\n", n)
+	}
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+func (s *Server) live(it item) bool {
+	return it.expiresAt.IsZero() || time.Now().Before(it.expiresAt)
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+// readCommand reads one RESP array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, errors.New("redistest: expected an array")
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		header, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(header, "$") {
+			return nil, errors.New("redistest: expected a bulk string")
+		}
+		size, err := strconv.Atoi(header[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -1,15 +1,79 @@
 package cfg
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	DBSource          string `mapstructure:"DB_SOURCE"`
-	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GinMode           string `mapstructure:"GIN_MODE"`
-	RedisAddress      string `mapstructure:"REDIS_ADDRESS"`
-	RedisPassword     string `mapstructure:"REDIS_PASSWORD"`
+	DBSource          string        `mapstructure:"DB_SOURCE"`
+	HTTPServerAddress string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GinMode           string        `mapstructure:"GIN_MODE"`
+	RedisAddress      string        `mapstructure:"REDIS_ADDRESS"`
+	RedisPassword     string        `mapstructure:"REDIS_PASSWORD"`
+	DBQueryTimeout    time.Duration `mapstructure:"DB_QUERY_TIMEOUT"`
+	// DBRowLevelSecurity confines every statement to the caller's tenant
+	// with the row-level security policies, on top of the repositories'
+	// scoping; background jobs run as database.SystemRole
+	DBRowLevelSecurity bool `mapstructure:"DB_ROW_LEVEL_SECURITY"`
+
+	// HTTPTrustedProxies are the comma separated proxy IPs or CIDRs whose
+	// X-Forwarded-For is believed; by default the client IP is the peer's
+	HTTPTrustedProxies []string `mapstructure:"HTTP_TRUSTED_PROXIES"`
+
+	AssignmentSinglePrimaryDriver    bool `mapstructure:"ASSIGNMENT_SINGLE_PRIMARY_DRIVER"`
+	AssignmentRejectDeleted          bool `mapstructure:"ASSIGNMENT_REJECT_DELETED"`
+	AssignmentRequireLicenseCategory bool `mapstructure:"ASSIGNMENT_REQUIRE_LICENSE_CATEGORY"`
+
+	OutboxPollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxBatchSize    int           `mapstructure:"OUTBOX_BATCH_SIZE"`
+
+	WebhookPollInterval time.Duration `mapstructure:"WEBHOOK_POLL_INTERVAL"`
+	WebhookTimeout      time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts  int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+
+	CacheEnabled bool          `mapstructure:"CACHE_ENABLED"`
+	CacheTTL     time.Duration `mapstructure:"CACHE_TTL"`
+	CacheListTTL time.Duration `mapstructure:"CACHE_LIST_TTL"`
+	CacheLRUSize int           `mapstructure:"CACHE_LRU_SIZE"`
+
+	RateLimitEnabled  bool          `mapstructure:"RATE_LIMIT_ENABLED"`
+	RateLimitBackend  string        `mapstructure:"RATE_LIMIT_BACKEND"`
+	RateLimitRequests int           `mapstructure:"RATE_LIMIT_REQUESTS"`
+	RateLimitPeriod   time.Duration `mapstructure:"RATE_LIMIT_PERIOD"`
+	// RateLimitRoutes and RateLimitClients are comma separated
+	// "<route or client IP>=<limit>/<period>" overrides
+	RateLimitRoutes  []string `mapstructure:"RATE_LIMIT_ROUTES"`
+	RateLimitClients []string `mapstructure:"RATE_LIMIT_CLIENTS"`
+
+	// MetricsEnabled serves Prometheus metrics on /metrics to callers
+	// holding system:read
+	MetricsEnabled bool `mapstructure:"METRICS_ENABLED"`
+
+	// TracingExporter is off, stdout or otlp, sending spans over OTLP/HTTP
+	// to TracingOTLPEndpoint
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingServiceName  string  `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
+	AuthEnabled bool `mapstructure:"AUTH_ENABLED"`
+	// AuthJWTAlgorithm is HS256, signing with AuthJWTSecret, or RS256,
+	// signing with the PEM key in AuthJWTPrivateKeyFile
+	AuthJWTAlgorithm      string        `mapstructure:"AUTH_JWT_ALGORITHM"`
+	AuthJWTSecret         string        `mapstructure:"AUTH_JWT_SECRET"`
+	AuthJWTPrivateKeyFile string        `mapstructure:"AUTH_JWT_PRIVATE_KEY_FILE"`
+	AuthJWTPublicKeyFile  string        `mapstructure:"AUTH_JWT_PUBLIC_KEY_FILE"`
+	AuthJWTIssuer         string        `mapstructure:"AUTH_JWT_ISSUER"`
+	AuthAccessTokenTTL    time.Duration `mapstructure:"AUTH_ACCESS_TOKEN_TTL"`
+	AuthRefreshTokenTTL   time.Duration `mapstructure:"AUTH_REFRESH_TOKEN_TTL"`
+	// AuthAPIKeyTTL is how long API keys issued without an expiry last
+	AuthAPIKeyTTL time.Duration `mapstructure:"AUTH_API_KEY_TTL"`
+	// AuthBootstrapEmail and AuthBootstrapPassword create the first user at
+	// startup unless it already exists; nothing is created without both
+	AuthBootstrapEmail    string `mapstructure:"AUTH_BOOTSTRAP_EMAIL"`
+	AuthBootstrapPassword string `mapstructure:"AUTH_BOOTSTRAP_PASSWORD"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -17,6 +81,44 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigType("env")
 	viper.SetConfigFile(".env")
 
+	viper.SetDefault("HTTP_TRUSTED_PROXIES", "")
+	viper.SetDefault("DB_QUERY_TIMEOUT", "10s")
+	viper.SetDefault("DB_ROW_LEVEL_SECURITY", false)
+	viper.SetDefault("ASSIGNMENT_SINGLE_PRIMARY_DRIVER", true)
+	viper.SetDefault("ASSIGNMENT_REJECT_DELETED", true)
+	viper.SetDefault("ASSIGNMENT_REQUIRE_LICENSE_CATEGORY", true)
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("WEBHOOK_POLL_INTERVAL", "1s")
+	viper.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("CACHE_ENABLED", true)
+	viper.SetDefault("CACHE_TTL", "5m")
+	viper.SetDefault("CACHE_LIST_TTL", "30s")
+	viper.SetDefault("CACHE_LRU_SIZE", 1000)
+	viper.SetDefault("RATE_LIMIT_ENABLED", true)
+	viper.SetDefault("RATE_LIMIT_BACKEND", "memory")
+	viper.SetDefault("RATE_LIMIT_REQUESTS", 4000)
+	viper.SetDefault("RATE_LIMIT_PERIOD", "60s")
+	viper.SetDefault("RATE_LIMIT_ROUTES", "")
+	viper.SetDefault("RATE_LIMIT_CLIENTS", "")
+	viper.SetDefault("METRICS_ENABLED", true)
+	viper.SetDefault("TRACING_EXPORTER", "off")
+	viper.SetDefault("TRACING_OTLP_ENDPOINT", "http://localhost:4318")
+	viper.SetDefault("TRACING_SERVICE_NAME", "go-ddd")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("AUTH_ENABLED", true)
+	viper.SetDefault("AUTH_JWT_ALGORITHM", "HS256")
+	viper.SetDefault("AUTH_JWT_SECRET", "")
+	viper.SetDefault("AUTH_JWT_PRIVATE_KEY_FILE", "")
+	viper.SetDefault("AUTH_JWT_PUBLIC_KEY_FILE", "")
+	viper.SetDefault("AUTH_JWT_ISSUER", "go-ddd")
+	viper.SetDefault("AUTH_ACCESS_TOKEN_TTL", "15m")
+	viper.SetDefault("AUTH_REFRESH_TOKEN_TTL", "720h")
+	viper.SetDefault("AUTH_API_KEY_TTL", "2160h")
+	viper.SetDefault("AUTH_BOOTSTRAP_EMAIL", "")
+	viper.SetDefault("AUTH_BOOTSTRAP_PASSWORD", "")
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/moura95/go-ddd/internal/infra/cfg"
@@ -38,3 +41,12 @@ func (c *conn) Close() error {
 func (c *conn) DB() *sqlx.DB {
 	return c.db
 }
+
+// WithQueryTimeout bounds ctx by the configured query deadline. A non-positive
+// timeout leaves ctx untouched so only the caller's cancellation applies.
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// UniqueViolation reports whether err is a Postgres unique violation and, if
+// so, the name of the constraint that was violated.
+func UniqueViolation(err error) (string, bool) {
+	return constraintViolation(err, uniqueViolation)
+}
+
+// ForeignKeyViolation reports whether err is a Postgres foreign key violation
+// and, if so, the name of the constraint that was violated.
+func ForeignKeyViolation(err error) (string, bool) {
+	return constraintViolation(err, foreignKeyViolation)
+}
+
+func constraintViolation(err error, code pq.ErrorCode) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == code {
+		return pqErr.Constraint, true
+	}
+	return "", false
+}
+
+// ExpectAffected returns notFound when the statement did not touch any row.
+func ExpectAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// ExpectVersioned is ExpectAffected for writes guarded by a version check.
+// When no row was touched it looks the row up to tell a missing row, reported
+// as notFound, from a stale version, reported as stale. Rows of another
+// tenant count as missing.
+func ExpectVersioned(ctx context.Context, q Querier, result sql.Result, table string, uid, tenantID interface{}, notFound, stale error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	var exists bool
+	if err := q.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE uuid = $1 AND tenant_id = $2)", uid, tenantID); err != nil {
+		return err
+	}
+	if exists {
+		return stale
+	}
+	return notFound
+}
@@ -0,0 +1,18 @@
+package database
+
+import "strings"
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike is the LIKE pattern matching s literally, for use with
+// ESCAPE '\'.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// Contains is the LIKE pattern matching values that contain s literally,
+// for use with ESCAPE '\'.
+func Contains(s string) string {
+	return "%" + EscapeLike(s) + "%"
+}
@@ -0,0 +1,15 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLikePatterns(t *testing.T) {
+	assert.Equal(t, "red", database.EscapeLike("red"))
+	assert.Equal(t, `\_`, database.EscapeLike("_"))
+	assert.Equal(t, "%ana%", database.Contains("ana"))
+	assert.Equal(t, `%100\%\_off\\%`, database.Contains(`100%_off\`))
+}
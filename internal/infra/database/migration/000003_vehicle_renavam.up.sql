@@ -0,0 +1 @@
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS renavam VARCHAR(11) UNIQUE;
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_drivers_vehicles_vehicle_uuid;
+DROP INDEX IF EXISTS idx_drivers_vehicles_driver_uuid;
+
+DELETE FROM drivers_vehicles WHERE unassigned_at IS NOT NULL OR driver_uuid IS NULL OR vehicle_uuid IS NULL;
+
+ALTER TABLE drivers_vehicles DROP CONSTRAINT IF EXISTS drivers_vehicles_driver_uuid_fkey;
+ALTER TABLE drivers_vehicles DROP CONSTRAINT IF EXISTS drivers_vehicles_vehicle_uuid_fkey;
+ALTER TABLE drivers_vehicles ADD CONSTRAINT drivers_vehicles_driver_uuid_fkey
+    FOREIGN KEY (driver_uuid) REFERENCES drivers(uuid);
+ALTER TABLE drivers_vehicles ADD CONSTRAINT drivers_vehicles_vehicle_uuid_fkey
+    FOREIGN KEY (vehicle_uuid) REFERENCES vehicles(uuid);
+
+DROP INDEX IF EXISTS drivers_vehicles_active_key;
+ALTER TABLE drivers_vehicles ADD CONSTRAINT drivers_vehicles_driver_uuid_vehicle_uuid_key UNIQUE (driver_uuid, vehicle_uuid);
+
+ALTER TABLE drivers_vehicles DROP COLUMN IF EXISTS notes;
+ALTER TABLE drivers_vehicles DROP COLUMN IF EXISTS assigned_by;
+ALTER TABLE drivers_vehicles DROP COLUMN IF EXISTS unassigned_at;
+ALTER TABLE drivers_vehicles RENAME COLUMN assigned_at TO created_at;
+ALTER TABLE drivers_vehicles DROP CONSTRAINT IF EXISTS drivers_vehicles_pkey;
+ALTER TABLE drivers_vehicles DROP COLUMN IF EXISTS uuid;
@@ -0,0 +1,22 @@
+ALTER TABLE drivers_vehicles ADD COLUMN IF NOT EXISTS uuid UUID NOT NULL DEFAULT uuid_generate_v4();
+ALTER TABLE drivers_vehicles ADD PRIMARY KEY (uuid);
+ALTER TABLE drivers_vehicles RENAME COLUMN created_at TO assigned_at;
+ALTER TABLE drivers_vehicles ADD COLUMN IF NOT EXISTS unassigned_at TIMESTAMP;
+ALTER TABLE drivers_vehicles ADD COLUMN IF NOT EXISTS assigned_by VARCHAR(255);
+ALTER TABLE drivers_vehicles ADD COLUMN IF NOT EXISTS notes TEXT;
+
+-- history is kept, so a pair may appear many times but only once while active
+ALTER TABLE drivers_vehicles DROP CONSTRAINT IF EXISTS drivers_vehicles_driver_uuid_vehicle_uuid_key;
+CREATE UNIQUE INDEX IF NOT EXISTS drivers_vehicles_active_key
+    ON drivers_vehicles (driver_uuid, vehicle_uuid) WHERE unassigned_at IS NULL;
+
+-- hard deleting one side keeps the other side's history
+ALTER TABLE drivers_vehicles DROP CONSTRAINT IF EXISTS drivers_vehicles_driver_uuid_fkey;
+ALTER TABLE drivers_vehicles DROP CONSTRAINT IF EXISTS drivers_vehicles_vehicle_uuid_fkey;
+ALTER TABLE drivers_vehicles ADD CONSTRAINT drivers_vehicles_driver_uuid_fkey
+    FOREIGN KEY (driver_uuid) REFERENCES drivers(uuid) ON DELETE SET NULL;
+ALTER TABLE drivers_vehicles ADD CONSTRAINT drivers_vehicles_vehicle_uuid_fkey
+    FOREIGN KEY (vehicle_uuid) REFERENCES vehicles(uuid) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_drivers_vehicles_driver_uuid ON drivers_vehicles (driver_uuid);
+CREATE INDEX IF NOT EXISTS idx_drivers_vehicles_vehicle_uuid ON drivers_vehicles (vehicle_uuid);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_drivers_vehicles_active_primary;
+ALTER TABLE drivers_vehicles DROP COLUMN IF EXISTS is_primary;
+ALTER TABLE vehicles DROP COLUMN IF EXISTS category;
+ALTER TABLE drivers DROP COLUMN IF EXISTS license_category;
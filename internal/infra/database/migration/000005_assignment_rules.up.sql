@@ -0,0 +1,6 @@
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS license_category VARCHAR(5);
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS category CHAR(1);
+ALTER TABLE drivers_vehicles ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_drivers_vehicles_active_primary
+    ON drivers_vehicles (vehicle_uuid) WHERE is_primary AND unassigned_at IS NULL;
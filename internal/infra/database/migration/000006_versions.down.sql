@@ -0,0 +1,2 @@
+ALTER TABLE vehicles DROP COLUMN IF EXISTS version;
+ALTER TABLE drivers DROP COLUMN IF EXISTS version;
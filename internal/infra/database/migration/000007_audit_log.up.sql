@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGSERIAL PRIMARY KEY,
+    uuid        UUID        NOT NULL UNIQUE DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(32) NOT NULL,
+    entity_uuid UUID        NOT NULL,
+    action      VARCHAR(32) NOT NULL,
+    before      JSONB,
+    after       JSONB,
+    actor       VARCHAR     NOT NULL,
+    request_id  VARCHAR,
+    ip          VARCHAR(45),
+    created_at  TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_uuid, created_at DESC);
+
+-- Entries are evidence: reject any attempt to rewrite or remove them.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
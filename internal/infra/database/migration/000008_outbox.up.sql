@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id             BIGSERIAL PRIMARY KEY,
+    uuid           UUID         NOT NULL UNIQUE,
+    name           VARCHAR(64)  NOT NULL,
+    aggregate_type VARCHAR(32)  NOT NULL,
+    aggregate_uuid UUID         NOT NULL,
+    payload        JSONB        NOT NULL,
+    occurred_at    TIMESTAMP    NOT NULL,
+    available_at   TIMESTAMP    NOT NULL DEFAULT now(),
+    attempts       INTEGER      NOT NULL DEFAULT 0,
+    last_error     VARCHAR,
+    dispatched_at  TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (id) WHERE dispatched_at IS NULL;
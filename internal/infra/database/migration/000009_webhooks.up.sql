@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id         SERIAL PRIMARY KEY,
+    uuid       UUID         NOT NULL UNIQUE,
+    url        VARCHAR(2048) NOT NULL,
+    secret     VARCHAR(255) NOT NULL,
+    events     TEXT[]       NOT NULL,
+    active     BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP    NOT NULL DEFAULT now(),
+    update_at  TIMESTAMP    NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                BIGSERIAL PRIMARY KEY,
+    uuid              UUID        NOT NULL UNIQUE,
+    subscription_uuid UUID        NOT NULL REFERENCES webhook_subscriptions (uuid) ON DELETE CASCADE,
+    event_uuid        UUID        NOT NULL,
+    event_name        VARCHAR(64) NOT NULL,
+    payload           JSONB       NOT NULL,
+    status            VARCHAR(16) NOT NULL,
+    attempts          INTEGER     NOT NULL DEFAULT 0,
+    last_status_code  INTEGER,
+    last_error        VARCHAR,
+    next_attempt_at   TIMESTAMP   NOT NULL,
+    delivered_at      TIMESTAMP,
+    created_at        TIMESTAMP   NOT NULL DEFAULT now(),
+    update_at         TIMESTAMP   NOT NULL DEFAULT now(),
+    -- an event re-published by the outbox relay is only queued once
+    UNIQUE (subscription_uuid, event_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries (next_attempt_at) WHERE status IN ('pending', 'failed');
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_log
+    ON webhook_deliveries (subscription_uuid, created_at DESC);
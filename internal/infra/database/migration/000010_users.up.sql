@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS users (
+    id            SERIAL PRIMARY KEY,
+    uuid          UUID         NOT NULL UNIQUE,
+    email         VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    active        BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at    TIMESTAMP    NOT NULL DEFAULT now(),
+    update_at     TIMESTAMP    NOT NULL DEFAULT now()
+);
+
+-- only hashes are stored; a refresh revokes its token and issues the next
+-- one of the same family
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id          BIGSERIAL PRIMARY KEY,
+    uuid        UUID        NOT NULL UNIQUE,
+    user_uuid   UUID        NOT NULL REFERENCES users (uuid) ON DELETE CASCADE,
+    family_uuid UUID        NOT NULL,
+    token_hash  VARCHAR(64) NOT NULL UNIQUE,
+    expires_at  TIMESTAMP   NOT NULL,
+    revoked_at  TIMESTAMP,
+    created_at  TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens (family_uuid) WHERE revoked_at IS NULL;
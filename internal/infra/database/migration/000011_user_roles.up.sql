@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_uuid  UUID        NOT NULL REFERENCES users (uuid) ON DELETE CASCADE,
+    role       VARCHAR(32) NOT NULL,
+    granted_by VARCHAR,
+    granted_at TIMESTAMP   NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_uuid, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles (role);
+
+-- users created before roles existed had full access; keep it that way
+INSERT INTO user_roles (user_uuid, role, granted_by)
+SELECT uuid, 'admin', 'migration' FROM users
+ON CONFLICT DO NOTHING;
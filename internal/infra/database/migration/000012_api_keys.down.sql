@@ -0,0 +1,3 @@
+ALTER TABLE audit_log DROP COLUMN IF EXISTS api_key_uuid;
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,16 @@
+-- only hashes are stored; prefix is the public start of the key
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           BIGSERIAL PRIMARY KEY,
+    uuid         UUID         NOT NULL UNIQUE,
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(32)  NOT NULL,
+    key_hash     VARCHAR(64)  NOT NULL UNIQUE,
+    scopes       TEXT[]       NOT NULL,
+    created_by   UUID         REFERENCES users (uuid) ON DELETE SET NULL,
+    expires_at   TIMESTAMP    NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT now()
+);
+
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS api_key_uuid UUID;
@@ -0,0 +1,32 @@
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM goddd_system;
+REVOKE ALL ON ALL TABLES IN SCHEMA public FROM goddd_system;
+DROP ROLE IF EXISTS goddd_system;
+
+DROP POLICY IF EXISTS tenant_isolation ON drivers_vehicles;
+ALTER TABLE drivers_vehicles NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE drivers_vehicles DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON vehicles;
+ALTER TABLE vehicles NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE vehicles DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON drivers;
+ALTER TABLE drivers NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE drivers DISABLE ROW LEVEL SECURITY;
+
+-- fails while two tenants share an email, tax id, plate or renavam
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_renavam_key;
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_renavam_key UNIQUE (renavam);
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_license_plate_key;
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_license_plate_key UNIQUE (license_plate);
+ALTER TABLE drivers DROP CONSTRAINT IF EXISTS drivers_tax_id_key;
+ALTER TABLE drivers ADD CONSTRAINT drivers_tax_id_key UNIQUE (tax_id);
+ALTER TABLE drivers DROP CONSTRAINT IF EXISTS drivers_email_key;
+ALTER TABLE drivers ADD CONSTRAINT drivers_email_key UNIQUE (email);
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE drivers_vehicles DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE vehicles DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE drivers DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,90 @@
+CREATE TABLE IF NOT EXISTS tenants (
+    id         SERIAL PRIMARY KEY,
+    uuid       UUID         NOT NULL UNIQUE,
+    name       VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP    NOT NULL DEFAULT now()
+);
+
+-- the operator's own tenant; everything created so far belongs to it
+INSERT INTO tenants (uuid, name) VALUES ('00000000-0000-0000-0000-000000000001', 'default')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE drivers_vehicles ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS tenant_id UUID;
+
+UPDATE drivers SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE vehicles SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE drivers_vehicles SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE users SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE api_keys SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+-- the append-only trigger fires per statement, matching rows or not, so it
+-- is lifted for the backfill alone
+ALTER TABLE audit_log DISABLE TRIGGER audit_log_append_only;
+UPDATE audit_log SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+ALTER TABLE audit_log ENABLE TRIGGER audit_log_append_only;
+
+ALTER TABLE drivers ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE vehicles ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE drivers_vehicles ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE users ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE api_keys ALTER COLUMN tenant_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_drivers_tenant ON drivers (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_vehicles_tenant ON vehicles (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_drivers_vehicles_tenant ON drivers_vehicles (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_tenant ON audit_log (tenant_id);
+
+-- uniqueness holds per tenant; the constraint names stay the same so the
+-- repositories keep translating violations
+ALTER TABLE drivers DROP CONSTRAINT IF EXISTS drivers_email_key;
+ALTER TABLE drivers ADD CONSTRAINT drivers_email_key UNIQUE (tenant_id, email);
+ALTER TABLE drivers DROP CONSTRAINT IF EXISTS drivers_tax_id_key;
+ALTER TABLE drivers ADD CONSTRAINT drivers_tax_id_key UNIQUE (tenant_id, tax_id);
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_license_plate_key;
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_license_plate_key UNIQUE (tenant_id, license_plate);
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_renavam_key;
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_renavam_key UNIQUE (tenant_id, renavam);
+
+-- Row-level security backs the scoping the repositories do. When
+-- DB_ROW_LEVEL_SECURITY is on every statement of a request sets
+-- app.tenant_id; a statement that did not set it sees and writes no rows.
+-- The setting reads as NULL when it was never set in the session and as ''
+-- once a transaction that set it ended, hence the missing_ok and NULLIF:
+-- either way no tenant_id equals it, instead of the cast raising an error.
+-- Superusers bypass these policies, so the API has to connect as an
+-- ordinary role for them to apply.
+ALTER TABLE drivers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE drivers FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON drivers
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+ALTER TABLE vehicles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE vehicles FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON vehicles
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+ALTER TABLE drivers_vehicles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE drivers_vehicles FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON drivers_vehicles
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+-- The outbox relay, the webhook dispatcher and the fleet metrics act for
+-- every tenant; they switch to this role, which bypasses the policies. The
+-- role the API connects as has to be granted it:
+--   GRANT goddd_system TO <api role>;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'goddd_system') THEN
+        CREATE ROLE goddd_system NOLOGIN BYPASSRLS;
+    END IF;
+END
+$$;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO goddd_system;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO goddd_system;
@@ -0,0 +1,15 @@
+-- webhooks and the events feeding them belong to a tenant too; everything
+-- queued so far belongs to the operator's own
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants (uuid);
+
+UPDATE webhook_subscriptions SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE webhook_deliveries SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+UPDATE outbox SET tenant_id = '00000000-0000-0000-0000-000000000001' WHERE tenant_id IS NULL;
+
+ALTER TABLE webhook_subscriptions ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE webhook_deliveries ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE outbox ALTER COLUMN tenant_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant ON webhook_subscriptions (tenant_id);
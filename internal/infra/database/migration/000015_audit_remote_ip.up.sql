@@ -0,0 +1,3 @@
+-- ip is the client the request claimed to come from; remote_ip is the peer
+-- that actually sent it.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS remote_ip VARCHAR(45);
@@ -0,0 +1,19 @@
+UPDATE drivers AS d SET tax_id = v.old_tax_id, driver_license = v.old_driver_license
+FROM (VALUES
+    ('motorista1@example.com', '12345678901', 'ABC12345', '12345678909', '12345000189'),
+    ('motorista2@example.com', '23456789012', 'XYZ54321', '23456789092', '54321000279'),
+    ('motorista3@example.com', '34567890123', 'DEF67890', '34567890175', '67890000338'),
+    ('motorista4@example.com', '45678901234', 'GHI56789', '45678901249', '56789000488'),
+    ('motorista5@example.com', '56789012345', 'JKL98765', '56789012303', '98765000578')
+) AS v (email, old_tax_id, old_driver_license, tax_id, driver_license)
+WHERE d.email = v.email AND d.tax_id = v.tax_id AND d.driver_license = v.driver_license;
+
+UPDATE vehicles AS ve SET license_plate = v.old_license_plate
+FROM (VALUES
+    ('ABC123', 'ABC1234'),
+    ('XYZ987', 'XYZ9876'),
+    ('DEF456', 'DEF4567'),
+    ('GHI789', 'GHI7890'),
+    ('JKL321', 'JKL3210')
+) AS v (old_license_plate, license_plate)
+WHERE ve.license_plate = v.license_plate;
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// SystemRole is the role background jobs switch to under row-level
+// security. It bypasses the tenant policies; migration 000013 creates it and
+// the role the API connects as must be a member.
+const SystemRole = "goddd_system"
+
+// ErrNoTenant is returned by a tenant unit of work asked to run for neither a
+// tenant nor the system.
+var ErrNoTenant = errors.New("no tenant to scope the transaction to")
+
+type tenantScopeKey struct{}
+
+type systemScopeKey struct{}
+
+// WithTenant returns a copy of ctx whose statements the row-level security
+// policies confine to the tenant id.
+func WithTenant(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantScopeKey{}, id)
+}
+
+// AsSystem returns a copy of ctx whose statements run as SystemRole, for the
+// background jobs acting for every tenant.
+func AsSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemScopeKey{}, true)
+}
+
+// scoped reports whether ctx was given a tenant or the system role.
+func scoped(ctx context.Context) bool {
+	_, tenant := ctx.Value(tenantScopeKey{}).(uuid.UUID)
+	_, system := ctx.Value(systemScopeKey{}).(bool)
+	return tenant || system
+}
+
+// applyScope confines tx to the tenant on ctx, or switches it to SystemRole,
+// for the rest of the transaction.
+func applyScope(ctx context.Context, tx *sqlx.Tx) error {
+	if _, ok := ctx.Value(systemScopeKey{}).(bool); ok {
+		if _, err := tx.ExecContext(ctx, "SET LOCAL ROLE "+SystemRole); err != nil {
+			return fmt.Errorf("failed to set transaction role %w", err)
+		}
+		return nil
+	}
+	id, ok := ctx.Value(tenantScopeKey{}).(uuid.UUID)
+	if !ok {
+		return ErrNoTenant
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", id.String()); err != nil {
+		return fmt.Errorf("failed to set transaction tenant %w", err)
+	}
+	return nil
+}
+
+// scopedQuerier runs each statement made outside a unit of work in a
+// transaction of its own, scoped like a unit of work would be. Only the calls
+// the repositories make are scoped; row-returning Query calls go straight to
+// the database, where the policies find no tenant and fail.
+type scopedQuerier struct {
+	*sqlx.DB
+}
+
+func (q scopedQuerier) in(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := q.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction %w", err)
+	}
+	if err := applyScope(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (q scopedQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	err = q.in(ctx, func(tx *sqlx.Tx) error {
+		res, err = tx.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+func (q scopedQuerier) NamedExecContext(ctx context.Context, query string, arg interface{}) (res sql.Result, err error) {
+	err = q.in(ctx, func(tx *sqlx.Tx) error {
+		res, err = tx.NamedExecContext(ctx, query, arg)
+		return err
+	})
+	return res, err
+}
+
+func (q scopedQuerier) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.in(ctx, func(tx *sqlx.Tx) error {
+		return tx.GetContext(ctx, dest, query, args...)
+	})
+}
+
+func (q scopedQuerier) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.in(ctx, func(tx *sqlx.Tx) error {
+		return tx.SelectContext(ctx, dest, query, args...)
+	})
+}
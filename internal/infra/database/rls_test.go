@@ -0,0 +1,108 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a database/sql driver that answers every statement with no
+// rows and keeps the statements it was sent.
+type recorder struct {
+	statements []string
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return recorderConn{r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return nil }
+
+type recorderConn struct{ r *recorder }
+
+func (c recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return recorderStmt{c.r, query}, nil
+}
+func (c recorderConn) Close() error { return nil }
+func (c recorderConn) Begin() (driver.Tx, error) {
+	c.r.statements = append(c.r.statements, "BEGIN")
+	return recorderTx{c.r}, nil
+}
+
+type recorderTx struct{ r *recorder }
+
+func (t recorderTx) Commit() error {
+	t.r.statements = append(t.r.statements, "COMMIT")
+	return nil
+}
+
+func (t recorderTx) Rollback() error {
+	t.r.statements = append(t.r.statements, "ROLLBACK")
+	return nil
+}
+
+type recorderStmt struct {
+	r     *recorder
+	query string
+}
+
+func (s recorderStmt) Close() error  { return nil }
+func (s recorderStmt) NumInput() int { return -1 }
+func (s recorderStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.r.statements = append(s.r.statements, s.query)
+	return driver.RowsAffected(0), nil
+}
+func (s recorderStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.r.statements = append(s.r.statements, s.query)
+	return noRows{}, nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string         { return []string{"n"} }
+func (noRows) Close() error              { return nil }
+func (noRows) Next([]driver.Value) error { return io.EOF }
+
+func newRecordingDB() (*sqlx.DB, *recorder) {
+	r := &recorder{}
+	return sqlx.NewDb(sql.OpenDB(r), "postgres"), r
+}
+
+func TestTenantUnitOfWorkScopesEachTransaction(t *testing.T) {
+	db, r := newRecordingDB()
+	uow := database.NewTenantUnitOfWork(db)
+	noop := func(ctx context.Context) error { return nil }
+
+	// without a tenant the transaction is given up before fn runs
+	ran := false
+	err := uow.Do(context.Background(), func(ctx context.Context) error { ran = true; return nil })
+	assert.ErrorIs(t, err, database.ErrNoTenant)
+	assert.False(t, ran)
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, r.statements)
+
+	r.statements = nil
+	assert.NoError(t, uow.Do(database.WithTenant(context.Background(), uuid.New()), noop))
+	assert.Equal(t, []string{"BEGIN", "SELECT set_config('app.tenant_id', $1, true)", "COMMIT"}, r.statements)
+
+	r.statements = nil
+	assert.NoError(t, uow.Do(database.AsSystem(context.Background()), noop))
+	assert.Equal(t, []string{"BEGIN", "SET LOCAL ROLE " + database.SystemRole, "COMMIT"}, r.statements)
+}
+
+func TestExecutorScopesStatementsOutsideUnitOfWork(t *testing.T) {
+	db, r := newRecordingDB()
+	ctx := database.WithTenant(context.Background(), uuid.New())
+
+	var n []int
+	assert.NoError(t, database.Executor(ctx, db).SelectContext(ctx, &n, "SELECT n FROM drivers"))
+	assert.Equal(t, []string{"BEGIN", "SELECT set_config('app.tenant_id', $1, true)", "SELECT n FROM drivers", "COMMIT"}, r.statements)
+
+	// an unscoped ctx keeps using db as is
+	r.statements = nil
+	assert.NoError(t, database.Executor(context.Background(), db).SelectContext(context.Background(), &n, "SELECT n FROM drivers"))
+	assert.Equal(t, []string{"SELECT n FROM drivers"}, r.statements)
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/moura95/go-ddd/internal/infra/database")
+
+// tracedQuerier opens a span around every statement run through q.
+type tracedQuerier struct {
+	Querier
+}
+
+// Traced returns q with a client span for each statement, named after its
+// operation and carrying the statement text. The repositories wrap their
+// executor in it.
+func Traced(q Querier) Querier {
+	return tracedQuerier{Querier: q}
+}
+
+func (t tracedQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startSpan(ctx, query)
+	res, err := t.Querier.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+	return res, err
+}
+
+func (t tracedQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startSpan(ctx, query)
+	rows, err := t.Querier.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func (t tracedQuerier) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := startSpan(ctx, query)
+	rows, err := t.Querier.QueryxContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func (t tracedQuerier) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startSpan(ctx, query)
+	row := t.Querier.QueryRowxContext(ctx, query, args...)
+	endSpan(span, row.Err())
+	return row
+}
+
+func (t tracedQuerier) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startSpan(ctx, query)
+	err := t.Querier.GetContext(ctx, dest, query, args...)
+	// no row is an answer, not a failure
+	if errors.Is(err, sql.ErrNoRows) {
+		endSpan(span, nil)
+	} else {
+		endSpan(span, err)
+	}
+	return err
+}
+
+func (t tracedQuerier) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startSpan(ctx, query)
+	err := t.Querier.SelectContext(ctx, dest, query, args...)
+	endSpan(span, err)
+	return err
+}
+
+func (t tracedQuerier) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	ctx, span := startSpan(ctx, query)
+	res, err := t.Querier.NamedExecContext(ctx, query, arg)
+	endSpan(span, err)
+	return res, err
+}
+
+func startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	statement := strings.Join(strings.Fields(query), " ")
+	operation, _, _ := strings.Cut(statement, " ")
+	operation = strings.ToUpper(operation)
+	return tracer.Start(ctx, "postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", statement),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
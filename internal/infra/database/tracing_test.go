@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// stubQuerier answers every Exec with err and every Get with no rows.
+type stubQuerier struct {
+	database.Querier
+	err error
+}
+
+func (s stubQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, s.err
+}
+
+func (s stubQuerier) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return sql.ErrNoRows
+}
+
+func TestTracedOpensASpanPerStatement(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	q := database.Traced(stubQuerier{err: errors.New("connection reset")})
+	_, err := q.ExecContext(context.Background(), "UPDATE drivers\n        SET name = $2 WHERE uuid = $1", 1, "x")
+	assert.Error(t, err)
+	err = q.GetContext(context.Background(), new(int), "select count(*) from drivers")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "postgres UPDATE", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.statement", "UPDATE drivers SET name = $2 WHERE uuid = $1"))
+	assert.Equal(t, "postgres SELECT", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
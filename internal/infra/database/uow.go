@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier is the part of *sqlx.DB and *sqlx.Tx the repositories use, so the
+// same query code runs inside or outside a transaction.
+type Querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+}
+
+type txKey struct{}
+
+// WithTx returns a copy of ctx carrying tx. Repositories called with it join
+// the transaction.
+func WithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok && tx != nil
+}
+
+// Executor returns the transaction carried by ctx, or db when there is none.
+// A ctx given a tenant or the system role outside a transaction gets db with
+// each statement scoped in a transaction of its own.
+func Executor(ctx context.Context, db *sqlx.DB) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	if scoped(ctx) {
+		return scopedQuerier{db}
+	}
+	return db
+}
+
+// UnitOfWork runs a use case atomically: every repository call made with the
+// ctx passed to fn commits together, or not at all when fn returns an error.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type sqlxUnitOfWork struct {
+	db *sqlx.DB
+	// scope, when set, confines each transaction to the tenant on its ctx
+	scope bool
+}
+
+func NewUnitOfWork(db *sqlx.DB) UnitOfWork {
+	return &sqlxUnitOfWork{db: db}
+}
+
+// NewTenantUnitOfWork is NewUnitOfWork for a database enforcing row-level
+// security: each transaction sets app.tenant_id to the tenant WithTenant put
+// on ctx, so the policies hide every other tenant's rows, or runs as
+// SystemRole for a ctx from AsSystem. Do fails with ErrNoTenant for a ctx
+// with neither.
+func NewTenantUnitOfWork(db *sqlx.DB) UnitOfWork {
+	return &sqlxUnitOfWork{db: db, scope: true}
+}
+
+// Do begins a transaction, unless ctx already carries one, in which case fn
+// joins it and the outermost Do decides whether to commit.
+func (u *sqlxUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if u.scope {
+		if err := applyScope(ctx, tx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	hooks := &commitHooks{}
+	if err := fn(withCommitHooks(WithTx(ctx, tx), hooks)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction %w", err)
+	}
+	hooks.run()
+	return nil
+}
+
+type commitHooks struct {
+	fns []func()
+}
+
+func (h *commitHooks) run() {
+	for _, fn := range h.fns {
+		fn()
+	}
+}
+
+type commitHooksKey struct{}
+
+func withCommitHooks(ctx context.Context, hooks *commitHooks) context.Context {
+	return context.WithValue(ctx, commitHooksKey{}, hooks)
+}
+
+// AfterCommit defers fn until the unit of work carried by ctx commits and
+// drops it if the unit of work rolls back. Outside a unit of work fn runs
+// right away. It suits side effects that must not observe uncommitted state,
+// such as cache invalidation.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn()
+}
+
+// InUnitOfWork reports whether ctx belongs to a unit of work that has not
+// committed yet, whichever UnitOfWork implementation started it.
+func InUnitOfWork(ctx context.Context) bool {
+	_, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	return ok
+}
+
+// Snapshotter is implemented by the memory repositories. Snapshot captures the
+// current state and returns a function that puts it back.
+type Snapshotter interface {
+	Snapshot() (restore func())
+}
+
+type memoryTxKey struct{}
+
+type memoryUnitOfWork struct {
+	mu    sync.Mutex
+	repos []Snapshotter
+}
+
+// NewMemoryUnitOfWork is the in-memory counterpart of NewUnitOfWork: when fn
+// fails, every given repository is restored to its state before Do.
+func NewMemoryUnitOfWork(repos ...Snapshotter) UnitOfWork {
+	return &memoryUnitOfWork{repos: repos}
+}
+
+func (u *memoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) == u {
+		return fn(ctx)
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	restores := make([]func(), 0, len(u.repos))
+	for _, repo := range u.repos {
+		restores = append(restores, repo.Snapshot())
+	}
+	hooks := &commitHooks{}
+	if err := fn(withCommitHooks(context.WithValue(ctx, memoryTxKey{}, u), hooks)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	hooks.run()
+	return nil
+}
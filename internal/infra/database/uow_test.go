@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	drivermemory "github.com/moura95/go-ddd/internal/domain/driver/memory"
+	vehiclememory "github.com/moura95/go-ddd/internal/domain/vehicle/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	driverUUID  = uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
+	vehicleUUID = uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9")
+)
+
+func TestMemoryUnitOfWorkRollsBack(t *testing.T) {
+	drivers := drivermemory.NewDriverRepositoryMemory()
+	vehicles := vehiclememory.NewVehicleRepositoryMemory()
+	uow := database.NewMemoryUnitOfWork(drivers, vehicles)
+	failure := errors.New("boom")
+
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		if err := drivers.HardDelete(ctx, driverUUID, 0); err != nil {
+			return err
+		}
+		if err := vehicles.HardDelete(ctx, vehicleUUID, 0); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	_, err = drivers.GetByID(context.Background(), driverUUID)
+	assert.NoError(t, err)
+	_, err = vehicles.GetByID(context.Background(), vehicleUUID)
+	assert.NoError(t, err)
+}
+
+func TestMemoryUnitOfWorkCommits(t *testing.T) {
+	vehicles := vehiclememory.NewVehicleRepositoryMemory()
+	uow := database.NewMemoryUnitOfWork(vehicles)
+
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		// nested units of work join the outer one
+		return uow.Do(ctx, func(ctx context.Context) error {
+			return vehicles.HardDelete(ctx, vehicleUUID, 0)
+		})
+	})
+	assert.NoError(t, err)
+
+	_, total, err := vehicles.GetAll(context.Background(), dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestExecutorPrefersContextTx(t *testing.T) {
+	db := &sqlx.DB{}
+	assert.Equal(t, database.Querier(db), database.Executor(context.Background(), db))
+
+	tx := &sqlx.Tx{}
+	ctx := database.WithTx(context.Background(), tx)
+	assert.Equal(t, database.Querier(tx), database.Executor(ctx, db))
+}
+
+func TestAfterCommitWaitsForTheOutermostUnitOfWork(t *testing.T) {
+	uow := database.NewMemoryUnitOfWork()
+	var ran []string
+
+	database.AfterCommit(context.Background(), func() { ran = append(ran, "now") })
+	assert.Equal(t, []string{"now"}, ran)
+
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		assert.True(t, database.InUnitOfWork(ctx))
+		return uow.Do(ctx, func(ctx context.Context) error {
+			database.AfterCommit(ctx, func() { ran = append(ran, "committed") })
+			assert.Equal(t, []string{"now"}, ran)
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"now", "committed"}, ran)
+
+	err = uow.Do(context.Background(), func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { ran = append(ran, "rolled back") })
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"now", "committed"}, ran)
+	assert.False(t, database.InUnitOfWork(context.Background()))
+}
@@ -0,0 +1,97 @@
+// Package etag maps row versions to HTTP entity tags and reads the
+// conditional request headers that carry them back.
+package etag
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+)
+
+// ErrInvalidIfMatch is returned for an If-Match header that does not name a
+// version this API issued.
+var ErrInvalidIfMatch = domainerr.PreconditionFailed("invalid_if_match", "If-Match does not match any known version")
+
+// Format returns the entity tag for version.
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// Set writes the ETag header for version.
+func Set(ctx *gin.Context, version int) {
+	ctx.Header("ETag", Format(version))
+}
+
+// IfMatch returns the versions the client expects to be modifying, in the
+// order listed. A missing header or "*" yields none, which Expect turns into
+// an unconditional write.
+func IfMatch(ctx *gin.Context) ([]int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		version, ok := parse(tag)
+		if !ok {
+			return nil, ErrInvalidIfMatch
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// Expect picks the version a conditional write is guarded by, zero meaning
+// unconditional. A list is resolved against the current version: when it is
+// listed, the write goes ahead as long as it is still current; otherwise the
+// first listed version is expected and the write fails its check.
+func Expect(versions []int, current func() (int, error)) (int, error) {
+	switch len(versions) {
+	case 0:
+		return 0, nil
+	case 1:
+		return versions[0], nil
+	}
+	version, err := current()
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range versions {
+		if v == version {
+			return version, nil
+		}
+	}
+	return versions[0], nil
+}
+
+// NotModified answers 304 when If-None-Match already names version and
+// reports whether it did so.
+func NotModified(ctx *gin.Context, version int) bool {
+	header := strings.TrimSpace(ctx.GetHeader("If-None-Match"))
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if v, ok := parse(tag); (ok && v == version) || strings.TrimSpace(tag) == "*" {
+			Set(ctx, version)
+			ctx.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+func parse(tag string) (int, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
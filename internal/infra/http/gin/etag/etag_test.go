@@ -0,0 +1,87 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(header, value string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		ctx.Request.Header.Set(header, value)
+	}
+	return ctx, w
+}
+
+func TestIfMatch(t *testing.T) {
+	cases := map[string][]int{
+		"":              nil,
+		"*":             nil,
+		`"3"`:           {3},
+		`W/"4"`:         {4},
+		`"5", "6"`:      {5, 6},
+		` "7" `:         {7},
+		`"8",W/"9","1"`: {8, 9, 1},
+	}
+	for value, want := range cases {
+		ctx, _ := newContext("If-Match", value)
+		got, err := IfMatch(ctx)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+}
+
+func TestExpect(t *testing.T) {
+	current := func(version int) func() (int, error) {
+		return func() (int, error) { return version, nil }
+	}
+	unread := func() (int, error) {
+		t.Fatal("current read for a single version")
+		return 0, nil
+	}
+
+	got, err := Expect(nil, unread)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got, "no header writes unconditionally")
+
+	got, _ = Expect([]int{4}, unread)
+	assert.Equal(t, 4, got)
+
+	got, _ = Expect([]int{2, 3, 5}, current(3))
+	assert.Equal(t, 3, got, "any listed version matches")
+
+	got, _ = Expect([]int{2, 5}, current(3))
+	assert.Equal(t, 2, got, "an unlisted current version fails the write")
+
+	_, err = Expect([]int{2, 5}, func() (int, error) { return 0, assert.AnError })
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestIfMatchRejectsForeignTags(t *testing.T) {
+	for _, value := range []string{"3", `"abc"`, `"0"`, `""`} {
+		ctx, _ := newContext("If-Match", value)
+		_, err := IfMatch(ctx)
+		assert.ErrorIs(t, err, ErrInvalidIfMatch, value)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	ctx, w := newContext("If-None-Match", `"1", "2"`)
+	assert.True(t, NotModified(ctx, 2))
+	ctx.Writer.WriteHeaderNow()
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	ctx, _ = newContext("If-None-Match", `"1"`)
+	assert.False(t, NotModified(ctx, 2))
+
+	ctx, _ = newContext("", "")
+	assert.False(t, NotModified(ctx, 2))
+}
@@ -0,0 +1,55 @@
+package httperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+var statusByKind = map[domainerr.Kind]int{
+	domainerr.KindNotFound:           http.StatusNotFound,
+	domainerr.KindConflict:           http.StatusConflict,
+	domainerr.KindValidation:         http.StatusUnprocessableEntity,
+	domainerr.KindRelationNotFound:   http.StatusUnprocessableEntity,
+	domainerr.KindPreconditionFailed: http.StatusPreconditionFailed,
+	domainerr.KindUnauthorized:       http.StatusUnauthorized,
+	domainerr.KindForbidden:          http.StatusForbidden,
+}
+
+// Status returns the HTTP status code that represents err.
+func Status(err error) int {
+	if e, ok := domainerr.As(err); ok {
+		if status, ok := statusByKind[e.Kind]; ok {
+			return status
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// Body returns the error payload for err. Errors that are not domain errors
+// are reported generically so internal details never reach the client.
+func Body(err error) util.ErrorBody {
+	if e, ok := domainerr.As(err); ok {
+		body := util.ErrorBody{Code: e.Code, Message: e.Message}
+		for _, f := range e.Fields {
+			body.Fields = append(body.Fields, util.FieldError{Field: f.Field, Code: f.Code, Message: f.Message})
+		}
+		return body
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return util.ErrorTimeout
+	}
+	return util.ErrorInternal
+}
+
+// Respond aborts the request with the status and body mapped from err.
+func Respond(ctx *gin.Context, err error) {
+	ctx.AbortWithStatusJSON(Status(err), util.ErrorResponse(Body(err)))
+}
@@ -0,0 +1,54 @@
+package httperror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/moura95/go-ddd/internal/domain/assignment"
+	"github.com/moura95/go-ddd/internal/domain/driver"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	cases := map[error]int{
+		driver.ErrNotFound: http.StatusNotFound,
+		fmt.Errorf("failed to get %w", vehicle.ErrNotFound): http.StatusNotFound,
+		driver.ErrEmailTaken:                              http.StatusConflict,
+		vehicle.ErrLicensePlateTaken:                      http.StatusConflict,
+		driver.ErrInvalidName:                             http.StatusUnprocessableEntity,
+		assignment.ErrVehicleNotFound:                     http.StatusUnprocessableEntity,
+		vehicle.ErrVersionMismatch:                        http.StatusPreconditionFailed,
+		user.ErrInvalidCredentials:                        http.StatusUnauthorized,
+		user.ErrForbidden:                                 http.StatusForbidden,
+		fmt.Errorf("query: %w", context.DeadlineExceeded): http.StatusGatewayTimeout,
+		errors.New("pq: connection refused"):              http.StatusInternalServerError,
+	}
+	for err, status := range cases {
+		assert.Equal(t, status, Status(err), err.Error())
+	}
+}
+
+func TestBodyHidesInternalErrors(t *testing.T) {
+	assert.Equal(t, util.ErrorInternal, Body(errors.New("pq: password authentication failed")))
+	assert.Equal(t, util.ErrorBody{Code: "driver_not_found", Message: "driver not found"}, Body(driver.ErrNotFound))
+}
+
+func TestBodyCarriesValidationFields(t *testing.T) {
+	_, err := driver.NewDriver("", "", "12345678909", "", "", "")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, Status(err))
+	assert.Equal(t, util.ErrorBody{
+		Code:    "validation_failed",
+		Message: "one or more fields are invalid",
+		Fields: []util.FieldError{
+			{Field: "name", Code: "invalid_name", Message: "invalid name"},
+			{Field: "email", Code: "invalid_email", Message: "invalid email"},
+		},
+	}, Body(err))
+}
@@ -1,11 +1,83 @@
 package middleware
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"go.uber.org/zap"
 )
 
-func ActivityMiddleware() gin.HandlerFunc {
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey holds the request ID on the gin context.
+const requestIDKey = "request_id"
+
+// requestIDPattern is what a client's X-Request-ID must look like to be
+// kept; anything else could forge log lines or bloat them.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// ActivityMiddleware gives every request an ID, taking the client's
+// X-Request-ID when it sent a usable one and echoing it back either way, and
+// puts a logger naming the request, its route, method and client IP on the
+// request context. Once the request is answered it writes one access line
+// with the status and latency; the probe and scrape paths are not logged.
+func ActivityMiddleware(log *zap.SugaredLogger) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		start := time.Now()
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		ctx.Set(requestIDKey, requestID)
+		ctx.Header(RequestIDHeader, requestID)
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		reqLog := log.With(
+			"request_id", requestID,
+			"route", route,
+			"method", ctx.Request.Method,
+			"client_ip", ctx.ClientIP(),
+		)
+		ctx.Request = ctx.Request.WithContext(logging.WithLogger(ctx.Request.Context(), reqLog))
 		ctx.Next()
+
+		if probePaths[ctx.Request.URL.Path] {
+			return
+		}
+		// taken from the context again for the principal the auth
+		// middleware added
+		access := logging.FromContext(ctx.Request.Context(), reqLog)
+		status := ctx.Writer.Status()
+		fields := []interface{}{
+			"status", status,
+			"latency", time.Since(start),
+			"bytes", ctx.Writer.Size(),
+		}
+		switch {
+		case status >= 500:
+			access.Errorw("request", fields...)
+		case status >= 400:
+			access.Warnw("request", fields...)
+		default:
+			access.Infow("request", fields...)
+		}
 	}
 }
+
+// RequestID returns the ID ActivityMiddleware gave the request, or "" when
+// it did not run.
+func RequestID(ctx *gin.Context) string {
+	return ctx.GetString(requestIDKey)
+}
+
+// Logger returns the logger of the request, or log when ActivityMiddleware
+// did not run, for the handlers to log with.
+func Logger(ctx *gin.Context, log *zap.SugaredLogger) *zap.SugaredLogger {
+	return logging.FromContext(ctx.Request.Context(), log)
+}
@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestActivityMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.InfoLevel)
+
+	router := gin.New()
+	router.Use(middleware.ActivityMiddleware(zap.New(core).Sugar()))
+	router.GET("/drivers/:uuid", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "principal", "user:1"))
+		middleware.Logger(c, zap.NewNop().Sugar()).Info("handled")
+		c.String(http.StatusNotFound, middleware.RequestID(c))
+	})
+	router.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/drivers/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "req-1", w.Header().Get(middleware.RequestIDHeader))
+	assert.Equal(t, "req-1", w.Body.String())
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "handled", entries[0].Message)
+	assert.Equal(t, "/drivers/:uuid", entries[0].ContextMap()["route"])
+	access := entries[1].ContextMap()
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, "req-1", access["request_id"])
+	assert.Equal(t, http.MethodGet, access["method"])
+	assert.Equal(t, "user:1", access["principal"])
+	assert.Equal(t, int64(http.StatusNotFound), access["status"])
+	assert.Contains(t, access, "latency")
+
+	// a request ID is made up when the client sent none, and probes are not logged
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	_, err := uuid.Parse(w.Header().Get(middleware.RequestIDHeader))
+	assert.NoError(t, err)
+	assert.Len(t, logs.AllUntimed(), 2)
+
+	// as it is when the client's ID could mangle the logs
+	for _, id := range []string{"req 1\nlevel=error", "req-1\"}", strings.Repeat("a", 129)} {
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set(middleware.RequestIDHeader, id)
+		router.ServeHTTP(w, req)
+		_, err = uuid.Parse(w.Header().Get(middleware.RequestIDHeader))
+		assert.NoError(t, err, id)
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/audit"
+)
+
+// AuditMiddleware puts the request ID ActivityMiddleware gave the request, the
+// client IP and the peer's own address on the request context so the audit
+// trail can attribute the changes a request makes. The client IP comes from
+// X-Forwarded-For when a trusted proxy sent the request, so the peer is kept
+// as well.
+func AuditMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		meta := audit.Metadata{RequestID: RequestID(ctx), IP: ctx.ClientIP(), RemoteIP: ctx.RemoteIP()}
+		ctx.Request = ctx.Request.WithContext(audit.WithMetadata(ctx.Request.Context(), meta))
+		ctx.Next()
+	}
+}
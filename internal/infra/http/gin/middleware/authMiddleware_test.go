@@ -0,0 +1,122 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/apikey"
+	"github.com/moura95/go-ddd/internal/domain/audit"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signer, err := auth.NewSigner(cfg.Config{AuthJWTSecret: "0123456789abcdef0123456789abcdef", AuthAccessTokenTTL: time.Minute})
+	assert.NoError(t, err)
+	u := &user.User{Uuid: uuid.New(), Email: "admin@example.com"}
+	token, _, err := signer.Issue(u)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(signer, nil))
+	router.GET("/drivers", func(c *gin.Context) {
+		c.String(http.StatusOK, audit.MetadataFrom(c.Request.Context()).Actor)
+	})
+
+	do := func(authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/drivers", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("Bearer " + token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, u.Uuid.String(), w.Body.String())
+
+	for _, authorization := range []string{"", "Bearer", "Basic " + token, "Bearer " + token + "x"} {
+		w = do(authorization)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, authorization)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+		assert.Contains(t, w.Body.String(), `"invalid_token"`)
+	}
+}
+
+// stubKeys accepts a single key.
+type stubKeys struct {
+	key    string
+	claims *auth.Claims
+}
+
+func (s stubKeys) Authenticate(ctx context.Context, key string) (*auth.Claims, error) {
+	if key != s.key {
+		return nil, apikey.ErrInvalidKey
+	}
+	return s.claims, nil
+}
+
+func TestAuthMiddlewareAcceptsAPIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signer, err := auth.NewSigner(cfg.Config{AuthJWTSecret: "0123456789abcdef0123456789abcdef", AuthAccessTokenTTL: time.Minute})
+	assert.NoError(t, err)
+	keys := stubKeys{key: "gddd_0123abcd_secret", claims: &auth.Claims{UserUUID: uuid.New(), APIKeyUUID: uuid.New()}}
+
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(signer, keys))
+	router.GET("/drivers", func(c *gin.Context) {
+		meta := audit.MetadataFrom(c.Request.Context())
+		c.String(http.StatusOK, meta.Actor+" "+meta.APIKey)
+	})
+
+	do := func(key string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/drivers", nil)
+		req.Header.Set(middleware.APIKeyHeader, key)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(keys.key)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, keys.claims.UserUUID.String()+" "+keys.claims.APIKeyUUID.String(), w.Body.String())
+
+	w = do("gddd_0123abcd_wrong")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), `"invalid_api_key"`)
+}
+
+func TestAuthMiddlewareScopesToTheCallersTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signer, err := auth.NewSigner(cfg.Config{AuthJWTSecret: "0123456789abcdef0123456789abcdef", AuthAccessTokenTTL: time.Minute})
+	assert.NoError(t, err)
+	u := &user.User{Uuid: uuid.New(), TenantID: uuid.New(), Email: "ops@example.com"}
+	token, _, err := signer.Issue(u)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(signer, nil))
+	router.GET("/drivers", func(c *gin.Context) {
+		id, _ := tenant.FromContext(c.Request.Context())
+		c.String(http.StatusOK, id.String())
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/drivers", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, u.TenantID.String(), w.Body.String())
+}
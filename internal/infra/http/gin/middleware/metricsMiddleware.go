@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/metrics"
+)
+
+// unmatchedRoute labels requests no route matched, so probing random paths
+// does not create a series per path.
+const unmatchedRoute = "unmatched"
+
+// otherMethod labels requests with a method outside the standard ones, which
+// clients may make up at will.
+const otherMethod = "OTHER"
+
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// MetricsMiddleware counts and times every request by its route pattern and
+// the status code it was answered with.
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := ctx.Request.Method
+		if !knownMethods[method] {
+			method = otherMethod
+		}
+		m.ObserveRequest(method, route, ctx.Writer.Status(), time.Since(start))
+	}
+}
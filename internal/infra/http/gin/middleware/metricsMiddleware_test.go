@@ -0,0 +1,34 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddlewareLabelsByRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+	router := gin.New()
+	router.Use(middleware.MetricsMiddleware(m))
+	router.GET("/drivers/:uuid", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+
+	for _, path := range []string{"/drivers/1", "/drivers/2", "/nope"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	for _, method := range []string{"FOO", "BAR"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/drivers/1", nil))
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `goddd_http_requests_total{method="GET",route="/drivers/:uuid",status="204"} 2`)
+	assert.Contains(t, w.Body.String(), `goddd_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, w.Body.String(), `goddd_http_requests_total{method="OTHER",route="unmatched",status="404"} 2`)
+}
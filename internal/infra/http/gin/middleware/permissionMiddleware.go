@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+)
+
+// Permissions builds the handlers routers put in front of their routes.
+// With auth disabled there is no caller to check and every request passes.
+type Permissions struct {
+	enabled bool
+}
+
+func NewPermissions(enabled bool) Permissions {
+	return Permissions{enabled: enabled}
+}
+
+// Require answers 403 unless the caller put on the request by
+// AuthMiddleware holds perm.
+func (p Permissions) Require(perm user.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !p.enabled {
+			ctx.Next()
+			return
+		}
+		if err := auth.Require(ctx.Request.Context(), perm); err != nil {
+			httperror.Respond(ctx, err)
+			return
+		}
+		ctx.Next()
+	}
+}
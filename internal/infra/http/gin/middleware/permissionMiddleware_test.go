@@ -0,0 +1,42 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionsRequire(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	do := func(perms middleware.Permissions, roles ...user.Role) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if roles != nil {
+				ctx := auth.WithClaims(c.Request.Context(), &auth.Claims{UserUUID: uuid.New(), Roles: roles})
+				c.Request = c.Request.WithContext(ctx)
+			}
+		})
+		router.DELETE("/driver/:uuid/hard", perms.Require(user.PermDriverHardDelete), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/driver/"+uuid.NewString()+"/hard", nil))
+		return w.Code
+	}
+
+	enabled := middleware.NewPermissions(true)
+	assert.Equal(t, http.StatusNoContent, do(enabled, user.RoleAdmin))
+	assert.Equal(t, http.StatusForbidden, do(enabled, user.RoleDispatcher))
+	assert.Equal(t, http.StatusForbidden, do(enabled, user.RoleViewer))
+	assert.Equal(t, http.StatusUnauthorized, do(enabled))
+
+	assert.Equal(t, http.StatusNoContent, do(middleware.NewPermissions(false)))
+}
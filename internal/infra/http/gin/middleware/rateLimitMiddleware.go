@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/ratelimit"
+	"github.com/moura95/go-ddd/internal/infra/util"
+	"go.uber.org/zap"
+)
+
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimitMiddleware takes a token from the bucket the policy picks for the
+// route and client IP. Every response carries the X-RateLimit-* headers;
+// requests over the limit get a 429 with Retry-After. A limiter error lets
+// the request through rather than failing it.
+func RateLimitMiddleware(limiter ratelimit.Limiter, policy ratelimit.Policy, log *zap.SugaredLogger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key, rule := policy.Bucket(ctx.Request.Method+" "+ctx.FullPath(), ctx.ClientIP())
+		result, err := limiter.Allow(ctx.Request.Context(), key, rule)
+		if err != nil {
+			Logger(ctx, log).Warnf("Failed Rate Limit %s: %s", key, err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		ctx.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		ctx.Header(RateLimitResetHeader, seconds(result.Reset))
+		if !result.Allowed {
+			ctx.Header("Retry-After", seconds(result.RetryAfter))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, util.ErrorResponse(util.ErrorRateLimited))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// seconds rounds d up, so clients waiting that long find a token.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
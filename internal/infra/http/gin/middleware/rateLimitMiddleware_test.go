@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRateLimitMiddlewareRejectsWithTooManyRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policy := ratelimit.Policy{
+		Default: ratelimit.Rule{Limit: 100, Period: time.Minute},
+		Routes:  map[string]ratelimit.Rule{"POST /drivers": {Limit: 1, Period: time.Minute}},
+	}
+	router := gin.New()
+	router.Use(middleware.RateLimitMiddleware(ratelimit.NewMemoryLimiter(), policy, zap.NewNop().Sugar()))
+	router.POST("/drivers", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/drivers", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, "/drivers", nil))
+		return w
+	}
+
+	w := do(http.MethodPost)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "1", w.Header().Get(middleware.RateLimitLimitHeader))
+	assert.Equal(t, "0", w.Header().Get(middleware.RateLimitRemainingHeader))
+	assert.Equal(t, "60", w.Header().Get(middleware.RateLimitResetHeader))
+
+	w = do(http.MethodPost)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"rate_limited"`)
+
+	w = do(http.MethodGet)
+	assert.Equal(t, http.StatusOK, w.Code, "other routes use the default budget")
+	assert.Equal(t, "99", w.Header().Get(middleware.RateLimitRemainingHeader))
+}
+
+func TestRateLimitMiddlewareIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policy := ratelimit.Policy{Default: ratelimit.Rule{Limit: 1, Period: time.Minute}}
+	router := gin.New()
+	// as the server does without HTTP_TRUSTED_PROXIES
+	assert.NoError(t, router.SetTrustedProxies(nil))
+	router.Use(middleware.RateLimitMiddleware(ratelimit.NewMemoryLimiter(), policy, zap.NewNop().Sugar()))
+	router.GET("/drivers", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(forwardedFor string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/drivers", nil)
+		req.RemoteAddr = "203.0.113.7:40000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, do("198.51.100.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, do("198.51.100.2").Code, "a new X-Forwarded-For is the same client")
+}
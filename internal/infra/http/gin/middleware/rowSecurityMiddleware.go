@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/infra/database"
+)
+
+// RowSecurityMiddleware hands the request's tenant to the database, whose
+// row-level security policies then confine every statement of the request
+// to it. It goes after AuthMiddleware or TenantMiddleware; a request without
+// a tenant is left unscoped and the policies show it no rows.
+func RowSecurityMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if id, ok := tenant.FromContext(ctx.Request.Context()); ok {
+			ctx.Request = ctx.Request.WithContext(database.WithTenant(ctx.Request.Context(), id))
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+)
+
+// TenantMiddleware scopes every request to the tenant id. It stands in for
+// AuthMiddleware while auth is disabled, when there is no caller to take the
+// tenant from.
+func TenantMiddleware(id uuid.UUID) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx := tenant.WithID(ctx.Request.Context(), id)
+		ctx.Request = ctx.Request.WithContext(logging.With(reqCtx, "tenant_id", id.String()))
+		ctx.Next()
+	}
+}
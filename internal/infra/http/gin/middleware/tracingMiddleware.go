@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// probePaths are polled by probes and scrapers; tracing or logging them
+// would only bury the requests worth looking at.
+var probePaths = map[string]bool{"/healthz": true, "/metrics": true}
+
+// TracingMiddleware opens a server span for each request, continuing the
+// trace of a caller sending a W3C traceparent header.
+func TracingMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !probePaths[r.URL.Path]
+	}))
+}
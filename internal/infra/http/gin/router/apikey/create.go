@@ -0,0 +1,73 @@
+package apikey_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type createReq struct {
+	Name      string    `json:"name" binding:"required"`
+	Scopes    []string  `json:"scopes" binding:"required,min=1"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type createdResponse struct {
+	apiKeyResponse
+	// Key is shown once; only its hash is kept.
+	Key string `json:"key"`
+}
+
+func (a *APIKeyRouter) create(ctx *gin.Context) {
+	var req createReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind API Key %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	created, err := a.service.Create(ctx.Request.Context(), dto.CreateInput{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Create API Key %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Create API Key Successful uuid: %s", created.Uuid)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(newCreatedResponse(created)))
+}
+
+func (a *APIKeyRouter) rotate(ctx *gin.Context) {
+	uid, ok := a.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	created, err := a.service.Rotate(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Rotate API Key %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Rotate API Key Successful uuid: %s new: %s", uid, created.Uuid)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(newCreatedResponse(created)))
+}
+
+func newCreatedResponse(created *dto.Created) createdResponse {
+	return createdResponse{
+		apiKeyResponse: newAPIKeyResponse(&created.Output),
+		Key:            created.Key,
+	}
+}
@@ -0,0 +1,27 @@
+package apikey_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+func (a *APIKeyRouter) revoke(ctx *gin.Context) {
+	uid, ok := a.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	err := a.service.Revoke(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Revoke API Key %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Revoke API Key Successful uuid: %s", uid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
+}
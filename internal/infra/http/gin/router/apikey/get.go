@@ -0,0 +1,121 @@
+package apikey_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type getIdReq struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+type listReq struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// apiKeyResponse leaves the key out; it is only returned when issued.
+type apiKeyResponse struct {
+	Uuid       uuid.UUID  `json:"uuid"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (a *APIKeyRouter) list(ctx *gin.Context) {
+	var req listReq
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	filter := dto.ListInput{Input: pagination.Input{Page: req.Page, PageSize: req.PageSize}}
+	filter.Normalize()
+
+	keys, total, err := a.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed List API Keys %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]apiKeyResponse, 0, len(keys))
+	for i := range keys {
+		resp = append(resp, newAPIKeyResponse(&keys[i]))
+	}
+
+	ctx.JSON(http.StatusOK, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
+}
+
+func (a *APIKeyRouter) getId(ctx *gin.Context) {
+	uid, ok := a.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	out, err := a.service.GetByID(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Get API Key %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Get API Key Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newAPIKeyResponse(out)))
+}
+
+// bindUuid parses the :uuid path parameter, answering 400 when it is invalid.
+func (a *APIKeyRouter) bindUuid(ctx *gin.Context) (uuid.UUID, bool) {
+	var req getIdReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func newAPIKeyResponse(out *dto.Output) apiKeyResponse {
+	resp := apiKeyResponse{
+		Uuid:      out.Uuid,
+		Name:      out.Name,
+		Prefix:    out.Prefix,
+		Scopes:    out.Scopes,
+		ExpiresAt: out.ExpiresAt,
+		CreatedAt: out.CreatedAt,
+	}
+	if out.CreatedBy != uuid.Nil {
+		resp.CreatedBy = &out.CreatedBy
+	}
+	if !out.LastUsedAt.IsZero() {
+		resp.LastUsedAt = &out.LastUsedAt
+	}
+	if !out.RevokedAt.IsZero() {
+		resp.RevokedAt = &out.RevokedAt
+	}
+	return resp
+}
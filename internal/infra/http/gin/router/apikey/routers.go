@@ -0,0 +1,36 @@
+package apikey_router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/service/apikey"
+
+	"go.uber.org/zap"
+)
+
+type IAPIKey interface {
+	SetupAPIKeyRoute(routers *gin.RouterGroup)
+}
+
+type APIKeyRouter struct {
+	service apikey.IAPIKeyService
+	perms   middleware.Permissions
+	logger  *zap.SugaredLogger
+}
+
+func NewAPIKeyRouter(s apikey.IAPIKeyService, perms middleware.Permissions, log *zap.SugaredLogger) *APIKeyRouter {
+	return &APIKeyRouter{
+		service: s,
+		perms:   perms,
+		logger:  log,
+	}
+}
+
+func (a *APIKeyRouter) SetupAPIKeyRoute(routers *gin.RouterGroup) {
+	routers.GET("/api-keys", a.perms.Require(user.PermAPIKeyManage), a.list)
+	routers.GET("/api-keys/:uuid", a.perms.Require(user.PermAPIKeyManage), a.getId)
+	routers.POST("/api-keys", a.perms.Require(user.PermAPIKeyManage), a.create)
+	routers.POST("/api-keys/:uuid/rotate", a.perms.Require(user.PermAPIKeyManage), a.rotate)
+	routers.DELETE("/api-keys/:uuid", a.perms.Require(user.PermAPIKeyManage), a.revoke)
+}
@@ -0,0 +1,80 @@
+package assignment_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type assignmentReq struct {
+	DriverUUID  string `json:"driver_uuid" binding:"required,uuid"`
+	VehicleUUID string `json:"vehicle_uuid" binding:"required,uuid"`
+	Primary     bool   `json:"primary"`
+	AssignedBy  string `json:"assigned_by"`
+	Notes       string `json:"notes"`
+}
+
+func (a *AssignmentRouter) create(ctx *gin.Context) {
+	var req assignmentReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Assignment %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	input := dto.CreateInput{
+		DriverUUID:  uuid.MustParse(req.DriverUUID),
+		VehicleUUID: uuid.MustParse(req.VehicleUUID),
+		Primary:     req.Primary,
+		AssignedBy:  req.AssignedBy,
+		Notes:       req.Notes,
+	}
+
+	out, err := a.service.Assign(ctx.Request.Context(), input)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Assign Driver %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Assign Driver Successful DriverUuid: %s VehicleUuid: %s", out.DriverUUID, out.VehicleUUID)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(newAssignmentResponse(out)))
+}
+
+type subscribeReq struct {
+	DriverUUID  string `json:"driver_uuid" binding:"required,uuid"`
+	VehicleUUID string `json:"vehicle_uuid" binding:"required,uuid"`
+}
+
+func (a *AssignmentRouter) subscribe(ctx *gin.Context) {
+	var req subscribeReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Assignment %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	input := dto.CreateInput{
+		DriverUUID:  uuid.MustParse(req.DriverUUID),
+		VehicleUUID: uuid.MustParse(req.VehicleUUID),
+	}
+
+	out, err := a.service.Assign(ctx.Request.Context(), input)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Create Relation Driver Vehicle %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Create Relation Driver Vehicle Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(newAssignmentResponse(out)))
+}
@@ -0,0 +1,61 @@
+package assignment_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+func (a *AssignmentRouter) unassign(ctx *gin.Context) {
+	var req getIdReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	out, err := a.service.Unassign(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Unassign %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Unassign Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newAssignmentResponse(out)))
+}
+
+func (a *AssignmentRouter) unSubscribe(ctx *gin.Context) {
+	var req subscribeReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Assignment %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+	driverUUID := uuid.MustParse(req.DriverUUID)
+	vehicleUUID := uuid.MustParse(req.VehicleUUID)
+
+	_, err = a.service.UnassignPair(ctx.Request.Context(), driverUUID, vehicleUUID)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Unsubscribe %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Delete Relation DriverUuid: %s VehicleUuid:%s", driverUUID, vehicleUUID)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
+}
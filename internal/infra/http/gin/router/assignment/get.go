@@ -0,0 +1,121 @@
+package assignment_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type getIdReq struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+type assignmentResponse struct {
+	Uuid         uuid.UUID  `json:"uuid"`
+	DriverUUID   uuid.UUID  `json:"driver_uuid"`
+	VehicleUUID  uuid.UUID  `json:"vehicle_uuid"`
+	Primary      bool       `json:"primary"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+	AssignedBy   string     `json:"assigned_by"`
+	Notes        string     `json:"notes"`
+	Active       bool       `json:"active"`
+}
+
+func (a *AssignmentRouter) getId(ctx *gin.Context) {
+	uid, ok := a.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	out, err := a.service.GetByID(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Get %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, a.logger).Infof("Get Id Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newAssignmentResponse(out)))
+}
+
+func (a *AssignmentRouter) listByDriver(ctx *gin.Context) {
+	uid, ok := a.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	assignments, err := a.service.ListByDriver(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed List Driver Assignments %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newAssignmentResponses(assignments)))
+}
+
+func (a *AssignmentRouter) listByVehicle(ctx *gin.Context) {
+	uid, ok := a.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	assignments, err := a.service.ListByVehicle(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed List Vehicle Assignments %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newAssignmentResponses(assignments)))
+}
+
+func (a *AssignmentRouter) bindUuid(ctx *gin.Context) (uuid.UUID, bool) {
+	var req getIdReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func newAssignmentResponse(out *dto.Output) assignmentResponse {
+	resp := assignmentResponse{
+		Uuid:        out.Uuid,
+		DriverUUID:  out.DriverUUID,
+		VehicleUUID: out.VehicleUUID,
+		Primary:     out.Primary,
+		AssignedAt:  out.AssignedAt,
+		AssignedBy:  out.AssignedBy,
+		Notes:       out.Notes,
+		Active:      !out.UnassignedAt.Valid,
+	}
+	if out.UnassignedAt.Valid {
+		resp.UnassignedAt = &out.UnassignedAt.Time
+	}
+	return resp
+}
+
+func newAssignmentResponses(assignments []dto.Output) []assignmentResponse {
+	resp := make([]assignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		resp = append(resp, newAssignmentResponse(&assignments[i]))
+	}
+	return resp
+}
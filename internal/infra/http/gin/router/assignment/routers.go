@@ -0,0 +1,40 @@
+package assignment_router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+
+	"go.uber.org/zap"
+)
+
+type IAssignment interface {
+	SetupAssignmentRoute(routers *gin.RouterGroup)
+}
+
+type AssignmentRouter struct {
+	service assignment.IAssignmentService
+	perms   middleware.Permissions
+	logger  *zap.SugaredLogger
+}
+
+func NewAssignmentRouter(s assignment.IAssignmentService, perms middleware.Permissions, log *zap.SugaredLogger) *AssignmentRouter {
+	return &AssignmentRouter{
+		service: s,
+		perms:   perms,
+		logger:  log,
+	}
+}
+
+func (a *AssignmentRouter) SetupAssignmentRoute(routers *gin.RouterGroup) {
+	routers.POST("/assignments", a.perms.Require(user.PermAssignmentWrite), a.create)
+	routers.GET("/assignments/:uuid", a.perms.Require(user.PermAssignmentRead), a.getId)
+	routers.DELETE("/assignments/:uuid", a.perms.Require(user.PermAssignmentWrite), a.unassign)
+	routers.GET("/driver/:uuid/assignments", a.perms.Require(user.PermAssignmentRead), a.listByDriver)
+	routers.GET("/vehicle/:uuid/assignments", a.perms.Require(user.PermAssignmentRead), a.listByVehicle)
+
+	// kept for clients of the original driver endpoints
+	routers.POST("/driver/subscribe", a.perms.Require(user.PermAssignmentWrite), a.subscribe)
+	routers.DELETE("/driver/unsubscribe", a.perms.Require(user.PermAssignmentWrite), a.unSubscribe)
+}
@@ -0,0 +1,111 @@
+package audit_router
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	dto "github.com/moura95/go-ddd/internal/dtos/audit"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type getIdReq struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+type listReq struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type entryResponse struct {
+	Uuid       uuid.UUID       `json:"uuid"`
+	EntityType string          `json:"entity_type"`
+	EntityUUID uuid.UUID       `json:"entity_uuid"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Actor      string          `json:"actor"`
+	APIKey     string          `json:"api_key,omitempty"`
+	RequestID  string          `json:"request_id"`
+	IP         string          `json:"ip"`
+	RemoteIP   string          `json:"remote_ip"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func (a *AuditRouter) listByDriver(ctx *gin.Context) {
+	a.list(ctx, domain.EntityDriver)
+}
+
+func (a *AuditRouter) listByVehicle(ctx *gin.Context) {
+	a.list(ctx, domain.EntityVehicle)
+}
+
+func (a *AuditRouter) list(ctx *gin.Context, entityType string) {
+	var req getIdReq
+	var query listReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	err = ctx.ShouldBindQuery(&query)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	filter := dto.ListInput{Input: pagination.Input{Page: query.Page, PageSize: query.PageSize}}
+	filter.Normalize()
+
+	entries, total, err := a.service.ListByEntity(ctx.Request.Context(), entityType, uid, filter)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed List Audit %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]entryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, entryResponse{
+			Uuid:       e.Uuid,
+			EntityType: e.EntityType,
+			EntityUUID: e.EntityUUID,
+			Action:     e.Action,
+			Before:     nullIfEmpty(e.Before),
+			After:      nullIfEmpty(e.After),
+			Actor:      e.Actor,
+			APIKey:     e.APIKey.String,
+			RequestID:  e.RequestID.String,
+			IP:         e.IP.String,
+			RemoteIP:   e.RemoteIP.String,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
+}
+
+// nullIfEmpty keeps a missing snapshot valid JSON; an empty RawMessage would
+// make the encoder fail.
+func nullIfEmpty(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
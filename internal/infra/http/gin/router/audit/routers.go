@@ -0,0 +1,33 @@
+package audit_router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/service/audit"
+
+	"go.uber.org/zap"
+)
+
+type IAudit interface {
+	SetupAuditRoute(routers *gin.RouterGroup)
+}
+
+type AuditRouter struct {
+	service audit.IAuditService
+	perms   middleware.Permissions
+	logger  *zap.SugaredLogger
+}
+
+func NewAuditRouter(s audit.IAuditService, perms middleware.Permissions, log *zap.SugaredLogger) *AuditRouter {
+	return &AuditRouter{
+		service: s,
+		perms:   perms,
+		logger:  log,
+	}
+}
+
+func (a *AuditRouter) SetupAuditRoute(routers *gin.RouterGroup) {
+	routers.GET("/driver/:uuid/audit", a.perms.Require(user.PermAuditRead), a.listByDriver)
+	routers.GET("/vehicle/:uuid/audit", a.perms.Require(user.PermAuditRead), a.listByVehicle)
+}
@@ -0,0 +1,55 @@
+package auth_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/moura95/go-ddd/internal/dtos/auth"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type loginReq struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type tokensResponse struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+func newTokensResponse(t *dto.Tokens) tokensResponse {
+	return tokensResponse{
+		TokenType:             "Bearer",
+		AccessToken:           t.AccessToken,
+		AccessTokenExpiresAt:  t.AccessTokenExpiresAt,
+		RefreshToken:          t.RefreshToken,
+		RefreshTokenExpiresAt: t.RefreshTokenExpiresAt,
+	}
+}
+
+func (a *AuthRouter) login(ctx *gin.Context) {
+	var req loginReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Login %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	tokens, err := a.service.Login(ctx.Request.Context(), dto.LoginInput{Email: req.Email, Password: req.Password})
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Login %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newTokensResponse(tokens)))
+}
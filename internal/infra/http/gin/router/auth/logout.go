@@ -0,0 +1,30 @@
+package auth_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+func (a *AuthRouter) logout(ctx *gin.Context) {
+	var req refreshTokenReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Logout %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	err = a.service.Logout(ctx.Request.Context(), req.RefreshToken)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Logout %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
+}
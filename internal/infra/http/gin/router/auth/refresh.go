@@ -0,0 +1,34 @@
+package auth_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type refreshTokenReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+func (a *AuthRouter) refresh(ctx *gin.Context) {
+	var req refreshTokenReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Bind Refresh %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	tokens, err := a.service.Refresh(ctx.Request.Context(), req.RefreshToken)
+	if err != nil {
+		middleware.Logger(ctx, a.logger).Errorf("Failed Refresh %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newTokensResponse(tokens)))
+}
@@ -0,0 +1,31 @@
+package auth_router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/service/auth"
+
+	"go.uber.org/zap"
+)
+
+type IAuth interface {
+	SetupAuthRoute(routers *gin.RouterGroup)
+}
+
+type AuthRouter struct {
+	service auth.IAuthService
+	logger  *zap.SugaredLogger
+}
+
+func NewAuthRouter(s auth.IAuthService, log *zap.SugaredLogger) *AuthRouter {
+	return &AuthRouter{
+		service: s,
+		logger:  log,
+	}
+}
+
+// SetupAuthRoute registers the routes reachable without an access token.
+func (a *AuthRouter) SetupAuthRoute(routers *gin.RouterGroup) {
+	routers.POST("/auth/login", a.login)
+	routers.POST("/auth/refresh", a.refresh)
+	routers.POST("/auth/logout", a.logout)
+}
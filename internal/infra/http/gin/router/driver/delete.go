@@ -3,6 +3,8 @@ package driver_router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 
 	"net/http"
@@ -14,25 +16,33 @@ func (d *Driver) softDelete(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&req)
 
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := d.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
 	}
 
 	// before hardDelete need remove relation
-	err = d.service.SoftDelete(uuidStr)
+	err = d.service.SoftDelete(ctx.Request.Context(), uuidStr, version)
 
 	if err != nil {
-		d.logger.Errorf("Failed Soft Delete %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, "Failed to HardDelete Driver")
+		middleware.Logger(ctx, d.logger).Errorf("Failed Soft Delete %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
-	d.logger.Infof("Succesful Deleted uuid: %s", uuidStr)
+	middleware.Logger(ctx, d.logger).Infof("Succesful Deleted uuid: %s", uuidStr)
 
 	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
 }
@@ -43,26 +53,34 @@ func (d *Driver) UnDelete(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&req)
 
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := d.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
 	}
 
 	// before hardDelete need remove relation
-	err = d.service.UnDelete(uuidStr)
+	err = d.service.UnDelete(ctx.Request.Context(), uuidStr, version)
 
 	if err != nil {
-		d.logger.Errorf("Failed to Delete %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, "Failed to HardDelete Driver")
+		middleware.Logger(ctx, d.logger).Errorf("Failed to Delete %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
 
-	d.logger.Infof("Succesful Delete uuid: %s", uuidStr)
+	middleware.Logger(ctx, d.logger).Infof("Succesful Delete uuid: %s", uuidStr)
 
 	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
 }
@@ -73,25 +91,33 @@ func (d *Driver) hardDelete(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&req)
 
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := d.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
 	}
 
 	// before hardDelete need remove relation
-	err = d.service.HardDelete(uuidStr)
+	err = d.service.HardDelete(ctx.Request.Context(), uuidStr, version)
 
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, "Failed to HardDelete Driver")
+		middleware.Logger(ctx, d.logger).Errorf("Failed Hard Delete %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
-	d.logger.Infof("Delete Sucessful uuid: %s", uuidStr)
+	middleware.Logger(ctx, d.logger).Infof("Delete Sucessful uuid: %s", uuidStr)
 
 	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
 
@@ -3,6 +3,12 @@ package driver_router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/etag"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 
 	"net/http"
@@ -14,53 +20,96 @@ type getIdReq struct {
 }
 
 type driverResponse struct {
-	Uuid          uuid.UUID         `json:"uuid"`
-	Name          string            `json:"name"`
-	Email         string            `json:"email"`
-	TaxID         string            `json:"tax_id"`
-	DriverLicense string            ` json:"driver_license"`
-	DateOfBirth   string            `json:"date_of_birth"`
-	Vehicles      []vehicleResponse `json:"vehicles"`
-	DeletedAt     string            `json:"deleted_at"`
-	CreatedAt     time.Time         `json:"created_at"`
-	UpdatedAt     time.Time         `json:"updated_at"`
+	Uuid            uuid.UUID         `json:"uuid"`
+	Name            string            `json:"name"`
+	Email           string            `json:"email"`
+	TaxID           string            `json:"tax_id"`
+	DriverLicense   string            ` json:"driver_license"`
+	LicenseCategory string            `json:"license_category"`
+	DateOfBirth     string            `json:"date_of_birth"`
+	Vehicles        []vehicleResponse `json:"vehicles"`
+	DeletedAt       string            `json:"deleted_at"`
+	Version         int               `json:"version"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
 }
 
 type vehicleResponse struct {
-	Uuid              uuid.UUID `database:"uuid"`
-	Brand             string    `database:"brand"`
-	Model             string    `database:"model"`
-	YearOfManufacture uint      `database:"year_of_manufacture"`
-	LicensePlate      string    `database:"license_plate"`
-	Color             string    `database:"color"`
+	Uuid              uuid.UUID `json:"uuid"`
+	Brand             string    `json:"brand"`
+	Model             string    `json:"model"`
+	YearOfManufacture uint      `json:"year_of_manufacture"`
+	LicensePlate      string    `json:"license_plate"`
+	Renavam           string    `json:"renavam"`
+	Category          string    `json:"category"`
+	Color             string    `json:"color"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type listReq struct {
+	Page          int    `form:"page" binding:"omitempty,min=1"`
+	PageSize      int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Sort          string `form:"sort" binding:"omitempty,oneof=name email created_at updated_at"`
+	Order         string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Name          string `form:"name"`
+	Email         string `form:"email"`
+	TaxID         string `form:"tax_id"`
+	DriverLicense string `form:"driver_license"`
 }
 
 func (d *Driver) list(ctx *gin.Context) {
-	drivers, err := d.service.List()
+	var req listReq
+
+	err := ctx.ShouldBindQuery(&req)
 	if err != nil {
-		d.logger.Errorf("Failed Get All %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, util.ErrorResponse("Failed List Drivers"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
 		return
 	}
 
-	var resp []driverResponse
+	filter := dto.ListInput{
+		Input: pagination.Input{
+			Page:      req.Page,
+			PageSize:  req.PageSize,
+			SortBy:    req.Sort,
+			SortOrder: req.Order,
+		},
+		Name:          req.Name,
+		Email:         req.Email,
+		TaxID:         req.TaxID,
+		DriverLicense: req.DriverLicense,
+	}
+	filter.Normalize()
+
+	drivers, total, err := d.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Get All %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]driverResponse, 0, len(drivers))
 
 	for _, driver := range drivers {
 		resp = append(resp, driverResponse{
-			Uuid:          driver.Uuid,
-			Name:          driver.Name,
-			Email:         driver.Email,
-			TaxID:         driver.TaxID,
-			DriverLicense: driver.DriverLicense,
-			DateOfBirth:   driver.DateOfBirth.String,
-			CreatedAt:     driver.CreatedAt,
-			UpdatedAt:     driver.UpdatedAt,
+			Uuid:            driver.Uuid,
+			Name:            driver.Name,
+			Email:           driver.Email,
+			TaxID:           driver.TaxID,
+			DriverLicense:   driver.DriverLicense,
+			LicenseCategory: driver.LicenseCategory.String,
+			DateOfBirth:     driver.DateOfBirth.String,
+			DeletedAt:       driver.DeletedAt.String,
+			Version:         driver.Version,
+			CreatedAt:       driver.CreatedAt,
+			UpdatedAt:       driver.UpdatedAt,
 		})
 	}
 
-	d.logger.Infof("Successfull List")
+	middleware.Logger(ctx, d.logger).Infof("Successfull List")
 
-	ctx.JSON(200, util.SuccessResponse(resp))
+	ctx.JSON(200, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
 	return
 }
 
@@ -69,37 +118,50 @@ func (d *Driver) getId(ctx *gin.Context) {
 
 	err := ctx.ShouldBindUri(&req)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 
-	driver, err := d.service.GetByID(uuidStr)
-	if driver == nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusOK, util.SuccessResponse("Not Found"))
+	driver, err := d.service.GetByID(ctx.Request.Context(), uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Get %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
-	var vehicles []vehicleResponse
+	if etag.NotModified(ctx, driver.Version) {
+		return
+	}
+	etag.Set(ctx, driver.Version)
+
+	resp := newDriverResponse(driver)
+	middleware.Logger(ctx, d.logger).Infof("Succesful getID: %s", resp.Uuid.String())
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(resp))
+
+}
 
+func newDriverResponse(driver *aggregate.DriverVehicleAggregate) driverResponse {
 	resp := driverResponse{
-		Uuid:          driver.Uuid,
-		Name:          driver.Name,
-		Email:         driver.Email,
-		TaxID:         driver.TaxID,
-		DriverLicense: driver.DriverLicense,
-		Vehicles:      vehicles,
-		DateOfBirth:   driver.DateOfBirth.String,
-		DeletedAt:     driver.DeletedAt.String,
-		CreatedAt:     driver.CreatedAt,
-		UpdatedAt:     driver.UpdatedAt,
+		Uuid:            driver.Uuid,
+		Name:            driver.Name,
+		Email:           driver.Email,
+		TaxID:           driver.TaxID,
+		DriverLicense:   driver.DriverLicense,
+		LicenseCategory: driver.LicenseCategory.String,
+		Vehicles:        make([]vehicleResponse, 0, len(driver.Vehicles)),
+		DateOfBirth:     driver.DateOfBirth.String,
+		DeletedAt:       driver.DeletedAt.String,
+		Version:         driver.Version,
+		CreatedAt:       driver.CreatedAt,
+		UpdatedAt:       driver.UpdatedAt,
 	}
 	for _, v := range driver.Vehicles {
 		resp.Vehicles = append(resp.Vehicles, vehicleResponse{
@@ -107,12 +169,13 @@ func (d *Driver) getId(ctx *gin.Context) {
 			Brand:             v.Brand,
 			Model:             v.Model,
 			YearOfManufacture: v.YearOfManufacture,
+			LicensePlate:      v.LicensePlate,
+			Renavam:           v.Renavam.String,
+			Category:          v.Category.String,
 			Color:             v.Color,
+			CreatedAt:         v.CreatedAt,
+			UpdatedAt:         v.UpdatedAt,
 		})
 	}
-
-	d.logger.Infof("Succesful getID: %s", resp.Uuid.String())
-
-	ctx.JSON(http.StatusOK, util.SuccessResponse(resp))
-
+	return resp
 }
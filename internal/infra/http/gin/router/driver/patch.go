@@ -0,0 +1,81 @@
+package driver_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/dtos/patch"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/etag"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type driverPatchReq struct {
+	Name            patch.Field[string] `json:"name"`
+	Email           patch.Field[string] `json:"email"`
+	TaxID           patch.Field[string] `json:"tax_id"`
+	DriverLicense   patch.Field[string] `json:"driver_license"`
+	LicenseCategory patch.Field[string] `json:"license_category"`
+	DateOfBirth     patch.Field[string] `json:"date_of_birth"`
+}
+
+func (d *Driver) patch(ctx *gin.Context) {
+	var req driverPatchReq
+	var reqUid getIdReq
+
+	if ct := ctx.ContentType(); ct != patch.MediaType && ct != gin.MIMEJSON {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Patch content type %s", ct)
+		ctx.JSON(http.StatusUnsupportedMediaType, util.ErrorResponse(util.ErrorMediaType))
+		return
+	}
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Driver %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	err = ctx.ShouldBindUri(&reqUid)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	uid, err := uuid.Parse(reqUid.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := d.expectedVersion(ctx, uid)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	patched, err := d.service.Patch(ctx.Request.Context(), driver.PatchInput{
+		Uuid:            uid,
+		Name:            req.Name,
+		Email:           req.Email,
+		TaxID:           req.TaxID,
+		DriverLicense:   req.DriverLicense,
+		LicenseCategory: req.LicenseCategory,
+		DateOfBirth:     req.DateOfBirth,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed Patch %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	etag.Set(ctx, patched.Version)
+	middleware.Logger(ctx, d.logger).Infof("Patch Successful uuid: %s", uid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newDriverResponse(patched)))
+}
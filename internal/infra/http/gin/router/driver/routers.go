@@ -2,6 +2,10 @@ package driver_router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/etag"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/service/driver"
 
 	"go.uber.org/zap"
@@ -13,25 +17,42 @@ type IDriver interface {
 
 type Driver struct {
 	service driver.IDriverService
+	perms   middleware.Permissions
 	logger  *zap.SugaredLogger
 }
 
-func NewDriverRouter(s driver.IDriverService, log *zap.SugaredLogger) *Driver {
+func NewDriverRouter(s driver.IDriverService, perms middleware.Permissions, log *zap.SugaredLogger) *Driver {
 	return &Driver{
 		service: s,
+		perms:   perms,
 		logger:  log,
 	}
 }
 
 func (d *Driver) SetupDriverRoute(routers *gin.RouterGroup) {
-	routers.GET("/driver", d.list)
-	routers.GET("/driver/:uuid", d.getId)
-	routers.PUT("/driver/:uuid", d.update)
-	routers.DELETE("/driver/:uuid/hard", d.hardDelete)
-	routers.DELETE("/driver/:uuid", d.softDelete)
-	routers.PATCH("/driver/:uuid/recover", d.UnDelete)
-	routers.POST("/driver", d.create)
-	routers.POST("/driver/subscribe", d.subscribe)
-	routers.DELETE("/driver/unsubscribe", d.unSubscribe)
+	routers.GET("/driver", d.perms.Require(user.PermDriverRead), d.list)
+	routers.GET("/driver/:uuid", d.perms.Require(user.PermDriverRead), d.getId)
+	routers.PUT("/driver/:uuid", d.perms.Require(user.PermDriverWrite), d.update)
+	routers.PATCH("/driver/:uuid", d.perms.Require(user.PermDriverWrite), d.patch)
+	routers.DELETE("/driver/:uuid/hard", d.perms.Require(user.PermDriverHardDelete), d.hardDelete)
+	routers.DELETE("/driver/:uuid", d.perms.Require(user.PermDriverWrite), d.softDelete)
+	routers.PATCH("/driver/:uuid/recover", d.perms.Require(user.PermDriverWrite), d.UnDelete)
+	routers.POST("/driver", d.perms.Require(user.PermDriverWrite), d.create)
 
 }
+
+// expectedVersion is the version the If-Match header conditions a write to
+// the driver on. Only a list of tags costs a read of the current version.
+func (d *Driver) expectedVersion(ctx *gin.Context, uid uuid.UUID) (int, error) {
+	versions, err := etag.IfMatch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return etag.Expect(versions, func() (int, error) {
+		current, err := d.service.GetByID(ctx.Request.Context(), uid)
+		if err != nil {
+			return 0, err
+		}
+		return current.Version, nil
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 )
 
@@ -16,37 +18,46 @@ func (d *Driver) update(ctx *gin.Context) {
 
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(400, util.ErrorResponse(util.ErrorBadRequest.Error()))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Driver %s", err.Error())
+		ctx.JSON(400, util.ErrorResponse(util.ErrorBadRequest))
 		return
 	}
 
 	err = ctx.ShouldBindUri(&reqUid)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid.Error()))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuid, err := uuid.Parse(reqUid.Uuid)
 	if err != nil {
-		d.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid.Error()))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := d.expectedVersion(ctx, uuid)
+	if err != nil {
+		middleware.Logger(ctx, d.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
 
 	updateDriver := driver.UpdateInput{
-		Uuid:          uuid,
-		Name:          req.Name,
-		Email:         req.Email,
-		TaxID:         req.TaxID,
-		DriverLicense: req.DriverLicense,
-		DateOfBirth:   sql.NullString{String: req.DateOfBirth},
+		Uuid:            uuid,
+		Name:            req.Name,
+		Email:           req.Email,
+		TaxID:           req.TaxID,
+		DriverLicense:   req.DriverLicense,
+		LicenseCategory: req.LicenseCategory,
+		DateOfBirth:     sql.NullString{String: req.DateOfBirth},
+		ExpectedVersion: version,
 	}
 
-	err = d.service.Update(updateDriver)
+	err = d.service.Update(ctx.Request.Context(), updateDriver)
 	if err != nil {
-		d.logger.Errorf("Failed Update %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, util.ErrorResponse(err.Error()))
+		middleware.Logger(ctx, d.logger).Errorf("Failed Update %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, req)
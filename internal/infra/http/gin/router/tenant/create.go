@@ -0,0 +1,42 @@
+package tenant_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type createReq struct {
+	Name          string `json:"name" binding:"required"`
+	AdminEmail    string `json:"admin_email" binding:"required"`
+	AdminPassword string `json:"admin_password" binding:"required"`
+}
+
+func (t *TenantRouter) create(ctx *gin.Context) {
+	var req createReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed Bind Tenant %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	out, err := t.service.Create(ctx.Request.Context(), dto.CreateInput{
+		Name:          req.Name,
+		AdminEmail:    req.AdminEmail,
+		AdminPassword: req.AdminPassword,
+	})
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed Create Tenant %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, t.logger).Infof("Create Tenant Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(newTenantResponse(out)))
+}
@@ -0,0 +1,92 @@
+package tenant_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type getIdReq struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+type listReq struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type tenantResponse struct {
+	Uuid      uuid.UUID `json:"uuid"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t *TenantRouter) list(ctx *gin.Context) {
+	var req listReq
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	filter := dto.ListInput{Input: pagination.Input{Page: req.Page, PageSize: req.PageSize}}
+	filter.Normalize()
+
+	tenants, total, err := t.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed List Tenants %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]tenantResponse, 0, len(tenants))
+	for i := range tenants {
+		resp = append(resp, newTenantResponse(&tenants[i]))
+	}
+
+	ctx.JSON(http.StatusOK, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
+}
+
+func (t *TenantRouter) getId(ctx *gin.Context) {
+	var req getIdReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	out, err := t.service.GetByID(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, t.logger).Errorf("Failed Get Tenant %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, t.logger).Infof("Get Tenant Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newTenantResponse(out)))
+}
+
+func newTenantResponse(out *dto.Output) tenantResponse {
+	return tenantResponse{
+		Uuid:      out.Uuid,
+		Name:      out.Name,
+		CreatedAt: out.CreatedAt,
+	}
+}
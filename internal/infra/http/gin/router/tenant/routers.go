@@ -0,0 +1,34 @@
+package tenant_router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/service/tenant"
+
+	"go.uber.org/zap"
+)
+
+type ITenant interface {
+	SetupTenantRoute(routers *gin.RouterGroup)
+}
+
+type TenantRouter struct {
+	service tenant.ITenantService
+	perms   middleware.Permissions
+	logger  *zap.SugaredLogger
+}
+
+func NewTenantRouter(s tenant.ITenantService, perms middleware.Permissions, log *zap.SugaredLogger) *TenantRouter {
+	return &TenantRouter{
+		service: s,
+		perms:   perms,
+		logger:  log,
+	}
+}
+
+func (t *TenantRouter) SetupTenantRoute(routers *gin.RouterGroup) {
+	routers.GET("/tenants", t.perms.Require(user.PermTenantManage), t.list)
+	routers.GET("/tenants/:uuid", t.perms.Require(user.PermTenantManage), t.getId)
+	routers.POST("/tenants", t.perms.Require(user.PermTenantManage), t.create)
+}
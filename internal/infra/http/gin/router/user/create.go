@@ -0,0 +1,42 @@
+package user_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type createReq struct {
+	Email    string   `json:"email" binding:"required"`
+	Password string   `json:"password" binding:"required"`
+	Roles    []string `json:"roles"`
+}
+
+func (u *UserRouter) create(ctx *gin.Context) {
+	var req createReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Bind User %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	out, err := u.service.Create(ctx.Request.Context(), dto.CreateInput{
+		Email:    req.Email,
+		Password: req.Password,
+		Roles:    req.Roles,
+	})
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Create User %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, u.logger).Infof("Create User Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(newUserResponse(out)))
+}
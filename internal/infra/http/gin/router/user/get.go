@@ -0,0 +1,107 @@
+package user_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type getIdReq struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+type listReq struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type userResponse struct {
+	Uuid      uuid.UUID `json:"uuid"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (u *UserRouter) list(ctx *gin.Context) {
+	var req listReq
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	filter := dto.ListInput{Input: pagination.Input{Page: req.Page, PageSize: req.PageSize}}
+	filter.Normalize()
+
+	users, total, err := u.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed List Users %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]userResponse, 0, len(users))
+	for i := range users {
+		resp = append(resp, newUserResponse(&users[i]))
+	}
+
+	ctx.JSON(http.StatusOK, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
+}
+
+func (u *UserRouter) getId(ctx *gin.Context) {
+	uid, ok := u.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	out, err := u.service.GetByID(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Get User %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, u.logger).Infof("Get User Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newUserResponse(out)))
+}
+
+// bindUuid parses the :uuid path parameter, answering 400 when it is invalid.
+func (u *UserRouter) bindUuid(ctx *gin.Context) (uuid.UUID, bool) {
+	var req getIdReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func newUserResponse(out *dto.Output) userResponse {
+	return userResponse{
+		Uuid:      out.Uuid,
+		Email:     out.Email,
+		Roles:     out.Roles,
+		Active:    out.Active,
+		CreatedAt: out.CreatedAt,
+		UpdatedAt: out.UpdatedAt,
+	}
+}
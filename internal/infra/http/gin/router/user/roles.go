@@ -0,0 +1,60 @@
+package user_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type roleReq struct {
+	Role string `uri:"role" binding:"required"`
+}
+
+func (u *UserRouter) grantRole(ctx *gin.Context) {
+	uid, ok := u.bindUuid(ctx)
+	if !ok {
+		return
+	}
+	var req roleReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	out, err := u.service.GrantRole(ctx.Request.Context(), uid, req.Role)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Grant Role %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, u.logger).Infof("Grant Role Successful uuid: %s role: %s", out.Uuid, req.Role)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newUserResponse(out)))
+}
+
+func (u *UserRouter) revokeRole(ctx *gin.Context) {
+	uid, ok := u.bindUuid(ctx)
+	if !ok {
+		return
+	}
+	var req roleReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	out, err := u.service.RevokeRole(ctx.Request.Context(), uid, req.Role)
+	if err != nil {
+		middleware.Logger(ctx, u.logger).Errorf("Failed Revoke Role %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, u.logger).Infof("Revoke Role Successful uuid: %s role: %s", out.Uuid, req.Role)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newUserResponse(out)))
+}
@@ -0,0 +1,36 @@
+package user_router
+
+import (
+	"github.com/gin-gonic/gin"
+	domain "github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/service/user"
+
+	"go.uber.org/zap"
+)
+
+type IUser interface {
+	SetupUserRoute(routers *gin.RouterGroup)
+}
+
+type UserRouter struct {
+	service user.IUserService
+	perms   middleware.Permissions
+	logger  *zap.SugaredLogger
+}
+
+func NewUserRouter(s user.IUserService, perms middleware.Permissions, log *zap.SugaredLogger) *UserRouter {
+	return &UserRouter{
+		service: s,
+		perms:   perms,
+		logger:  log,
+	}
+}
+
+func (u *UserRouter) SetupUserRoute(routers *gin.RouterGroup) {
+	routers.GET("/users", u.perms.Require(domain.PermUserManage), u.list)
+	routers.GET("/users/:uuid", u.perms.Require(domain.PermUserManage), u.getId)
+	routers.POST("/users", u.perms.Require(domain.PermUserManage), u.create)
+	routers.PUT("/users/:uuid/roles/:role", u.perms.Require(domain.PermUserManage), u.grantRole)
+	routers.DELETE("/users/:uuid/roles/:role", u.perms.Require(domain.PermUserManage), u.revokeRole)
+}
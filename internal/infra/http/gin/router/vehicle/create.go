@@ -3,16 +3,20 @@ package vehicle_router
 import (
 	"github.com/gin-gonic/gin"
 	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 
 	"net/http"
 )
 
 type vehicleReq struct {
-	Brand             string `json:"brand" binding:"required"`
-	Model             string `json:"model" binding:"required"`
+	Brand             string `json:"brand"`
+	Model             string `json:"model"`
 	YearOfManufacture uint   `json:"year_of_manufacture"`
 	LicensePlate      string `json:"license_plate"`
+	Renavam           string `json:"renavam"`
+	Category          string `json:"category"`
 	Color             string `json:"color"`
 }
 
@@ -21,8 +25,8 @@ func (v *VehicleRouter) create(ctx *gin.Context) {
 
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(400, util.ErrorResponse(util.ErrorBadRequest.Error()))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Vehicle %s", err.Error())
+		ctx.JSON(400, util.ErrorResponse(util.ErrorBadRequest))
 		return
 	}
 
@@ -31,14 +35,17 @@ func (v *VehicleRouter) create(ctx *gin.Context) {
 		Model:             req.Model,
 		YearOfManufacture: req.YearOfManufacture,
 		LicensePlate:      req.LicensePlate,
+		Renavam:           req.Renavam,
+		Category:          req.Category,
 		Color:             req.Color,
 	}
-	err = v.service.Create(ve)
+	uid, err := v.service.Create(ctx.Request.Context(), ve)
 	if err != nil {
-		v.logger.Errorf("Failed Created %s", err.Error())
-		ctx.JSON(500, util.ErrorResponse(util.ErrorDatabaseCreate.Error()))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Create Vehicle %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
+	middleware.Logger(ctx, v.logger).Infof("Create Successful uuid: %s", uid)
 
 	ctx.JSON(http.StatusCreated, util.SuccessResponse(req))
 }
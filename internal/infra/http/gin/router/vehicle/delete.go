@@ -3,6 +3,8 @@ package vehicle_router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 
 	"net/http"
@@ -14,26 +16,34 @@ func (v *VehicleRouter) hardDelete(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&req)
 
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := v.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
 	}
 
 	// before hardDelete need remove relation
-	err = v.service.HardDelete(uuidStr)
+	err = v.service.HardDelete(ctx.Request.Context(), uuidStr, version)
 
 	if err != nil {
-		v.logger.Errorf("Failed Hard Delete %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, "Failed to HardDelete Driver")
+		middleware.Logger(ctx, v.logger).Errorf("Failed Hard Delete %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
 
-	v.logger.Infof("Failed Unmarshal %s", err.Error())
+	middleware.Logger(ctx, v.logger).Infof("Hard Delete Successful uuid: %s", uuidStr)
 
 	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
 
@@ -45,26 +55,34 @@ func (v *VehicleRouter) delete(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&req)
 
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := v.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
 	}
 
 	// before hardDelete need remove relation
-	err = v.service.SoftDelete(uuidStr)
+	err = v.service.SoftDelete(ctx.Request.Context(), uuidStr, version)
 
 	if err != nil {
-		v.logger.Errorf("Failed Soft Delete %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, "Failed to Delete Driver")
+		middleware.Logger(ctx, v.logger).Errorf("Failed Soft Delete %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
-	v.logger.Infof("Delete Successful uuid: %s", uuidStr)
+	middleware.Logger(ctx, v.logger).Infof("Delete Successful uuid: %s", uuidStr)
 
 	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
 
@@ -76,27 +94,34 @@ func (v *VehicleRouter) undelete(ctx *gin.Context) {
 	err := ctx.ShouldBindUri(&req)
 
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		v.logger.Errorf("Failed Parser uuid %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := v.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
 
 	// before hardDelete need remove relation
-	err = v.service.UnDelete(uuidStr)
+	err = v.service.UnDelete(ctx.Request.Context(), uuidStr, version)
 
 	if err != nil {
-		v.logger.Errorf("Failed Delete %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, "Failed to Delete Driver")
+		middleware.Logger(ctx, v.logger).Errorf("Failed Delete %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
 
-	v.logger.Infof("delete successful uuid: %s", uuidStr)
+	middleware.Logger(ctx, v.logger).Infof("delete successful uuid: %s", uuidStr)
 
 	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
 
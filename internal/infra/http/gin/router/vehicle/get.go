@@ -3,6 +3,11 @@ package vehicle_router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/etag"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 
 	"net/http"
@@ -19,22 +24,62 @@ type vehicleResponse struct {
 	Model             string    `json:"model"`
 	YearOfManufacture uint      `json:"year_of_manufacture"`
 	LicensePlate      string    `json:"license_plate"`
+	Renavam           string    `json:"renavam"`
+	Category          string    `json:"category"`
 	Color             string    `json:"color"`
 	DeletedAt         string    `json:"deleted_at"`
+	Version           int       `json:"version"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
+type listReq struct {
+	Page               int    `form:"page" binding:"omitempty,min=1"`
+	PageSize           int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Sort               string `form:"sort" binding:"omitempty,oneof=brand model year_of_manufacture license_plate created_at updated_at"`
+	Order              string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Brand              string `form:"brand"`
+	Model              string `form:"model"`
+	Color              string `form:"color"`
+	YearFrom           uint   `form:"year_from"`
+	YearTo             uint   `form:"year_to" binding:"omitempty,gtefield=YearFrom"`
+	LicensePlatePrefix string `form:"license_plate"`
+}
+
 func (v *VehicleRouter) list(ctx *gin.Context) {
-	vehicles, err := v.service.List()
+	var req listReq
 
+	err := ctx.ShouldBindQuery(&req)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, util.ErrorResponse(util.ErrorDatabaseRead.Error()))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
 		return
 	}
 
-	var resp []vehicleResponse
+	filter := dto.ListInput{
+		Input: pagination.Input{
+			Page:      req.Page,
+			PageSize:  req.PageSize,
+			SortBy:    req.Sort,
+			SortOrder: req.Order,
+		},
+		Brand:              req.Brand,
+		Model:              req.Model,
+		Color:              req.Color,
+		YearFrom:           req.YearFrom,
+		YearTo:             req.YearTo,
+		LicensePlatePrefix: req.LicensePlatePrefix,
+	}
+	filter.Normalize()
+
+	vehicles, total, err := v.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed List %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]vehicleResponse, 0, len(vehicles))
 
 	for _, vehicle := range vehicles {
 		resp = append(resp, vehicleResponse{
@@ -43,14 +88,17 @@ func (v *VehicleRouter) list(ctx *gin.Context) {
 			Model:             vehicle.Model,
 			YearOfManufacture: vehicle.YearOfManufacture,
 			LicensePlate:      vehicle.LicensePlate,
+			Renavam:           vehicle.Renavam.String,
+			Category:          vehicle.Category.String,
 			Color:             vehicle.Color,
 			DeletedAt:         vehicle.DeletedAt.String,
+			Version:           vehicle.Version,
 			CreatedAt:         vehicle.CreatedAt,
 			UpdatedAt:         vehicle.UpdatedAt,
 		})
 	}
 
-	ctx.JSON(200, util.SuccessResponse(resp))
+	ctx.JSON(200, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
 	return
 }
 
@@ -59,36 +107,75 @@ func (v *VehicleRouter) getId(ctx *gin.Context) {
 
 	err := ctx.ShouldBindUri(&req)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse("Bad Request!! Uuid: Invalid"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	vehicle, err := v.service.GetByID(ctx.Request.Context(), uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Get %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
-	vehicle, err := v.service.GetByID(uuidStr)
-	if vehicle == nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusOK, util.ErrorResponse("Not Found"))
+	if etag.NotModified(ctx, vehicle.Version) {
 		return
 	}
+	etag.Set(ctx, vehicle.Version)
+
+	resp := newVehicleResponse(vehicle)
+	middleware.Logger(ctx, v.logger).Infof("Get Id Successful uuid: %s", resp.Uuid.String())
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(resp))
+
+}
+
+type getPlateReq struct {
+	Plate string `uri:"plate" binding:"required"`
+}
 
-	resp := vehicleResponse{
+func (v *VehicleRouter) getByPlate(ctx *gin.Context) {
+	var req getPlateReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	vehicle, err := v.service.GetByLicensePlate(ctx.Request.Context(), req.Plate)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Get By Plate %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	etag.Set(ctx, vehicle.Version)
+
+	resp := newVehicleResponse(vehicle)
+	middleware.Logger(ctx, v.logger).Infof("Get Plate Successful uuid: %s", resp.Uuid.String())
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(resp))
+}
+
+func newVehicleResponse(vehicle *dto.Output) vehicleResponse {
+	return vehicleResponse{
 		Uuid:              vehicle.Uuid,
 		Brand:             vehicle.Brand,
 		Model:             vehicle.Model,
 		YearOfManufacture: vehicle.YearOfManufacture,
 		LicensePlate:      vehicle.LicensePlate,
+		Renavam:           vehicle.Renavam.String,
+		Category:          vehicle.Category.String,
 		Color:             vehicle.Color,
 		CreatedAt:         vehicle.CreatedAt,
 		DeletedAt:         vehicle.DeletedAt.String,
+		Version:           vehicle.Version,
 		UpdatedAt:         vehicle.UpdatedAt,
 	}
-	v.logger.Infof("Get Id Successful uuid: %s", resp.Uuid.String())
-
-	ctx.JSON(http.StatusOK, util.SuccessResponse(resp))
-
 }
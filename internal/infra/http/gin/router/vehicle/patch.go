@@ -0,0 +1,83 @@
+package vehicle_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/patch"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/etag"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type vehiclePatchReq struct {
+	Brand             patch.Field[string] `json:"brand"`
+	Model             patch.Field[string] `json:"model"`
+	YearOfManufacture patch.Field[uint]   `json:"year_of_manufacture"`
+	LicensePlate      patch.Field[string] `json:"license_plate"`
+	Renavam           patch.Field[string] `json:"renavam"`
+	Category          patch.Field[string] `json:"category"`
+	Color             patch.Field[string] `json:"color"`
+}
+
+func (v *VehicleRouter) patch(ctx *gin.Context) {
+	var req vehiclePatchReq
+	var reqUid getIdReq
+
+	if ct := ctx.ContentType(); ct != patch.MediaType && ct != gin.MIMEJSON {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Patch content type %s", ct)
+		ctx.JSON(http.StatusUnsupportedMediaType, util.ErrorResponse(util.ErrorMediaType))
+		return
+	}
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Vehicle %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	err = ctx.ShouldBindUri(&reqUid)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	uuidStr, err := uuid.Parse(reqUid.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	version, err := v.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	patched, err := v.service.Patch(ctx.Request.Context(), dto.PatchInput{
+		Uuid:              uuidStr,
+		Brand:             req.Brand,
+		Model:             req.Model,
+		YearOfManufacture: req.YearOfManufacture,
+		LicensePlate:      req.LicensePlate,
+		Renavam:           req.Renavam,
+		Category:          req.Category,
+		Color:             req.Color,
+		ExpectedVersion:   version,
+	})
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed Patch %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	etag.Set(ctx, patched.Version)
+	middleware.Logger(ctx, v.logger).Infof("Patch Successful uuid: %s", uuidStr)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newVehicleResponse(patched)))
+}
@@ -2,6 +2,10 @@ package vehicle_router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/etag"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/service/vehicle"
 
 	"go.uber.org/zap"
@@ -13,23 +17,43 @@ type IVehicle interface {
 
 type VehicleRouter struct {
 	service vehicle.IVehicleService
+	perms   middleware.Permissions
 	logger  *zap.SugaredLogger
 }
 
-func NewVehicleRouter(s vehicle.IVehicleService, log *zap.SugaredLogger) *VehicleRouter {
+func NewVehicleRouter(s vehicle.IVehicleService, perms middleware.Permissions, log *zap.SugaredLogger) *VehicleRouter {
 	return &VehicleRouter{
 		service: s,
+		perms:   perms,
 		logger:  log,
 	}
 }
 
 func (v *VehicleRouter) SetupVehicleRoute(routers *gin.RouterGroup) {
-	routers.GET("/vehicle", v.list)
-	routers.GET("/vehicle/:uuid", v.getId)
-	routers.PUT("/vehicle/:uuid", v.update)
-	routers.DELETE("/vehicle/:uuid/hard", v.hardDelete)
-	routers.DELETE("/vehicle/:uuid", v.delete)
-	routers.PATCH("/vehicle/:uuid/recover", v.undelete)
-	routers.POST("/vehicle", v.create)
+	routers.GET("/vehicle", v.perms.Require(user.PermVehicleRead), v.list)
+	routers.GET("/vehicle/:uuid", v.perms.Require(user.PermVehicleRead), v.getId)
+	routers.GET("/vehicle/plate/:plate", v.perms.Require(user.PermVehicleRead), v.getByPlate)
+	routers.PUT("/vehicle/:uuid", v.perms.Require(user.PermVehicleWrite), v.update)
+	routers.PATCH("/vehicle/:uuid", v.perms.Require(user.PermVehicleWrite), v.patch)
+	routers.DELETE("/vehicle/:uuid/hard", v.perms.Require(user.PermVehicleHardDelete), v.hardDelete)
+	routers.DELETE("/vehicle/:uuid", v.perms.Require(user.PermVehicleWrite), v.delete)
+	routers.PATCH("/vehicle/:uuid/recover", v.perms.Require(user.PermVehicleWrite), v.undelete)
+	routers.POST("/vehicle", v.perms.Require(user.PermVehicleWrite), v.create)
 
 }
+
+// expectedVersion is the version the If-Match header conditions a write to
+// the vehicle on. Only a list of tags costs a read of the current version.
+func (v *VehicleRouter) expectedVersion(ctx *gin.Context, uid uuid.UUID) (int, error) {
+	versions, err := etag.IfMatch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return etag.Expect(versions, func() (int, error) {
+		current, err := v.service.GetByID(ctx.Request.Context(), uid)
+		if err != nil {
+			return 0, err
+		}
+		return current.Version, nil
+	})
+}
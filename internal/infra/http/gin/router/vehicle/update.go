@@ -4,6 +4,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
 	"github.com/moura95/go-ddd/internal/infra/util"
 
 	"net/http"
@@ -15,22 +17,29 @@ func (v *VehicleRouter) update(ctx *gin.Context) {
 
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(400, util.ErrorResponse(util.ErrorBadRequest.Error()))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Vehicle %s", err.Error())
+		ctx.JSON(400, util.ErrorResponse(util.ErrorBadRequest))
 		return
 	}
 
 	err = ctx.ShouldBindUri(&reqUid)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid.Error()))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
 		return
 	}
 	uuidStr, err := uuid.Parse(reqUid.Uuid)
 	if err != nil {
-		v.logger.Errorf("Failed Parser uuid %s", err.Error())
-		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid.Error()))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
 
+	version, err := v.expectedVersion(ctx, uuidStr)
+	if err != nil {
+		middleware.Logger(ctx, v.logger).Errorf("Failed If-Match %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
 	}
 
 	updateVehicle := dto.UpdateInput{
@@ -39,16 +48,19 @@ func (v *VehicleRouter) update(ctx *gin.Context) {
 		Model:             req.Model,
 		YearOfManufacture: req.YearOfManufacture,
 		LicensePlate:      req.LicensePlate,
+		Renavam:           req.Renavam,
+		Category:          req.Category,
 		Color:             req.Color,
+		ExpectedVersion:   version,
 	}
 
-	err = v.service.Update(updateVehicle)
+	err = v.service.Update(ctx.Request.Context(), updateVehicle)
 	if err != nil {
-		v.logger.Errorf("Failed Unmarshal %s", err.Error())
-		ctx.JSON(http.StatusInternalServerError, util.ErrorResponse("Failed Updated"))
+		middleware.Logger(ctx, v.logger).Errorf("Failed Update %s", err.Error())
+		httperror.Respond(ctx, err)
 		return
 	}
-	v.logger.Infof("Updated Successful uuid: %s", updateVehicle.Uuid.String())
+	middleware.Logger(ctx, v.logger).Infof("Updated Successful uuid: %s", updateVehicle.Uuid.String())
 
 	ctx.JSON(http.StatusOK, req)
 
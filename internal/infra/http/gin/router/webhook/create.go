@@ -0,0 +1,51 @@
+package webhook_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type createReq struct {
+	URL    string   `json:"url" binding:"required"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+type createResponse struct {
+	webhookResponse
+	// Secret is shown once, so clients can verify signatures.
+	Secret string `json:"secret"`
+}
+
+func (w *WebhookRouter) create(ctx *gin.Context) {
+	var req createReq
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Bind Webhook %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	out, err := w.service.Create(ctx.Request.Context(), dto.CreateInput{
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+	})
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Create Webhook %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, w.logger).Infof("Create Webhook Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusCreated, util.SuccessResponse(createResponse{
+		webhookResponse: newWebhookResponse(out),
+		Secret:          out.Secret,
+	}))
+}
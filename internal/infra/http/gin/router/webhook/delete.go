@@ -0,0 +1,27 @@
+package webhook_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+func (w *WebhookRouter) delete(ctx *gin.Context) {
+	uid, ok := w.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	err := w.service.Delete(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Delete Webhook %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, w.logger).Infof("Delete Webhook Successful uuid: %s", uid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse("OK"))
+}
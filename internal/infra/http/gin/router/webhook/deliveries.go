@@ -0,0 +1,133 @@
+package webhook_router
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/webhook"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type deliveryListReq struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending failed succeeded dead"`
+}
+
+type redeliverReq struct {
+	Uuid     string `uri:"uuid" binding:"required"`
+	Delivery string `uri:"delivery" binding:"required"`
+}
+
+type deliveryResponse struct {
+	Uuid           uuid.UUID       `json:"uuid"`
+	EventUUID      uuid.UUID       `json:"event_uuid"`
+	EventName      string          `json:"event_name"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int64           `json:"last_status_code"`
+	LastError      string          `json:"last_error"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func (w *WebhookRouter) listDeliveries(ctx *gin.Context) {
+	var query deliveryListReq
+
+	uid, ok := w.bindUuid(ctx)
+	if !ok {
+		return
+	}
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	filter := dto.DeliveryListInput{
+		Input:  pagination.Input{Page: query.Page, PageSize: query.PageSize},
+		Status: query.Status,
+	}
+	filter.Normalize()
+
+	deliveries, total, err := w.service.ListDeliveries(ctx.Request.Context(), uid, filter)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed List Webhook Deliveries %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]deliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		resp = append(resp, newDeliveryResponse(&deliveries[i]))
+	}
+
+	ctx.JSON(http.StatusOK, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
+}
+
+func (w *WebhookRouter) redeliver(ctx *gin.Context) {
+	var req redeliverReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+	deliveryUUID, err := uuid.Parse(req.Delivery)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return
+	}
+
+	out, err := w.service.Redeliver(ctx.Request.Context(), uid, deliveryUUID)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Redeliver Webhook %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, w.logger).Infof("Redeliver Webhook Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusAccepted, util.SuccessResponse(newDeliveryResponse(out)))
+}
+
+func newDeliveryResponse(d *dto.DeliveryOutput) deliveryResponse {
+	resp := deliveryResponse{
+		Uuid:           d.Uuid,
+		EventUUID:      d.EventUUID,
+		EventName:      d.EventName,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode.Int64,
+		LastError:      d.LastError.String,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	// only deliveries still waiting have a meaningful next attempt
+	if d.Status == string(domain.StatusPending) || d.Status == string(domain.StatusFailed) {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+	if d.DeliveredAt.Valid {
+		resp.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return resp
+}
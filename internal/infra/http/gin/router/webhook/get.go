@@ -0,0 +1,108 @@
+package webhook_router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type getIdReq struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+type listReq struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// webhookResponse leaves the secret out; it is only returned on creation.
+type webhookResponse struct {
+	Uuid      uuid.UUID `json:"uuid"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (w *WebhookRouter) list(ctx *gin.Context) {
+	var req listReq
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Bind Query %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	filter := dto.ListInput{Input: pagination.Input{Page: req.Page, PageSize: req.PageSize}}
+	filter.Normalize()
+
+	subscriptions, total, err := w.service.List(ctx.Request.Context(), filter)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed List Webhooks %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+
+	resp := make([]webhookResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		resp = append(resp, newWebhookResponse(&subscriptions[i]))
+	}
+
+	ctx.JSON(http.StatusOK, util.PaginatedResponse(resp, filter.Page, filter.PageSize, total))
+}
+
+func (w *WebhookRouter) getId(ctx *gin.Context) {
+	uid, ok := w.bindUuid(ctx)
+	if !ok {
+		return
+	}
+
+	out, err := w.service.GetByID(ctx.Request.Context(), uid)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Get Webhook %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, w.logger).Infof("Get Webhook Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newWebhookResponse(out)))
+}
+
+// bindUuid parses the :uuid path parameter, answering 400 when it is invalid.
+func (w *WebhookRouter) bindUuid(ctx *gin.Context) (uuid.UUID, bool) {
+	var req getIdReq
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Bind Uri %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Parser uuid %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequestUuid))
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func newWebhookResponse(out *dto.Output) webhookResponse {
+	return webhookResponse{
+		Uuid:      out.Uuid,
+		URL:       out.URL,
+		Events:    out.Events,
+		Active:    out.Active,
+		CreatedAt: out.CreatedAt,
+		UpdatedAt: out.UpdatedAt,
+	}
+}
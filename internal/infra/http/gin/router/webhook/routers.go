@@ -0,0 +1,38 @@
+package webhook_router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/service/webhook"
+
+	"go.uber.org/zap"
+)
+
+type IWebhook interface {
+	SetupWebhookRoute(routers *gin.RouterGroup)
+}
+
+type WebhookRouter struct {
+	service webhook.IWebhookService
+	perms   middleware.Permissions
+	logger  *zap.SugaredLogger
+}
+
+func NewWebhookRouter(s webhook.IWebhookService, perms middleware.Permissions, log *zap.SugaredLogger) *WebhookRouter {
+	return &WebhookRouter{
+		service: s,
+		perms:   perms,
+		logger:  log,
+	}
+}
+
+func (w *WebhookRouter) SetupWebhookRoute(routers *gin.RouterGroup) {
+	routers.GET("/webhooks", w.perms.Require(user.PermWebhookManage), w.list)
+	routers.GET("/webhooks/:uuid", w.perms.Require(user.PermWebhookManage), w.getId)
+	routers.POST("/webhooks", w.perms.Require(user.PermWebhookManage), w.create)
+	routers.PUT("/webhooks/:uuid", w.perms.Require(user.PermWebhookManage), w.update)
+	routers.DELETE("/webhooks/:uuid", w.perms.Require(user.PermWebhookManage), w.delete)
+	routers.GET("/webhooks/:uuid/deliveries", w.perms.Require(user.PermWebhookManage), w.listDeliveries)
+	routers.POST("/webhooks/:uuid/deliveries/:delivery/redeliver", w.perms.Require(user.PermWebhookManage), w.redeliver)
+}
@@ -0,0 +1,49 @@
+package webhook_router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/httperror"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/util"
+)
+
+type updateReq struct {
+	URL    string   `json:"url" binding:"required"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events" binding:"required,min=1"`
+	Active *bool    `json:"active" binding:"required"`
+}
+
+func (w *WebhookRouter) update(ctx *gin.Context) {
+	var req updateReq
+
+	uid, ok := w.bindUuid(ctx)
+	if !ok {
+		return
+	}
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Bind Webhook %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, util.ErrorResponse(util.ErrorBadRequest))
+		return
+	}
+
+	out, err := w.service.Update(ctx.Request.Context(), dto.UpdateInput{
+		Uuid:   uid,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+		Active: *req.Active,
+	})
+	if err != nil {
+		middleware.Logger(ctx, w.logger).Errorf("Failed Update Webhook %s", err.Error())
+		httperror.Respond(ctx, err)
+		return
+	}
+	middleware.Logger(ctx, w.logger).Infof("Update Webhook Successful uuid: %s", out.Uuid)
+
+	ctx.JSON(http.StatusOK, util.SuccessResponse(newWebhookResponse(out)))
+}
@@ -1,15 +1,48 @@
 package gin
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apikeypostgres "github.com/moura95/go-ddd/internal/domain/apikey/postgres"
+	assignmentcache "github.com/moura95/go-ddd/internal/domain/assignment/cache"
+	assignmentpostgres "github.com/moura95/go-ddd/internal/domain/assignment/postgres"
+	auditpostgres "github.com/moura95/go-ddd/internal/domain/audit/postgres"
+	drivercache "github.com/moura95/go-ddd/internal/domain/driver/cache"
 	driverpostgres "github.com/moura95/go-ddd/internal/domain/driver/postgres"
+	domaintenant "github.com/moura95/go-ddd/internal/domain/tenant"
+	tenantpostgres "github.com/moura95/go-ddd/internal/domain/tenant/postgres"
+	domainuser "github.com/moura95/go-ddd/internal/domain/user"
+	userpostgres "github.com/moura95/go-ddd/internal/domain/user/postgres"
+	vehiclecache "github.com/moura95/go-ddd/internal/domain/vehicle/cache"
 	vehiclepostgres "github.com/moura95/go-ddd/internal/domain/vehicle/postgres"
+	infraauth "github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	apikeyrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/apikey"
+	assignmentrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/assignment"
+	auditrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/audit"
+	authrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/auth"
 	driverrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/driver"
+	tenantrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/tenant"
+	userrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/user"
 	vehiclerouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/vehicle"
+	webhookrouter "github.com/moura95/go-ddd/internal/infra/http/gin/router/webhook"
+	"github.com/moura95/go-ddd/internal/infra/outbox"
+	"github.com/moura95/go-ddd/internal/infra/util"
+	"github.com/moura95/go-ddd/internal/service/apikey"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+	"github.com/moura95/go-ddd/internal/service/audit"
+	"github.com/moura95/go-ddd/internal/service/auth"
 	"github.com/moura95/go-ddd/internal/service/driver"
+	servicemetrics "github.com/moura95/go-ddd/internal/service/metrics"
+	"github.com/moura95/go-ddd/internal/service/rbac"
+	"github.com/moura95/go-ddd/internal/service/tenant"
+	servicetracing "github.com/moura95/go-ddd/internal/service/tracing"
+	"github.com/moura95/go-ddd/internal/service/user"
 	"github.com/moura95/go-ddd/internal/service/vehicle"
+	"github.com/moura95/go-ddd/internal/service/webhook"
 	"go.uber.org/zap"
 )
 
@@ -17,18 +50,124 @@ func (s *Server) createRoutesV1(router *gin.Engine, log *zap.SugaredLogger) {
 	router.GET("/healthz", func(c *gin.Context) {
 		c.Status(http.StatusNoContent)
 	})
-
 	routes := router.Group("/")
+	// Unit of work shared by the services for multi-step use cases
+	uow := database.NewUnitOfWork(s.store)
+	if s.config.DBRowLevelSecurity {
+		// confine each statement to the caller's tenant in the database too
+		uow = database.NewTenantUnitOfWork(s.store)
+	}
+	// Permissions the routers demand; every request passes with auth disabled
+	perms := middleware.NewPermissions(s.config.AuthEnabled)
+
+	if s.config.AuthEnabled {
+		signer, err := infraauth.NewSigner(*s.config)
+		if err != nil {
+			log.Fatalf("Invalid auth config: %s", err)
+		}
+		// Instance User Repository
+		userRepository := userpostgres.NewUserRepository(s.store, log)
+		// Instance Auth Service. Users, sessions, API keys and tenants are
+		// not row-secured, and logins and key checks happen before there is
+		// a tenant, so the services managing them keep a plain unit of work
+		authUow := database.NewUnitOfWork(s.store)
+		authService := auth.NewAuthService(s.store, authUow, userRepository, signer, *s.config, log)
+		if s.config.AuthBootstrapEmail != "" && s.config.AuthBootstrapPassword != "" {
+			if err := authService.Bootstrap(context.Background(), s.config.AuthBootstrapEmail, s.config.AuthBootstrapPassword); err != nil {
+				log.Errorf("Failed Bootstrap User %s", err.Error())
+			}
+		}
+		// Instance API Key Service, also checking the keys integrations send
+		apiKeyService := apikey.NewAPIKeyService(s.store, authUow, apikeypostgres.NewAPIKeyRepository(s.store, log), userRepository, *s.config, log)
+		// login, refresh and logout stay open; everything registered on
+		// routes from here on needs an access token or an API key
+		authrouter.NewAuthRouter(authService, log).SetupAuthRoute(router.Group("/"))
+		routes.Use(middleware.AuthMiddleware(signer, apiKeyService))
+		// ahead of the first route on routes, so every one of them runs
+		// scoped to the caller's tenant
+		if s.config.DBRowLevelSecurity {
+			routes.Use(middleware.RowSecurityMiddleware())
+		}
+
+		// Instance User Service, managing accounts and their roles
+		userService := user.NewUserService(s.store, authUow, userRepository, *s.config, log)
+		userrouter.NewUserRouter(userService, perms, log).SetupUserRoute(routes)
+		apikeyrouter.NewAPIKeyRouter(apiKeyService, perms, log).SetupAPIKeyRoute(routes)
+
+		// Instance Tenant Service, onboarding client companies
+		tenantService := tenant.NewTenantService(s.store, authUow, tenantpostgres.NewTenantRepository(s.store, log), userRepository, *s.config, log)
+		tenantrouter.NewTenantRouter(tenantService, perms, log).SetupTenantRoute(routes)
+	} else {
+		// without a caller to take it from, everything runs in the default tenant
+		routes.Use(middleware.TenantMiddleware(domaintenant.Default))
+		if s.config.DBRowLevelSecurity {
+			routes.Use(middleware.RowSecurityMiddleware())
+		}
+	}
+
+	routes.GET("/cache/stats", perms.Require(domainuser.PermSystemRead), func(c *gin.Context) {
+		c.JSON(http.StatusOK, util.SuccessResponse(s.cache.Stats()))
+	})
+	if s.metrics != nil {
+		// scrapers send an API key holding system:read
+		routes.GET("/metrics", perms.Require(domainuser.PermSystemRead), gin.WrapH(s.metrics.Handler()))
+	}
+
+	// Instance Audit Repository
+	auditRepository := auditpostgres.NewAuditRepository(s.store, log)
+	// Records write operations of the services wrapped below
+	recorder := audit.NewRecorder(uow, auditRepository, log)
+	// Instance Audit Service
+	auditService := audit.NewAuditService(s.store, auditRepository, *s.config, log)
+
 	// Instance Driver Repository Postgres
 	driverRepository := driverpostgres.NewDriverRepository(s.store, log)
+	if s.config.CacheEnabled {
+		driverRepository = drivercache.NewDriverRepository(driverRepository, s.cache)
+	}
 	// Instance Driver Service
-	driverService := driver.NewDriverService(s.store, driverRepository, *s.config, log)
+	driverService := audit.NewDriverService(driver.NewDriverService(s.store, uow, driverRepository, s.outbox, *s.config, log), recorder)
 
 	// Instance VehicleRouter Repository
 	vehicleRepository := vehiclepostgres.NewVehicleRepository(s.store, log)
+	if s.config.CacheEnabled {
+		vehicleRepository = vehiclecache.NewVehicleRepository(vehicleRepository, s.cache)
+	}
 	// Instance VehicleRouter Service
-	vehicleService := vehicle.NewVehicleService(s.store, vehicleRepository, *s.config, log)
+	vehicleService := audit.NewVehicleService(vehicle.NewVehicleService(s.store, uow, vehicleRepository, s.outbox, *s.config, log), recorder)
+
+	// Instance Assignment Repository
+	assignmentRepository := assignmentpostgres.NewAssignmentRepository(s.store, log)
+	if s.config.CacheEnabled {
+		// keeps cached drivers in step with their assignments
+		assignmentRepository = assignmentcache.NewAssignmentRepository(assignmentRepository, s.cache)
+	}
+	// Instance Assignment Service
+	assignmentService := audit.NewAssignmentService(assignment.NewAssignmentService(s.store, uow, assignmentRepository, s.outbox, *s.config, log), recorder)
+
+	if s.config.AuthEnabled {
+		// re-check in the services the permissions the routers demand
+		driverService = rbac.NewDriverService(driverService)
+		vehicleService = rbac.NewVehicleService(vehicleService)
+		assignmentService = rbac.NewAssignmentService(assignmentService)
+	}
+	// spans for every call, with the statements the call runs nested in them
+	driverService = servicetracing.NewDriverService(driverService)
+	vehicleService = servicetracing.NewVehicleService(vehicleService)
+	assignmentService = servicetracing.NewAssignmentService(assignmentService)
+	if s.metrics != nil {
+		// outermost, so calls refused by the checks above are counted too
+		driverService = servicemetrics.NewDriverService(driverService, s.metrics)
+		vehicleService = servicemetrics.NewVehicleService(vehicleService, s.metrics)
+	}
+
+	// Instance Webhook Service, fed with every event the outbox relays
+	webhookService := webhook.NewWebhookService(s.store, uow, s.webhooks, *s.config, log)
+	s.bus.Subscribe(outbox.AllEvents, webhookService.Enqueue)
 
-	vehiclerouter.NewVehicleRouter(vehicleService, log).SetupVehicleRoute(routes)
-	driverrouter.NewDriverRouter(driverService, log).SetupDriverRoute(routes)
+	vehiclerouter.NewVehicleRouter(vehicleService, perms, log).SetupVehicleRoute(routes)
+	driverrouter.NewDriverRouter(driverService, perms, log).SetupDriverRoute(routes)
+	assignmentrouter.NewAssignmentRouter(assignmentService, perms, log).SetupAssignmentRoute(routes)
+	auditrouter.NewAuditRouter(auditService, perms, log).SetupAuditRoute(routes)
+	webhookrouter.NewWebhookRouter(webhookService, perms, log).SetupWebhookRoute(routes)
 }
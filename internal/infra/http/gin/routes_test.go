@@ -0,0 +1,54 @@
+package gin
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// TestRowSecurityCoversEveryRoute boots the routes with row-level security
+// and checks every route behind auth carries the same middleware as the
+// driver routes, the row security one included.
+func TestRowSecurityCoversEveryRoute(t *testing.T) {
+	mode := gin.Mode()
+	gin.SetMode(gin.DebugMode)
+	defer gin.SetMode(mode)
+	handlers := map[string]int{}
+	print := gin.DebugPrintRouteFunc
+	gin.DebugPrintRouteFunc = func(method, path, _ string, n int) {
+		handlers[method+" "+path] = n
+	}
+	defer func() { gin.DebugPrintRouteFunc = print }()
+
+	// opening does not connect, and building the routes runs no query
+	store, err := sqlx.Open("postgres", "postgres://localhost:1/none?sslmode=disable")
+	assert.NoError(t, err)
+	defer store.Close()
+	NewServer(cfg.Config{
+		DBRowLevelSecurity: true,
+		AuthEnabled:        true,
+		AuthJWTAlgorithm:   "HS256",
+		AuthJWTSecret:      strings.Repeat("s", 32),
+	}, store, zap.NewNop().Sugar())
+
+	want := handlers[http.MethodPost+" /driver"]
+	assert.NotZero(t, want)
+	for _, route := range []string{
+		"POST /users",
+		"PUT /users/:uuid/roles/:role",
+		"DELETE /users/:uuid/roles/:role",
+		"POST /tenants",
+		"POST /api-keys",
+		"GET /vehicle/:uuid",
+	} {
+		assert.Equal(t, want, handlers[route], route)
+	}
+	assert.Less(t, handlers["POST /auth/login"], want, "logins run before there is a tenant")
+}
@@ -1,14 +1,25 @@
 package gin
 
 import (
+	"context"
 	"time"
 
-	"github.com/axiaoxin-com/ratelimiter"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	eventpostgres "github.com/moura95/go-ddd/internal/domain/event/postgres"
+	"github.com/moura95/go-ddd/internal/domain/webhook"
+	webhookpostgres "github.com/moura95/go-ddd/internal/domain/webhook/postgres"
+	"github.com/moura95/go-ddd/internal/infra/cache"
 	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/http/gin/middleware"
+	"github.com/moura95/go-ddd/internal/infra/metrics"
+	"github.com/moura95/go-ddd/internal/infra/outbox"
+	"github.com/moura95/go-ddd/internal/infra/ratelimit"
+	webhookdispatcher "github.com/moura95/go-ddd/internal/infra/webhook"
 
 	"go.uber.org/zap"
 )
@@ -18,6 +29,17 @@ type Server struct {
 	router *gin.Engine
 	config *cfg.Config
 	logger *zap.SugaredLogger
+	// outbox receives the domain events the services raise; the relay
+	// started by Start hands them to the in-process bus
+	outbox event.IOutboxRepository
+	bus    *outbox.Bus
+	// webhooks holds the subscriptions fed from the bus and the deliveries
+	// the dispatcher started by Start sends
+	webhooks webhook.IWebhookRepository
+	// cache backs the driver and vehicle repositories when CACHE_ENABLED
+	cache *cache.Cache
+	// metrics is nil unless METRICS_ENABLED
+	metrics *metrics.Metrics
 }
 
 func NewServer(cfg cfg.Config, store *sqlx.DB, log *zap.SugaredLogger) *Server {
@@ -26,45 +48,91 @@ func NewServer(cfg cfg.Config, store *sqlx.DB, log *zap.SugaredLogger) *Server {
 		store:  store,
 		config: &cfg,
 		logger: log,
+		outbox: eventpostgres.NewOutboxRepository(store, log),
+		bus:    outbox.NewBus(),
+
+		webhooks: webhookpostgres.NewWebhookRepository(store, log),
+		cache:    cache.NewFromConfig(cfg, log),
 	}
 	var router *gin.Engine
 
+	// gin's own access log is left out; ActivityMiddleware writes a
+	// structured one
 	if server.config.GinMode == "release" {
-		router = gin.Default()
+		router = gin.New()
+		router.Use(gin.Recovery())
 		router.Use(gzip.Gzip(gzip.DefaultCompression))
-		router.Use(ratelimiter.GinMemRatelimiter(ratelimiter.GinRatelimiterConfig{
-			LimitKey: func(c *gin.Context) string {
-				return c.ClientIP()
-			},
-			LimitedHandler: func(c *gin.Context) {
-				c.JSON(200, "too many requests!!!")
-				c.Abort()
-				return
-			},
-			TokenBucketConfig: func(*gin.Context) (time.Duration, int) {
-				return time.Second * 60, 4000
-			},
-		}))
-
 	} else {
-		router = gin.Default()
+		router = gin.New()
+		router.Use(gin.Recovery())
+	}
+	// ClientIP, which rate limiting and the audit trail key on, only reads
+	// X-Forwarded-For from these proxies
+	if err := router.SetTrustedProxies(cfg.HTTPTrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %s", err)
 	}
 
 	corsConfig := cors.Config{
 		AllowAllOrigins:  true,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "redirect", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "redirect", "Authorization", "If-Match", "If-None-Match", middleware.RequestIDHeader, middleware.APIKeyHeader, "traceparent", "tracestate"},
+		ExposeHeaders:    []string{"ETag", "WWW-Authenticate", middleware.RequestIDHeader, middleware.RateLimitLimitHeader, middleware.RateLimitRemainingHeader, middleware.RateLimitResetHeader, "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
 
+	// outermost, so every request, rejected or not, gets a span that the
+	// logs written while handling it refer to
+	router.Use(middleware.TracingMiddleware(cfg.TracingServiceName))
+	// inside the span, so the access line carries its trace id
+	router.Use(middleware.ActivityMiddleware(log))
+	if server.config.MetricsEnabled {
+		server.metrics = metrics.New()
+		err := server.metrics.Register(
+			metrics.NewDBStatsCollector(store.DB),
+			metrics.NewFleetCollector(store, cfg.DBQueryTimeout, cfg.DBRowLevelSecurity, log),
+		)
+		if err != nil {
+			log.Fatalf("Invalid metrics config: %s", err)
+		}
+		// after tracing and the access log but ahead of CORS, rate limiting
+		// and auth, so the requests those reject are counted as well
+		router.Use(middleware.MetricsMiddleware(server.metrics))
+	}
 	router.Use(cors.New(corsConfig))
+	if server.config.RateLimitEnabled {
+		policy, err := ratelimit.NewPolicy(cfg)
+		if err != nil {
+			log.Fatalf("Invalid rate limit config: %s", err)
+		}
+		limiter, err := ratelimit.NewFromConfig(cfg, log)
+		if err != nil {
+			log.Fatalf("Invalid rate limit config: %s", err)
+		}
+		router.Use(middleware.RateLimitMiddleware(limiter, policy, log))
+	}
+	router.Use(middleware.AuditMiddleware())
 	server.createRoutesV1(router, log)
 
 	server.router = router
 	return server
 }
 
+// Start runs the outbox relay and the webhook dispatcher in the background
+// and serves HTTP on address.
 func (s *Server) Start(address string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uow := database.NewUnitOfWork(s.store)
+	if s.config.DBRowLevelSecurity {
+		// the relay and the dispatcher act for every tenant
+		ctx = database.AsSystem(ctx)
+		uow = database.NewTenantUnitOfWork(s.store)
+	}
+	relay := outbox.NewRelay(uow, s.outbox, []outbox.Publisher{s.bus}, *s.config, s.logger)
+	go relay.Run(ctx)
+	go webhookdispatcher.NewDispatcher(s.webhooks, nil, *s.config, s.logger).Run(ctx)
+
 	return s.router.Run(address)
 }
@@ -0,0 +1,37 @@
+// Package logging carries the logger of a request on its context, so the
+// lines handlers and services write can be told apart by request.
+package logging
+
+import (
+	"context"
+
+	"github.com/moura95/go-ddd/internal/infra/tracing"
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying log.
+func WithLogger(ctx context.Context, log *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// With returns a copy of ctx whose logger adds the key-value pairs to every
+// line; ctx is returned as is when it carries no logger.
+func With(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	log, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger)
+	if !ok {
+		return ctx
+	}
+	return WithLogger(ctx, log.With(keysAndValues...))
+}
+
+// FromContext returns the logger on ctx, or fallback when there is none,
+// with the ids of the span ctx is in.
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	log, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger)
+	if !ok {
+		log = fallback
+	}
+	return tracing.Logger(ctx, log)
+}
@@ -0,0 +1,31 @@
+package logging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	fallback := zap.New(core).Sugar().With("logger", "fallback")
+
+	logging.FromContext(context.Background(), fallback).Info("no request")
+	ctx := logging.WithLogger(context.Background(), zap.New(core).Sugar().With("request_id", "abc"))
+	ctx = logging.With(ctx, "principal", "user:1")
+	logging.FromContext(ctx, fallback).Info("in request")
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "fallback", entries[0].ContextMap()["logger"])
+	assert.Equal(t, map[string]interface{}{"request_id": "abc", "principal": "user:1"}, entries[1].ContextMap())
+}
+
+func TestWithoutLogger(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, logging.With(ctx, "principal", "user:1"))
+}
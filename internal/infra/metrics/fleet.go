@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// fleetGauges are counted on every scrape; each query is a plain count
+// over the whole table, across tenants.
+var fleetGauges = []struct {
+	desc  *prometheus.Desc
+	query string
+}{
+	{
+		desc:  prometheus.NewDesc(namespace+"_fleet_active_drivers", "Drivers that are not deleted.", nil, nil),
+		query: "SELECT count(*) FROM drivers WHERE deleted_at IS NULL",
+	},
+	{
+		desc:  prometheus.NewDesc(namespace+"_fleet_active_vehicles", "Vehicles that are not deleted.", nil, nil),
+		query: "SELECT count(*) FROM vehicles WHERE deleted_at IS NULL",
+	},
+	{
+		desc:  prometheus.NewDesc(namespace+"_fleet_current_assignments", "Driver to vehicle assignments still in force.", nil, nil),
+		query: "SELECT count(*) FROM drivers_vehicles WHERE unassigned_at IS NULL",
+	},
+}
+
+type fleetCollector struct {
+	db      *sqlx.DB
+	timeout time.Duration
+	// system counts as database.SystemRole, past the row-level security
+	// policies
+	system bool
+	logger *zap.SugaredLogger
+}
+
+// NewFleetCollector exports the size of the fleet. A count that fails is
+// logged and left out of the scrape rather than failing it. rowSecurity
+// tells it the database enforces row-level security.
+func NewFleetCollector(db *sqlx.DB, timeout time.Duration, rowSecurity bool, log *zap.SugaredLogger) prometheus.Collector {
+	return &fleetCollector{db: db, timeout: timeout, system: rowSecurity, logger: log}
+}
+
+func (c *fleetCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, g := range fleetGauges {
+		ch <- g.desc
+	}
+}
+
+func (c *fleetCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := database.WithQueryTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if c.system {
+		ctx = database.AsSystem(ctx)
+	}
+	for _, g := range fleetGauges {
+		var n int
+		if err := database.Executor(ctx, c.db).GetContext(ctx, &n, g.query); err != nil {
+			c.logger.Errorf("Failed Collect Fleet Metric %s", err.Error())
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, float64(n))
+	}
+}
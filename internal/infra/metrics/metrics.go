@@ -0,0 +1,119 @@
+// Package metrics collects the server's Prometheus metrics: HTTP traffic per
+// route, service calls, the database pool and the size of the fleet.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "goddd"
+
+// Outcomes of a service call besides the domain error kinds.
+const (
+	OutcomeOK    = "ok"
+	OutcomeError = "error"
+)
+
+// Metrics owns a registry of its own rather than the global one, so tests
+// and several servers in one process do not collide.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	calls           *prometheus.CounterVec
+	callDuration    *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests served, by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to serve HTTP requests, by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "calls_total",
+			Help:      "Service calls, by service, method and outcome.",
+		}, []string{"service", "method", "outcome"}),
+		callDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "call_duration_seconds",
+			Help:      "Time taken by service calls, by service and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.requestDuration, m.calls, m.callDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Register adds collectors, such as the pool and fleet collectors, to the
+// metrics served.
+func (m *Metrics) Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObserveRequest records a served request. route is the route pattern, not
+// the path, so ids in the path do not each get a series.
+func (m *Metrics) ObserveRequest(method, route string, status int, took time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(took.Seconds())
+}
+
+// ObserveCall records a service call and how it ended.
+func (m *Metrics) ObserveCall(service, method string, err error, took time.Duration) {
+	m.calls.WithLabelValues(service, method, Outcome(err)).Inc()
+	m.callDuration.WithLabelValues(service, method).Observe(took.Seconds())
+}
+
+// Outcome labels err by its domain error kind, keeping the label's values
+// few: "ok", a domainerr.Kind, or "error" for anything else.
+func Outcome(err error) string {
+	if err == nil {
+		return OutcomeOK
+	}
+	var derr *domainerr.Error
+	if errors.As(err, &derr) {
+		return string(derr.Kind)
+	}
+	return OutcomeError
+}
+
+// NewDBStatsCollector exports the sql.DBStats of db's connection pool.
+func NewDBStatsCollector(db *sql.DB) prometheus.Collector {
+	return collectors.NewDBStatsCollector(db, "postgres")
+}
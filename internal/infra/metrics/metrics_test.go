@@ -0,0 +1,36 @@
+package metrics_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moura95/go-ddd/internal/domain/driver"
+	"github.com/moura95/go-ddd/internal/infra/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcome(t *testing.T) {
+	assert.Equal(t, metrics.OutcomeOK, metrics.Outcome(nil))
+	assert.Equal(t, "not_found", metrics.Outcome(fmt.Errorf("failed to get driver %w", driver.ErrNotFound)))
+	assert.Equal(t, metrics.OutcomeError, metrics.Outcome(errors.New("connection refused")))
+}
+
+func TestHandlerExposesObservations(t *testing.T) {
+	m := metrics.New()
+	m.ObserveRequest(http.MethodGet, "/drivers/:uuid", http.StatusNotFound, 20*time.Millisecond)
+	m.ObserveCall("driver", "GetByID", driver.ErrNotFound, 10*time.Millisecond)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(w.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `goddd_http_requests_total{method="GET",route="/drivers/:uuid",status="404"} 1`)
+	assert.Contains(t, string(body), `goddd_http_request_duration_seconds_count{method="GET",route="/drivers/:uuid"} 1`)
+	assert.Contains(t, string(body), `goddd_service_calls_total{method="GetByID",outcome="not_found",service="driver"} 1`)
+	assert.Contains(t, string(body), `go_goroutines`)
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	dto "github.com/moura95/go-ddd/internal/dtos/event"
+)
+
+// Publisher delivers an outbox message to the outside world. A message is
+// only marked dispatched once every publisher accepted it, so Publish may be
+// called again for a message it already saw.
+type Publisher interface {
+	Publish(ctx context.Context, msg dto.Message) error
+}
+
+// Handler reacts to a published message.
+type Handler func(ctx context.Context, msg dto.Message) error
+
+// AllEvents subscribes a handler to every event name.
+const AllEvents = "*"
+
+// Bus is the in-process publisher: it calls the handlers subscribed to the
+// message's event name, or to AllEvents, in the relay goroutine.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+// Subscribe registers h for the event called name, or for every event when
+// name is AllEvents.
+func (b *Bus) Subscribe(name string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], h)
+}
+
+// Publish calls every matching handler and joins their errors.
+func (b *Bus) Publish(ctx context.Context, msg dto.Message) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[msg.Name]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moura95/go-ddd/internal/domain/event"
+	dto "github.com/moura95/go-ddd/internal/dtos/event"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+	maxRetryDelay       = 10 * time.Minute
+)
+
+// Relay moves committed events from the outbox to the publishers. Delivery is
+// at least once: a message is retried until every publisher accepts it.
+type Relay struct {
+	uow        database.UnitOfWork
+	repository event.IOutboxRepository
+	publishers []Publisher
+	interval   time.Duration
+	batchSize  int
+	logger     *zap.SugaredLogger
+}
+
+func NewRelay(uow database.UnitOfWork, repo event.IOutboxRepository, publishers []Publisher, cfg cfg.Config, log *zap.SugaredLogger) *Relay {
+	r := &Relay{
+		uow:        uow,
+		repository: repo,
+		publishers: publishers,
+		interval:   cfg.OutboxPollInterval,
+		batchSize:  cfg.OutboxBatchSize,
+		logger:     log,
+	}
+	if r.interval <= 0 {
+		r.interval = defaultPollInterval
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = defaultBatchSize
+	}
+	return r
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		// drain full batches before waiting for the next tick
+		for {
+			n, err := r.DispatchPending(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				r.logger.Errorf("Failed to dispatch outbox %s", err)
+			}
+			if err != nil || n < r.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending claims one batch of due messages and publishes them. Failed
+// messages are rescheduled with an exponential backoff. It returns how many
+// messages were claimed.
+func (r *Relay) DispatchPending(ctx context.Context) (int, error) {
+	claimed := 0
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		messages, err := r.repository.ClaimPending(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+		claimed = len(messages)
+		for _, msg := range messages {
+			if err := r.publish(ctx, msg); err != nil {
+				r.logger.Warnf("Failed to publish event %s %s attempt %d: %s", msg.Name, msg.Uuid, msg.Attempts+1, err)
+				retryAt := time.Now().Add(Backoff(msg.Attempts + 1))
+				if err := r.repository.MarkFailed(ctx, msg.Uuid, err.Error(), retryAt); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.repository.MarkDispatched(ctx, msg.Uuid, time.Now()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to dispatch outbox %w", err)
+	}
+	return claimed, nil
+}
+
+func (r *Relay) publish(ctx context.Context, msg dto.Message) error {
+	var errs []error
+	for _, p := range r.publishers {
+		if err := p.Publish(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Backoff is the delay before the given delivery attempt is retried: one
+// second doubled per attempt, capped at ten minutes.
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 20 {
+		return maxRetryDelay
+	}
+	d := time.Second << (attempt - 1)
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
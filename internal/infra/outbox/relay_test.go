@@ -0,0 +1,92 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	"github.com/moura95/go-ddd/internal/domain/event/memory"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	dto "github.com/moura95/go-ddd/internal/dtos/event"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/outbox"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newRelay(repo memory.IOutboxRepositoryMemory, publishers ...outbox.Publisher) *outbox.Relay {
+	uow := database.NewMemoryUnitOfWork(repo)
+	return outbox.NewRelay(uow, repo, publishers, cfg.Config{OutboxBatchSize: 10}, zap.NewNop().Sugar())
+}
+
+func TestRelayDispatchesToSubscribers(t *testing.T) {
+	repo := memory.NewOutboxRepositoryMemory()
+	uid := uuid.New()
+	assert.NoError(t, repo.Append(tenant.WithID(context.Background(), tenant.Default),
+		event.New("DriverCreated", "driver", uid, map[string]string{"name": "Driver 1"}),
+		event.New("VehicleCreated", "vehicle", uuid.New(), nil),
+	))
+
+	bus := outbox.NewBus()
+	var drivers, all []string
+	bus.Subscribe("DriverCreated", func(ctx context.Context, msg dto.Message) error {
+		drivers = append(drivers, msg.AggregateUUID.String())
+		assert.JSONEq(t, `{"name":"Driver 1"}`, string(msg.Payload))
+		return nil
+	})
+	bus.Subscribe(outbox.AllEvents, func(ctx context.Context, msg dto.Message) error {
+		all = append(all, msg.Name)
+		return nil
+	})
+
+	n, err := newRelay(repo, bus).DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{uid.String()}, drivers)
+	assert.Equal(t, []string{"DriverCreated", "VehicleCreated"}, all)
+
+	for _, msg := range repo.Messages() {
+		assert.True(t, msg.DispatchedAt.Valid)
+		assert.Equal(t, 1, msg.Attempts)
+	}
+
+	// dispatched messages are not claimed again
+	n, err = newRelay(repo, bus).DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestRelayReschedulesFailedMessages(t *testing.T) {
+	repo := memory.NewOutboxRepositoryMemory()
+	assert.NoError(t, repo.Append(tenant.WithID(context.Background(), tenant.Default), event.New("DriverCreated", "driver", uuid.New(), nil)))
+
+	bus := outbox.NewBus()
+	bus.Subscribe(outbox.AllEvents, func(ctx context.Context, msg dto.Message) error {
+		return errors.New("subscriber down")
+	})
+
+	n, err := newRelay(repo, bus).DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	msg := repo.Messages()[0]
+	assert.False(t, msg.DispatchedAt.Valid)
+	assert.Equal(t, 1, msg.Attempts)
+	assert.Equal(t, "subscriber down", msg.LastError.String)
+
+	// the retry waits for the backoff
+	n, err = newRelay(repo, bus).DispatchPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, outbox.Backoff(1))
+	assert.Equal(t, 8*time.Second, outbox.Backoff(4))
+	assert.Equal(t, 10*time.Minute, outbox.Backoff(15))
+	assert.Equal(t, 10*time.Minute, outbox.Backoff(100))
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+	period time.Duration
+}
+
+// memoryLimiter keeps the buckets in process, so every replica has a budget
+// of its own. Buckets that have refilled completely are dropped now and then
+// to keep the map small.
+type memoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	nextSweep time.Time
+}
+
+func NewMemoryLimiter() Limiter {
+	return newMemoryLimiter(time.Now)
+}
+
+func newMemoryLimiter(now func() time.Time) *memoryLimiter {
+	return &memoryLimiter{buckets: map[string]*bucket{}, now: now}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Limit), at: now}
+		l.buckets[key] = b
+	}
+	rate := float64(rule.Limit) / float64(rule.Period)
+	b.tokens = math.Min(float64(rule.Limit), b.tokens+float64(now.Sub(b.at))*rate)
+	b.at, b.period = now, rule.Period
+
+	return take(&b.tokens, rule, rate), nil
+}
+
+// take spends one of tokens when there is one. rate is in tokens per
+// nanosecond.
+func take(tokens *float64, rule Rule, rate float64) Result {
+	result := Result{Limit: rule.Limit}
+	if *tokens >= 1 {
+		*tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - *tokens) / rate))
+	}
+	result.Remaining = int(*tokens)
+	result.Reset = time.Duration(math.Ceil((float64(rule.Limit) - *tokens) / rate))
+	return result
+}
+
+func (l *memoryLimiter) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	l.nextSweep = now.Add(time.Minute)
+	for key, b := range l.buckets {
+		if now.Sub(b.at) >= b.period {
+			delete(l.buckets, key)
+		}
+	}
+}
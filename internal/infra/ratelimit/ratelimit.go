@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"go.uber.org/zap"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+
+	defaultLimit  = 4000
+	defaultPeriod = time.Minute
+	// redisTimeout bounds every Redis call, so an unreachable server costs a
+	// request little before the in-memory buckets take over.
+	redisTimeout = 250 * time.Millisecond
+)
+
+// Rule is a token bucket holding Limit tokens that refills completely over
+// Period. Every request takes one token.
+type Rule struct {
+	Limit  int
+	Period time.Duration
+}
+
+// Result is the outcome of taking a token. Reset is how long until the
+// bucket is full again and RetryAfter how long until the next token when the
+// request was not allowed.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Limiter takes one token from the bucket at key, creating it full.
+type Limiter interface {
+	Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// Policy picks the rule and the bucket for a request. Clients listed in
+// Clients get their own budget in place of the route and default limits;
+// otherwise a route listed in Routes has a budget per client of its own and
+// every other route shares the client's Default budget.
+type Policy struct {
+	Default Rule
+	Routes  map[string]Rule
+	Clients map[string]Rule
+}
+
+// Bucket returns the key and rule for a request from client to the route
+// pattern, e.g. "GET /vehicle/:uuid".
+func (p Policy) Bucket(route, client string) (string, Rule) {
+	if rule, ok := p.Clients[client]; ok {
+		return "client:" + client, rule
+	}
+	if rule, ok := p.Routes[route]; ok {
+		return "route:" + route + ":" + client, rule
+	}
+	return "default:" + client, p.Default
+}
+
+// NewPolicy reads the limits from the config. Routes and clients are given
+// as "<key>=<limit>/<period>" entries, e.g. "POST /driver=100/1m" or
+// "10.0.0.7=10000/1m".
+func NewPolicy(cfg cfg.Config) (Policy, error) {
+	policy := Policy{
+		Default: Rule{Limit: cfg.RateLimitRequests, Period: cfg.RateLimitPeriod},
+		Routes:  map[string]Rule{},
+		Clients: map[string]Rule{},
+	}
+	if policy.Default.Limit <= 0 {
+		policy.Default.Limit = defaultLimit
+	}
+	if policy.Default.Period <= 0 {
+		policy.Default.Period = defaultPeriod
+	}
+	if err := parseRules(cfg.RateLimitRoutes, policy.Routes); err != nil {
+		return Policy{}, fmt.Errorf("RATE_LIMIT_ROUTES: %w", err)
+	}
+	if err := parseRules(cfg.RateLimitClients, policy.Clients); err != nil {
+		return Policy{}, fmt.Errorf("RATE_LIMIT_CLIENTS: %w", err)
+	}
+	return policy, nil
+}
+
+func parseRules(entries []string, into map[string]Rule) error {
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return fmt.Errorf("entry %q is not <key>=<limit>/<period>", entry)
+		}
+		rule, err := ParseRule(entry[i+1:])
+		if err != nil {
+			return fmt.Errorf("entry %q: %w", entry, err)
+		}
+		into[strings.TrimSpace(entry[:i])] = rule
+	}
+	return nil
+}
+
+// ParseRule reads a rule written as "<limit>/<period>", e.g. "100/1m".
+func ParseRule(s string) (Rule, error) {
+	limit, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("rule %q is not <limit>/<period>", s)
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		return Rule{}, fmt.Errorf("rule %q has an invalid limit", s)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Rule{}, fmt.Errorf("rule %q has an invalid period", s)
+	}
+	return Rule{Limit: n, Period: d}, nil
+}
+
+// NewFromConfig returns the limiter named by RATE_LIMIT_BACKEND. The Redis
+// backend shares one budget between every replica and falls back to the
+// in-memory buckets while Redis is down.
+func NewFromConfig(cfg cfg.Config, log *zap.SugaredLogger) (Limiter, error) {
+	switch cfg.RateLimitBackend {
+	case "", BackendMemory:
+		return NewMemoryLimiter(), nil
+	case BackendRedis:
+		if cfg.RedisAddress == "" {
+			return nil, fmt.Errorf("RATE_LIMIT_BACKEND=redis needs REDIS_ADDRESS")
+		}
+		client := redis.NewClient(&redis.Options{
+			Addr:         cfg.RedisAddress,
+			Password:     cfg.RedisPassword,
+			DialTimeout:  redisTimeout,
+			ReadTimeout:  redisTimeout,
+			WriteTimeout: redisTimeout,
+			MaxRetries:   -1,
+		})
+		return NewRedisLimiter(client, NewMemoryLimiter(), log), nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_BACKEND %q", cfg.RateLimitBackend)
+	}
+}
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiterRefillsOverThePeriod(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	limiter := newMemoryLimiter(func() time.Time { return now })
+	rule := Rule{Limit: 2, Period: 10 * time.Second}
+
+	result, err := limiter.Allow(ctx, "a", rule)
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 5 * time.Second}, result)
+
+	result, _ = limiter.Allow(ctx, "a", rule)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, _ = limiter.Allow(ctx, "a", rule)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 5*time.Second, result.RetryAfter)
+	assert.Equal(t, 10*time.Second, result.Reset)
+
+	result, _ = limiter.Allow(ctx, "b", rule)
+	assert.True(t, result.Allowed, "buckets are per key")
+
+	now = now.Add(5 * time.Second)
+	result, _ = limiter.Allow(ctx, "a", rule)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryLimiterDropsRefilledBuckets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	limiter := newMemoryLimiter(func() time.Time { return now })
+	rule := Rule{Limit: 1, Period: time.Second}
+
+	_, _ = limiter.Allow(ctx, "a", rule)
+	now = now.Add(2 * time.Minute)
+	_, _ = limiter.Allow(ctx, "b", rule)
+
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("100/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 100, Period: time.Minute}, rule)
+
+	for _, s := range []string{"100", "0/1m", "x/1m", "10/0s", "10/soon"} {
+		_, err := ParseRule(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPolicyBucket(t *testing.T) {
+	policy, err := NewPolicy(cfg.Config{
+		RateLimitRoutes:  []string{"POST /driver=10/1m", " GET /vehicle/:uuid = 100/1m"},
+		RateLimitClients: []string{"10.0.0.7=10000/1m"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 4000, Period: time.Minute}, policy.Default)
+
+	key, rule := policy.Bucket("POST /driver", "10.0.0.1")
+	assert.Equal(t, "route:POST /driver:10.0.0.1", key)
+	assert.Equal(t, 10, rule.Limit)
+
+	key, rule = policy.Bucket("GET /vehicle/:uuid", "10.0.0.1")
+	assert.Equal(t, "route:GET /vehicle/:uuid:10.0.0.1", key)
+	assert.Equal(t, 100, rule.Limit)
+
+	key, rule = policy.Bucket("GET /vehicles", "10.0.0.1")
+	assert.Equal(t, "default:10.0.0.1", key)
+	assert.Equal(t, 4000, rule.Limit)
+
+	key, rule = policy.Bucket("POST /driver", "10.0.0.7")
+	assert.Equal(t, "client:10.0.0.7", key)
+	assert.Equal(t, 10000, rule.Limit)
+
+	_, err = NewPolicy(cfg.Config{RateLimitRoutes: []string{"POST /driver"}})
+	assert.Error(t, err)
+}
+
+func TestNewFromConfigRejectsUnknownBackends(t *testing.T) {
+	_, err := NewFromConfig(cfg.Config{RateLimitBackend: "memcached"}, nil)
+	assert.Error(t, err)
+	_, err = NewFromConfig(cfg.Config{RateLimitBackend: BackendRedis}, nil)
+	assert.Error(t, err)
+}
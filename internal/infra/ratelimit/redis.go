@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// keyPrefix keeps the buckets apart from anything else sharing the Redis
+// database.
+const keyPrefix = "go-ddd:ratelimit:"
+
+// retryRedisAfter is how long the in-memory buckets are used after Redis
+// failed before Redis is tried again.
+const retryRedisAfter = 5 * time.Second
+
+// tokenBucket refills and takes from the bucket in one step, so concurrent
+// requests from every replica see a consistent count. It uses the Redis
+// clock so replicas with skewed clocks agree on the refill.
+//
+// KEYS[1] bucket, ARGV[1] limit, ARGV[2] period in milliseconds.
+// Returns allowed, remaining, and reset and retry after in milliseconds.
+var tokenBucket = redis.NewScript(`
+redis.replicate_commands()
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'at')
+local tokens = tonumber(state[1])
+local at = tonumber(state[2])
+if tokens == nil or at == nil then
+	tokens = limit
+	at = now
+end
+
+local rate = limit / period
+tokens = math.min(limit, tokens + math.max(0, now - at) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], period)
+return {allowed, math.floor(tokens), math.ceil((limit - tokens) / rate), retry}
+`)
+
+type redisLimiter struct {
+	client   *redis.Client
+	fallback Limiter
+	logger   *zap.SugaredLogger
+
+	mu        sync.Mutex
+	downUntil time.Time
+}
+
+// NewRedisLimiter keeps the buckets in Redis and uses fallback while Redis
+// is failing, so an outage relaxes the limits to per replica rather than
+// rejecting or letting through everything.
+func NewRedisLimiter(client *redis.Client, fallback Limiter, log *zap.SugaredLogger) Limiter {
+	return &redisLimiter{client: client, fallback: fallback, logger: log}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	if !l.redisUp() {
+		return l.fallback.Allow(ctx, key, rule)
+	}
+	result, err := l.allow(ctx, key, rule)
+	if err != nil {
+		l.markDown(err)
+		return l.fallback.Allow(ctx, key, rule)
+	}
+	return result, nil
+}
+
+func (l *redisLimiter) allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	period := rule.Period.Milliseconds()
+	if period < 1 {
+		period = 1
+	}
+	reply, err := tokenBucket.Run(ctx, l.client, []string{keyPrefix + key}, rule.Limit, period).Result()
+	if err != nil {
+		return Result{}, err
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 4 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	n := make([]int64, len(values))
+	for i, v := range values {
+		if n[i], ok = v.(int64); !ok {
+			return Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+		}
+	}
+	return Result{
+		Allowed:    n[0] == 1,
+		Limit:      rule.Limit,
+		Remaining:  int(n[1]),
+		Reset:      time.Duration(n[2]) * time.Millisecond,
+		RetryAfter: time.Duration(n[3]) * time.Millisecond,
+	}, nil
+}
+
+func (l *redisLimiter) redisUp() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Now().After(l.downUntil)
+}
+
+func (l *redisLimiter) markDown(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if time.Now().After(l.downUntil) {
+		l.logger.Warnf("Rate limit backend unavailable, using in-process buckets for %s: %s", retryRedisAfter, err)
+	}
+	l.downUntil = time.Now().Add(retryRedisAfter)
+}
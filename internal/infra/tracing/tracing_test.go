@@ -0,0 +1,37 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/tracing"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetup(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), cfg.Config{TracingExporter: tracing.ExporterOff})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = tracing.Setup(context.Background(), cfg.Config{TracingExporter: "jaeger"})
+	assert.Error(t, err)
+}
+
+func TestLoggerAddsTraceIDs(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	log := zap.New(core).Sugar()
+
+	tracing.Logger(context.Background(), log).Infof("no span")
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	tracing.Logger(ctx, log).Infof("in span")
+
+	entries := logs.All()
+	assert.Empty(t, entries[0].ContextMap())
+	assert.Equal(t, span.SpanContext().TraceID().String(), entries[1].ContextMap()["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), entries[1].ContextMap()["span_id"])
+}
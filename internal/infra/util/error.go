@@ -1,12 +1,24 @@
 package util
 
-import "errors"
+// ErrorBody is the structured payload carried in Response.Error.
+type ErrorBody struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError points a client at the input field that was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
 
 var (
-	ErrorBadRequest     = errors.New("Bad Request: One or more fields are invalid")
-	ErrorBadRequestUuid = errors.New("Bad Request!! Uuid: Invalid")
-	ErrorDatabaseCreate = errors.New("Error creating record")
-	ErrorDatabaseRead   = errors.New("Error reading record")
-	ErrorDatabaseUpdate = errors.New("Error updating record")
-	ErrorDatabaseDelete = errors.New("Error deleting record")
+	ErrorBadRequest     = ErrorBody{Code: "bad_request", Message: "One or more fields are invalid"}
+	ErrorBadRequestUuid = ErrorBody{Code: "invalid_uuid", Message: "Uuid: Invalid"}
+	ErrorInternal       = ErrorBody{Code: "internal_error", Message: "Internal server error"}
+	ErrorTimeout        = ErrorBody{Code: "timeout", Message: "The request took too long to complete"}
+	ErrorMediaType      = ErrorBody{Code: "unsupported_media_type", Message: "Content-Type is not supported"}
+	ErrorRateLimited    = ErrorBody{Code: "rate_limited", Message: "Too many requests, retry later"}
 )
@@ -3,6 +3,14 @@ package util
 type Response struct {
 	Error interface{} `json:"error"`
 	Data  interface{} `json:"data"`
+	Meta  *Pagination `json:"meta,omitempty"`
+}
+
+type Pagination struct {
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
 }
 
 func SuccessResponse(data interface{}) Response {
@@ -12,9 +20,26 @@ func SuccessResponse(data interface{}) Response {
 	}
 }
 
-func ErrorResponse(error string) Response {
+func PaginatedResponse(data interface{}, page, pageSize, total int) Response {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return Response{
+		Data:  data,
+		Error: "",
+		Meta: &Pagination{
+			Page:       page,
+			PageSize:   pageSize,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+func ErrorResponse(body ErrorBody) Response {
 	return Response{
 		Data:  "",
-		Error: error,
+		Error: body,
 	}
 }
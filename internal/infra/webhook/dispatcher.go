@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	domain "github.com/moura95/go-ddd/internal/domain/webhook"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 20
+	defaultMaxAttempts  = 8
+	defaultTimeout      = 10 * time.Second
+	// errorBodyLimit caps how much of a failed response is kept in the log.
+	errorBodyLimit = 512
+)
+
+// Dispatcher POSTs queued deliveries to their subscriptions, outside of any
+// transaction, and records the outcome of every attempt.
+type Dispatcher struct {
+	repository  domain.IWebhookRepository
+	client      *http.Client
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	logger      *zap.SugaredLogger
+}
+
+// NewDispatcher uses client to send deliveries; a nil client gets one with
+// the configured WEBHOOK_TIMEOUT.
+func NewDispatcher(repo domain.IWebhookRepository, client *http.Client, cfg cfg.Config, log *zap.SugaredLogger) *Dispatcher {
+	d := &Dispatcher{
+		repository:  repo,
+		client:      client,
+		interval:    cfg.WebhookPollInterval,
+		batchSize:   defaultBatchSize,
+		maxAttempts: cfg.WebhookMaxAttempts,
+		logger:      log,
+	}
+	if d.interval <= 0 {
+		d.interval = defaultPollInterval
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultMaxAttempts
+	}
+	if d.client == nil {
+		timeout := cfg.WebhookTimeout
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		d.client = &http.Client{Timeout: timeout}
+	}
+	return d
+}
+
+// Run sends due deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := d.DeliverDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				d.logger.Errorf("Failed to deliver webhooks %s", err)
+			}
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends one batch of due deliveries and returns how many were
+// attempted.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	// the lease covers sending the whole batch one request at a time, so a
+	// crash mid-batch only delays it
+	lease := now.Add(time.Duration(d.batchSize)*d.client.Timeout + time.Minute)
+	claimed, err := d.repository.ClaimDueDeliveries(ctx, now, d.batchSize, lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries %w", err)
+	}
+
+	subscriptions := map[uuid.UUID]*dto.Output{}
+	for i, out := range claimed {
+		// the stored lease, as the database rounded it, identifies our claim
+		leaseUntil := out.NextAttemptAt
+		if time.Now().Add(d.client.Timeout).After(leaseUntil) {
+			// another dispatcher may take it over mid-request; leave the rest
+			// for when the lease is up
+			d.logger.Warnf("Webhook lease running out, leaving %d claimed deliveries", len(claimed)-i)
+			return i, nil
+		}
+		delivery := domain.DeliveryFrom(out)
+		s, ok := subscriptions[delivery.SubscriptionUUID]
+		if !ok {
+			s, err = d.repository.GetByID(tenant.WithID(ctx, delivery.TenantID), delivery.SubscriptionUUID)
+			if errors.Is(err, domain.ErrNotFound) {
+				// deleted after the claim; its deliveries went with it
+				continue
+			}
+			if err != nil {
+				return 0, fmt.Errorf("failed to load webhook %w", err)
+			}
+			subscriptions[delivery.SubscriptionUUID] = s
+		}
+
+		code, err := d.send(ctx, s, delivery)
+		if err != nil {
+			delivery.Fail(code, err.Error(), time.Now(), d.maxAttempts)
+			d.logger.Warnf("Failed Webhook Delivery %s to %s attempt %d: %s", delivery.Uuid, s.URL, delivery.Attempts, err)
+		} else {
+			delivery.Succeed(code, time.Now())
+		}
+		err = d.repository.SaveClaimedDelivery(ctx, delivery, leaseUntil)
+		if errors.Is(err, domain.ErrLeaseLost) {
+			// the lease ran out while sending and another dispatcher owns
+			// the delivery now; its outcome wins
+			d.logger.Warnf("Webhook Delivery %s was claimed again while sending, dropping this attempt", delivery.Uuid)
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to save webhook delivery %w", err)
+		}
+	}
+	return len(claimed), nil
+}
+
+// send POSTs the delivery and returns the response status, or 0 when the
+// receiver could not be reached. Any non-2xx answer is a failure.
+func (d *Dispatcher) send(ctx context.Context, s *dto.Output, delivery *domain.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(domain.EventHeader, delivery.EventName)
+	req.Header.Set(domain.DeliveryHeader, delivery.Uuid.String())
+	req.Header.Set(domain.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(domain.SignatureHeader, domain.Sign(s.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return resp.StatusCode, nil
+}
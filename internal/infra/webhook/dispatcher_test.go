@@ -0,0 +1,250 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	eventmemory "github.com/moura95/go-ddd/internal/domain/event/memory"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	domain "github.com/moura95/go-ddd/internal/domain/webhook"
+	"github.com/moura95/go-ddd/internal/domain/webhook/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/outbox"
+	"github.com/moura95/go-ddd/internal/infra/webhook"
+	service "github.com/moura95/go-ddd/internal/service/webhook"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// receiver is a local webhook endpoint that checks signatures and answers
+// with the queued status codes, then 204. onRequest, when set, runs before
+// each answer.
+type receiver struct {
+	mu        sync.Mutex
+	secret    string
+	statuses  []int
+	received  []map[string]interface{}
+	verified  []bool
+	onRequest func()
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.onRequest != nil {
+		r.onRequest()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, _ := io.ReadAll(req.Body)
+	ts, _ := strconv.ParseInt(req.Header.Get(domain.TimestampHeader), 10, 64)
+	r.verified = append(r.verified, domain.Verify(r.secret, ts, body, req.Header.Get(domain.SignatureHeader)))
+
+	var payload map[string]interface{}
+	_ = json.Unmarshal(body, &payload)
+	r.received = append(r.received, payload)
+
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// inTenant is the context of the default tenant's calls.
+var inTenant = tenant.WithID(context.Background(), tenant.Default)
+
+type fixture struct {
+	outbox   eventmemory.IOutboxRepositoryMemory
+	repo     memory.IWebhookRepositoryMemory
+	service  service.IWebhookService
+	relay    *outbox.Relay
+	receiver *receiver
+	server   *httptest.Server
+}
+
+func newFixture(t *testing.T, maxAttempts int) *fixture {
+	f := &fixture{
+		outbox:   eventmemory.NewOutboxRepositoryMemory(),
+		repo:     memory.NewWebhookRepositoryMemory(),
+		receiver: &receiver{secret: "whsec_0123456789abcdef"},
+	}
+	f.server = httptest.NewServer(f.receiver)
+	t.Cleanup(f.server.Close)
+
+	config := cfg.Config{WebhookMaxAttempts: maxAttempts}
+	uow := database.NewMemoryUnitOfWork(f.outbox, f.repo)
+	f.service = service.NewWebhookService(nil, uow, f.repo, config, zap.NewNop().Sugar())
+
+	bus := outbox.NewBus()
+	bus.Subscribe(outbox.AllEvents, f.service.Enqueue)
+	f.relay = outbox.NewRelay(uow, f.outbox, []outbox.Publisher{bus}, config, zap.NewNop().Sugar())
+	return f
+}
+
+func (f *fixture) dispatcher(maxAttempts int) *webhook.Dispatcher {
+	return webhook.NewDispatcher(f.repo, f.server.Client(), cfg.Config{WebhookMaxAttempts: maxAttempts}, zap.NewNop().Sugar())
+}
+
+// publish raises an event through the outbox and relays it to the webhooks.
+func (f *fixture) publish(t *testing.T, name string) uuid.UUID {
+	e := event.New(name, aggregate.DriverAggregateType, uuid.New(), map[string]string{"name": "Driver 1"})
+	assert.NoError(t, f.outbox.Append(inTenant, e))
+	_, err := f.relay.DispatchPending(context.Background())
+	assert.NoError(t, err)
+	return e.Uuid
+}
+
+func TestDeliversSignedEventsToMatchingSubscriptions(t *testing.T) {
+	f := newFixture(t, 3)
+	s, err := f.service.Create(inTenant, dto.CreateInput{
+		URL:    f.server.URL,
+		Secret: f.receiver.secret,
+		Events: []string{aggregate.EventDriverCreated},
+	})
+	assert.NoError(t, err)
+
+	eventUUID := f.publish(t, aggregate.EventDriverCreated)
+	f.publish(t, aggregate.EventDriverDeleted) // filtered out
+
+	n, err := f.dispatcher(3).DeliverDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	assert.Equal(t, []bool{true}, f.receiver.verified)
+	assert.Equal(t, eventUUID.String(), f.receiver.received[0]["id"])
+	assert.Equal(t, aggregate.EventDriverCreated, f.receiver.received[0]["event"])
+	assert.Equal(t, map[string]interface{}{"name": "Driver 1"}, f.receiver.received[0]["data"])
+
+	deliveries, total, err := f.service.ListDeliveries(inTenant, s.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, string(domain.StatusSucceeded), deliveries[0].Status)
+	assert.Equal(t, int64(http.StatusNoContent), deliveries[0].LastStatusCode.Int64)
+	assert.True(t, deliveries[0].DeliveredAt.Valid)
+}
+
+func TestFailedDeliveriesRetryThenDeadLetter(t *testing.T) {
+	f := newFixture(t, 2)
+	f.receiver.statuses = []int{http.StatusInternalServerError, http.StatusBadGateway}
+	s, err := f.service.Create(inTenant, dto.CreateInput{
+		URL:    f.server.URL,
+		Secret: f.receiver.secret,
+		Events: []string{domain.AllEvents},
+	})
+	assert.NoError(t, err)
+	f.publish(t, aggregate.EventDriverCreated)
+
+	_, err = f.dispatcher(2).DeliverDue(context.Background())
+	assert.NoError(t, err)
+	deliveries, _, err := f.service.ListDeliveries(inTenant, s.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	failed := deliveries[0]
+	assert.Equal(t, string(domain.StatusFailed), failed.Status)
+	assert.Equal(t, int64(http.StatusInternalServerError), failed.LastStatusCode.Int64)
+
+	// not due until the backoff elapsed
+	n, err := f.dispatcher(2).DeliverDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	d := domain.DeliveryFrom(failed)
+	d.NextAttemptAt = d.CreatedAt
+	assert.NoError(t, f.repo.SaveDelivery(context.Background(), d))
+	_, err = f.dispatcher(2).DeliverDue(context.Background())
+	assert.NoError(t, err)
+
+	dead, _, err := f.service.ListDeliveries(inTenant, s.Uuid, dto.DeliveryListInput{Status: string(domain.StatusDead)})
+	assert.NoError(t, err)
+	assert.Len(t, dead, 1)
+	assert.Equal(t, 2, dead[0].Attempts)
+
+	// a dead letter can be sent again by hand
+	_, err = f.service.Redeliver(inTenant, s.Uuid, dead[0].Uuid)
+	assert.NoError(t, err)
+	_, err = f.dispatcher(2).DeliverDue(context.Background())
+	assert.NoError(t, err)
+
+	got, _, err := f.service.ListDeliveries(inTenant, s.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, string(domain.StatusSucceeded), got[0].Status)
+	assert.Len(t, f.receiver.received, 3)
+}
+
+func TestExpiredLeaseDoesNotOverwriteTheNewClaim(t *testing.T) {
+	f := newFixture(t, 3)
+	s, err := f.service.Create(inTenant, dto.CreateInput{URL: f.server.URL, Events: []string{domain.AllEvents}})
+	assert.NoError(t, err)
+	f.publish(t, aggregate.EventDriverCreated)
+
+	// another dispatcher claims the delivery again while it is being sent,
+	// as if the lease had run out
+	takeover := time.Now().Add(2 * time.Hour)
+	f.receiver.onRequest = func() {
+		reclaimed, err := f.repo.ClaimDueDeliveries(context.Background(), time.Now().Add(time.Hour), 10, takeover)
+		assert.NoError(t, err)
+		assert.Len(t, reclaimed, 1)
+	}
+
+	n, err := f.dispatcher(3).DeliverDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	deliveries, _, err := f.service.ListDeliveries(inTenant, s.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, string(domain.StatusPending), deliveries[0].Status, "the stale attempt is not recorded")
+	assert.True(t, deliveries[0].NextAttemptAt.Equal(takeover))
+}
+
+func TestRepublishedEventsAreQueuedOnce(t *testing.T) {
+	f := newFixture(t, 3)
+	s, err := f.service.Create(inTenant, dto.CreateInput{URL: f.server.URL, Events: []string{domain.AllEvents}})
+	assert.NoError(t, err)
+
+	e := event.New(aggregate.EventDriverCreated, aggregate.DriverAggregateType, uuid.New(), nil)
+	assert.NoError(t, f.outbox.Append(inTenant, e))
+	msg := f.outbox.Messages()[0]
+	// the relay publishes again when it fails after a publisher succeeded
+	assert.NoError(t, f.service.Enqueue(inTenant, msg))
+	assert.NoError(t, f.service.Enqueue(inTenant, msg))
+
+	_, total, err := f.service.ListDeliveries(inTenant, s.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestEventsReachOnlyTheirTenantsSubscriptions(t *testing.T) {
+	f := newFixture(t, 3)
+	other := tenant.WithID(context.Background(), uuid.New())
+	mine, err := f.service.Create(inTenant, dto.CreateInput{URL: f.server.URL, Events: []string{domain.AllEvents}})
+	assert.NoError(t, err)
+	theirs, err := f.service.Create(other, dto.CreateInput{URL: f.server.URL, Events: []string{domain.AllEvents}})
+	assert.NoError(t, err)
+
+	f.publish(t, aggregate.EventDriverCreated)
+
+	_, total, err := f.service.ListDeliveries(inTenant, mine.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	_, total, err = f.service.ListDeliveries(other, theirs.Uuid, dto.DeliveryListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	// another tenant's subscriptions are not found, let alone listed
+	_, err = f.service.GetByID(other, mine.Uuid)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	listed, _, err := f.service.List(other, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, theirs.Uuid, listed[0].Uuid)
+}
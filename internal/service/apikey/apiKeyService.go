@@ -0,0 +1,279 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	domain "github.com/moura95/go-ddd/internal/domain/apikey"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"go.uber.org/zap"
+)
+
+const defaultKeyTTL = 90 * 24 * time.Hour
+
+// lastUsedResolution spares a write per request: a key's last use is only
+// recorded again once it is this old.
+const lastUsedResolution = time.Minute
+
+// IAPIKeyService issues and checks API keys. Every call but Authenticate
+// requires the caller on ctx to hold apikey:manage and reaches only the keys
+// of the tenant on ctx.
+type IAPIKeyService interface {
+	// Create issues a key; the caller must hold every scope it grants.
+	Create(ctx context.Context, input dto.CreateInput) (*dto.Created, error)
+	List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+	// Rotate revokes the key and issues its successor with the same name,
+	// scopes and lifetime.
+	Rotate(ctx context.Context, uid uuid.UUID) (*dto.Created, error)
+	Revoke(ctx context.Context, uid uuid.UUID) error
+	// Authenticate returns the claims of the caller presenting key, or
+	// domain.ErrInvalidKey. A key acts for its creator: it stops working
+	// once they are deactivated and its scopes shrink with their roles.
+	Authenticate(ctx context.Context, key string) (*auth.Claims, error)
+}
+
+type apiKeyService struct {
+	database   *sqlx.DB
+	uow        database.UnitOfWork
+	repository domain.IAPIKeyRepository
+	users      user.IUserRepository
+	keyTTL     time.Duration
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewAPIKeyService(db *sqlx.DB, uow database.UnitOfWork, repo domain.IAPIKeyRepository, users user.IUserRepository, cfg cfg.Config, log *zap.SugaredLogger) *apiKeyService {
+	keyTTL := cfg.AuthAPIKeyTTL
+	if keyTTL <= 0 {
+		keyTTL = defaultKeyTTL
+	}
+	return &apiKeyService{
+		database:   db,
+		uow:        uow,
+		repository: repo,
+		users:      users,
+		keyTTL:     keyTTL,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, input dto.CreateInput) (*dto.Created, error) {
+	if err := auth.Require(ctx, user.PermAPIKeyManage); err != nil {
+		return nil, fmt.Errorf("failed to create api key %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key %w", err)
+	}
+	expiresAt := input.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(s.keyTTL)
+	}
+	k, plain, err := domain.New(input.Name, input.Scopes, expiresAt, caller(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key %w", err)
+	}
+	k.TenantID = tenantID
+	for _, scope := range k.Scopes {
+		if err := auth.Require(ctx, scope); err != nil {
+			return nil, fmt.Errorf("failed to create api key %w", domain.ErrScopeNotHeld)
+		}
+	}
+	if err := s.repository.Create(ctx, k); err != nil {
+		return nil, fmt.Errorf("failed to create api key %w", err)
+	}
+	return &dto.Created{Output: *newOutput(k), Key: plain}, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if err := auth.Require(ctx, user.PermAPIKeyManage); err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list api keys %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list api keys %w", err)
+	}
+	filter.Normalize()
+	filter.TenantID = tenantID
+	keys, total, err := s.repository.GetAll(ctx, filter)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list api keys %w", err)
+	}
+	out := make([]dto.Output, 0, len(keys))
+	for i := range keys {
+		out = append(out, *newOutput(&keys[i]))
+	}
+	return out, total, nil
+}
+
+func (s *apiKeyService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAPIKeyManage); err != nil {
+		return nil, fmt.Errorf("failed to get api key %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	k, err := s.getInTenant(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key %w", err)
+	}
+	return newOutput(k), nil
+}
+
+func (s *apiKeyService) Rotate(ctx context.Context, uid uuid.UUID) (*dto.Created, error) {
+	if err := auth.Require(ctx, user.PermAPIKeyManage); err != nil {
+		return nil, fmt.Errorf("failed to rotate api key %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	var created *dto.Created
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := s.getInTenant(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if err := s.repository.Revoke(ctx, current.Uuid, time.Now()); err != nil {
+			return err
+		}
+		next, plain, err := current.Rotate(caller(ctx))
+		if err != nil {
+			return err
+		}
+		if err := s.repository.Create(ctx, next); err != nil {
+			return err
+		}
+		created = &dto.Created{Output: *newOutput(next), Key: plain}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate api key %w", err)
+	}
+	return created, nil
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, uid uuid.UUID) error {
+	if err := auth.Require(ctx, user.PermAPIKeyManage); err != nil {
+		return fmt.Errorf("failed to revoke api key %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	if _, err := s.getInTenant(ctx, uid); err != nil {
+		return fmt.Errorf("failed to revoke api key %w", err)
+	}
+	if err := s.repository.Revoke(ctx, uid, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke api key %w", err)
+	}
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*auth.Claims, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	k, err := s.repository.GetByHash(ctx, domain.Hash(key))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate api key %w", err)
+	}
+	now := time.Now()
+	if k.Revoked() || k.Expired(now) {
+		return nil, domain.ErrInvalidKey
+	}
+	creator, err := s.users.GetByID(ctx, k.CreatedBy)
+	if errors.Is(err, user.ErrNotFound) {
+		return nil, domain.ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate api key %w", err)
+	}
+	if !creator.Active || creator.TenantID != k.TenantID {
+		return nil, domain.ErrInvalidKey
+	}
+	// the creator held every scope when the key was issued, but may have
+	// lost roles since
+	scopes := make([]user.Permission, 0, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		if creator.Can(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if now.Sub(k.LastUsedAt) >= lastUsedResolution {
+		// a failed bookkeeping write must not fail the request
+		if err := s.repository.TouchLastUsed(ctx, k.Uuid, now); err != nil {
+			logging.FromContext(ctx, s.logger).Errorf("Failed Touch API Key %s", err.Error())
+		}
+	}
+	return &auth.Claims{
+		UserUUID:   k.CreatedBy,
+		TenantUUID: k.TenantID,
+		ExpiresAt:  k.ExpiresAt,
+		APIKeyUUID: k.Uuid,
+		Scopes:     scopes,
+	}, nil
+}
+
+// getInTenant loads the key, hiding keys of other tenants behind
+// ErrNotFound.
+func (s *apiKeyService) getInTenant(ctx context.Context, uid uuid.UUID) (*domain.APIKey, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	k, err := s.repository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if k.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	return k, nil
+}
+
+// caller is the user on ctx, who becomes the creator of a key.
+func caller(ctx context.Context) uuid.UUID {
+	if claims, ok := auth.ClaimsFrom(ctx); ok {
+		return claims.UserUUID
+	}
+	return uuid.Nil
+}
+
+func newOutput(k *domain.APIKey) *dto.Output {
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+	return &dto.Output{
+		Uuid:       k.Uuid,
+		TenantID:   k.TenantID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  k.CreatedBy,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
@@ -0,0 +1,140 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/apikey"
+	"github.com/moura95/go-ddd/internal/domain/apikey/memory"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	usermemory "github.com/moura95/go-ddd/internal/domain/user/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/apikey"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/service/apikey"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var adminUUID = uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
+
+func newAPIKeyService() (apikey.IAPIKeyService, context.Context, user.IUserRepository) {
+	repo := memory.NewAPIKeyRepositoryMemory()
+	users := usermemory.NewUserRepositoryMemory()
+	_ = users.Create(context.Background(), &user.User{Uuid: adminUUID, TenantID: tenant.Default, Email: "admin@example.com", Roles: []user.Role{user.RoleAdmin}, Active: true})
+	service := apikey.NewAPIKeyService(nil, database.NewMemoryUnitOfWork(repo), repo, users, cfg.Config{AuthAPIKeyTTL: time.Hour}, zap.NewNop().Sugar())
+	admin := auth.WithClaims(context.Background(), &auth.Claims{UserUUID: adminUUID, TenantUUID: tenant.Default, Roles: []user.Role{user.RoleAdmin}})
+	return service, tenant.WithID(admin, tenant.Default), users
+}
+
+func TestCreateAndAuthenticate(t *testing.T) {
+	service, admin, _ := newAPIKeyService()
+
+	created, err := service.Create(admin, dto.CreateInput{Name: "telematics", Scopes: []string{"driver:read"}})
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), created.ExpiresAt, time.Second)
+	assert.Equal(t, adminUUID, created.CreatedBy)
+
+	claims, err := service.Authenticate(context.Background(), created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, created.Uuid, claims.APIKeyUUID)
+	assert.Equal(t, adminUUID, claims.UserUUID)
+	assert.Equal(t, tenant.Default, claims.TenantUUID)
+	assert.True(t, claims.Can(user.PermDriverRead))
+	assert.False(t, claims.Can(user.PermDriverWrite))
+
+	out, err := service.GetByID(admin, created.Uuid)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), out.LastUsedAt, time.Second)
+
+	_, err = service.Authenticate(context.Background(), created.Key+"x")
+	assert.ErrorIs(t, err, domain.ErrInvalidKey)
+}
+
+func TestCreateNeedsTheScopesItGrants(t *testing.T) {
+	service, _, _ := newAPIKeyService()
+	dispatcher := auth.WithClaims(context.Background(), &auth.Claims{UserUUID: uuid.New(), Roles: []user.Role{user.RoleDispatcher}})
+	_, err := service.Create(dispatcher, dto.CreateInput{Name: "erp", Scopes: []string{"driver:read"}})
+	assert.ErrorIs(t, err, user.ErrForbidden)
+
+	// an API key managing keys cannot mint one with more than its scopes
+	key := tenant.WithID(auth.WithClaims(context.Background(), &auth.Claims{
+		APIKeyUUID: uuid.New(),
+		TenantUUID: tenant.Default,
+		Scopes:     []user.Permission{user.PermAPIKeyManage, user.PermDriverRead},
+	}), tenant.Default)
+	_, err = service.Create(key, dto.CreateInput{Name: "erp", Scopes: []string{"driver:hard_delete"}})
+	assert.ErrorIs(t, err, domain.ErrScopeNotHeld)
+	_, err = service.Create(key, dto.CreateInput{Name: "erp", Scopes: []string{"driver:read"}})
+	assert.NoError(t, err)
+}
+
+func TestRotateAndRevoke(t *testing.T) {
+	service, admin, _ := newAPIKeyService()
+	created, err := service.Create(admin, dto.CreateInput{Name: "erp", Scopes: []string{"vehicle:read"}})
+	assert.NoError(t, err)
+
+	rotated, err := service.Rotate(admin, created.Uuid)
+	assert.NoError(t, err)
+	assert.Equal(t, created.Name, rotated.Name)
+	_, err = service.Authenticate(context.Background(), created.Key)
+	assert.ErrorIs(t, err, domain.ErrInvalidKey)
+	_, err = service.Authenticate(context.Background(), rotated.Key)
+	assert.NoError(t, err)
+	_, err = service.Rotate(admin, created.Uuid)
+	assert.ErrorIs(t, err, domain.ErrRevoked)
+
+	assert.NoError(t, service.Revoke(admin, rotated.Uuid))
+	_, err = service.Authenticate(context.Background(), rotated.Key)
+	assert.ErrorIs(t, err, domain.ErrInvalidKey)
+	assert.ErrorIs(t, service.Revoke(admin, rotated.Uuid), domain.ErrRevoked)
+	assert.ErrorIs(t, service.Revoke(admin, uuid.New()), domain.ErrNotFound)
+
+	keys, total, err := service.List(admin, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, rotated.Uuid, keys[0].Uuid)
+}
+
+func TestKeysStayInTheirTenant(t *testing.T) {
+	service, admin, _ := newAPIKeyService()
+	created, err := service.Create(admin, dto.CreateInput{Name: "erp", Scopes: []string{"vehicle:read"}})
+	assert.NoError(t, err)
+
+	otherTenant := uuid.New()
+	other := tenant.WithID(auth.WithClaims(context.Background(), &auth.Claims{UserUUID: uuid.New(), TenantUUID: otherTenant, Roles: []user.Role{user.RoleAdmin}}), otherTenant)
+	_, err = service.GetByID(other, created.Uuid)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, service.Revoke(other, created.Uuid), domain.ErrNotFound)
+	_, total, err := service.List(other, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestKeysActForTheirCreator(t *testing.T) {
+	service, admin, users := newAPIKeyService()
+	created, err := service.Create(admin, dto.CreateInput{Name: "erp", Scopes: []string{"driver:read", "driver:write"}})
+	assert.NoError(t, err)
+
+	// demoted to viewer, the admin's key keeps only what viewers may do
+	assert.NoError(t, users.RemoveRole(context.Background(), adminUUID, user.RoleAdmin))
+	assert.NoError(t, users.AddRole(context.Background(), adminUUID, user.RoleViewer, adminUUID.String()))
+	claims, err := service.Authenticate(context.Background(), created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, []user.Permission{user.PermDriverRead}, claims.Scopes)
+
+	// keys of someone who is gone or inactive stop working
+	inactive := uuid.New()
+	assert.NoError(t, users.Create(context.Background(), &user.User{Uuid: inactive, TenantID: tenant.Default, Email: "gone@example.com", Roles: []user.Role{user.RoleAdmin}}))
+	for _, creator := range []uuid.UUID{inactive, uuid.New()} {
+		ctx := tenant.WithID(auth.WithClaims(context.Background(), &auth.Claims{UserUUID: creator, TenantUUID: tenant.Default, Roles: []user.Role{user.RoleAdmin}}), tenant.Default)
+		created, err := service.Create(ctx, dto.CreateInput{Name: "erp", Scopes: []string{"driver:read"}})
+		assert.NoError(t, err)
+		_, err = service.Authenticate(context.Background(), created.Key)
+		assert.ErrorIs(t, err, domain.ErrInvalidKey)
+	}
+}
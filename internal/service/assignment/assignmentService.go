@@ -0,0 +1,191 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	domain "github.com/moura95/go-ddd/internal/domain/assignment"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type IAssignmentService interface {
+	Assign(ctx context.Context, input dto.CreateInput) (*dto.Output, error)
+	Unassign(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+	UnassignPair(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+	ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]dto.Output, error)
+	ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]dto.Output, error)
+}
+
+type assignmentService struct {
+	database   *sqlx.DB
+	uow        database.UnitOfWork
+	repository domain.IAssignmentRepository
+	outbox     event.IOutboxRepository
+	rules      domain.Rules
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewAssignmentService(db *sqlx.DB, uow database.UnitOfWork, repo domain.IAssignmentRepository, outbox event.IOutboxRepository, cfg cfg.Config, log *zap.SugaredLogger) *assignmentService {
+	return &assignmentService{
+		database:   db,
+		uow:        uow,
+		repository: repo,
+		outbox:     outbox,
+		rules: domain.Rules{
+			SinglePrimaryDriver:    cfg.AssignmentSinglePrimaryDriver,
+			RejectDeleted:          cfg.AssignmentRejectDeleted,
+			RequireLicenseCategory: cfg.AssignmentRequireLicenseCategory,
+		},
+		config: cfg,
+		logger: log,
+	}
+}
+
+func (a *assignmentService) Assign(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	var out *dto.Output
+	err := a.uow.Do(ctx, func(ctx context.Context) error {
+		if err := a.checkRules(ctx, input); err != nil {
+			return err
+		}
+		var err error
+		if out, err = a.repository.Create(ctx, input); err != nil {
+			return err
+		}
+		return a.raise(ctx, out, domain.EventDriverAssigned)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign driver %w", err)
+	}
+	return out, nil
+}
+
+// checkRules loads the driver, the vehicle and its current primary driver and
+// checks the proposed assignment against the configured rules. Loading the
+// vehicle locks it, so a concurrent assignment to it waits for this one to
+// commit and then sees its primary driver.
+func (a *assignmentService) checkRules(ctx context.Context, input dto.CreateInput) error {
+	dr, err := a.repository.GetDriver(ctx, input.DriverUUID)
+	if err != nil {
+		return err
+	}
+	ve, err := a.repository.GetVehicle(ctx, input.VehicleUUID)
+	if err != nil {
+		return err
+	}
+
+	candidate := domain.Candidate{Driver: *dr, Vehicle: *ve, Primary: input.Primary}
+	if input.Primary && a.rules.SinglePrimaryDriver {
+		current, err := a.repository.GetActivePrimary(ctx, input.VehicleUUID)
+		switch {
+		case err == nil:
+			candidate.CurrentPrimary = current.DriverUUID
+		case !errors.Is(err, domain.ErrActiveNotFound):
+			return err
+		}
+	}
+	return a.rules.Check(candidate)
+}
+
+func (a *assignmentService) Unassign(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	current, err := a.repository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign driver %w", err)
+	}
+	return a.end(ctx, current)
+}
+
+// UnassignPair ends the active assignment between a driver and a vehicle.
+func (a *assignmentService) UnassignPair(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	current, err := a.repository.GetActive(ctx, driverUUID, vehicleUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign driver %w", err)
+	}
+	return a.end(ctx, current)
+}
+
+func (a *assignmentService) end(ctx context.Context, current *dto.Output) (*dto.Output, error) {
+	if current.UnassignedAt.Valid {
+		return nil, fmt.Errorf("failed to unassign driver %w", domain.ErrAlreadyUnassigned)
+	}
+	at := time.Now()
+	err := a.uow.Do(ctx, func(ctx context.Context) error {
+		if err := a.repository.Unassign(ctx, current.Uuid, at); err != nil {
+			return err
+		}
+		current.UnassignedAt.Time, current.UnassignedAt.Valid = at, true
+		return a.raise(ctx, current, domain.EventDriverUnassigned)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign driver %w", err)
+	}
+	return current, nil
+}
+
+// raise lets the assignment in out raise name and writes the event to the
+// outbox in the caller's unit of work.
+func (a *assignmentService) raise(ctx context.Context, out *dto.Output, name string) error {
+	as := domain.Assignment{
+		Uuid:         out.Uuid,
+		DriverUUID:   out.DriverUUID,
+		VehicleUUID:  out.VehicleUUID,
+		Primary:      out.Primary,
+		AssignedAt:   out.AssignedAt,
+		UnassignedAt: out.UnassignedAt,
+		AssignedBy:   out.AssignedBy,
+		Notes:        out.Notes,
+	}
+	as.Raise(name)
+	return a.outbox.Append(ctx, as.PullEvents()...)
+}
+
+func (a *assignmentService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	out, err := a.repository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment %w", err)
+	}
+	return out, nil
+}
+
+func (a *assignmentService) ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	out, err := a.repository.ListByDriver(ctx, driverUUID)
+	if err != nil {
+		return []dto.Output{}, fmt.Errorf("failed to list driver assignments %w", err)
+	}
+	return out, nil
+}
+
+func (a *assignmentService) ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	out, err := a.repository.ListByVehicle(ctx, vehicleUUID)
+	if err != nil {
+		return []dto.Output{}, fmt.Errorf("failed to list vehicle assignments %w", err)
+	}
+	return out, nil
+}
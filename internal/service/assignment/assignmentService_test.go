@@ -0,0 +1,225 @@
+package assignment_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/assignment"
+	"github.com/moura95/go-ddd/internal/domain/assignment/memory"
+	drivermemory "github.com/moura95/go-ddd/internal/domain/driver/memory"
+	eventmemory "github.com/moura95/go-ddd/internal/domain/event/memory"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+	vehiclememory "github.com/moura95/go-ddd/internal/domain/vehicle/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	driverdto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var (
+	driver1  = uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
+	driver2  = uuid.MustParse("ef9da75e-949f-4780-92b5-eda71618fc6c")
+	vehicle1 = uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9")
+	vehicle2 = uuid.MustParse("457a8df2-782f-4f22-8233-623b694096a1")
+)
+
+type fixture struct {
+	drivers  drivermemory.IDriverRepositoryMemory
+	vehicles vehiclememory.IVehicleRepositoryMemory
+	outbox   eventmemory.IOutboxRepositoryMemory
+	service  assignment.IAssignmentService
+}
+
+// inTenant is the context of the default tenant's calls, which the outbox
+// needs to file the events under.
+var inTenant = tenant.WithID(context.Background(), tenant.Default)
+
+func newFixture(rules domain.Rules) *fixture {
+	f := &fixture{
+		drivers:  drivermemory.NewDriverRepositoryMemory(),
+		vehicles: vehiclememory.NewVehicleRepositoryMemory(),
+		outbox:   eventmemory.NewOutboxRepositoryMemory(),
+	}
+	config := cfg.Config{
+		AssignmentSinglePrimaryDriver:    rules.SinglePrimaryDriver,
+		AssignmentRejectDeleted:          rules.RejectDeleted,
+		AssignmentRequireLicenseCategory: rules.RequireLicenseCategory,
+	}
+	repo := memory.NewAssignmentRepositoryMemory(f.drivers, f.vehicles)
+	uow := database.NewMemoryUnitOfWork(f.drivers, f.vehicles, repo, f.outbox)
+	f.service = assignment.NewAssignmentService(nil, uow, repo, f.outbox, config, zap.NewNop().Sugar())
+	return f
+}
+
+func TestAssign(t *testing.T) {
+	service := newFixture(domain.DefaultRules()).service
+
+	out, err := service.Assign(inTenant, dto.CreateInput{
+		DriverUUID:  driver1,
+		VehicleUUID: vehicle1,
+		AssignedBy:  "dispatcher",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, driver1, out.DriverUUID)
+	assert.Equal(t, vehicle1, out.VehicleUUID)
+	assert.False(t, out.UnassignedAt.Valid)
+
+	_, err = service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.ErrorIs(t, err, domain.ErrAlreadyAssigned)
+}
+
+func TestAssignUnknownParties(t *testing.T) {
+	service := newFixture(domain.DefaultRules()).service
+
+	_, err := service.Assign(inTenant, dto.CreateInput{DriverUUID: uuid.New(), VehicleUUID: vehicle1})
+	assert.ErrorIs(t, err, domain.ErrDriverNotFound)
+
+	_, err = service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrVehicleNotFound)
+}
+
+func TestAssignSinglePrimaryDriver(t *testing.T) {
+	service := newFixture(domain.DefaultRules()).service
+
+	_, err := service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1, Primary: true})
+	assert.NoError(t, err)
+
+	_, err = service.Assign(inTenant, dto.CreateInput{DriverUUID: driver2, VehicleUUID: vehicle1, Primary: true})
+	assert.ErrorIs(t, err, domain.ErrPrimaryDriverTaken)
+
+	// secondary drivers are not limited
+	_, err = service.Assign(inTenant, dto.CreateInput{DriverUUID: driver2, VehicleUUID: vehicle1})
+	assert.NoError(t, err)
+
+	_, err = service.UnassignPair(inTenant, driver1, vehicle1)
+	assert.NoError(t, err)
+
+	_, err = service.Assign(inTenant, dto.CreateInput{DriverUUID: driver2, VehicleUUID: vehicle2, Primary: true})
+	assert.NoError(t, err)
+}
+
+func TestAssignRejectsDeleted(t *testing.T) {
+	f := newFixture(domain.DefaultRules())
+	assert.NoError(t, f.drivers.SoftDelete(context.Background(), driver1, 0))
+	assert.NoError(t, f.vehicles.SoftDelete(context.Background(), vehicle2, 0))
+
+	_, err := f.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.ErrorIs(t, err, domain.ErrDriverDeleted)
+
+	_, err = f.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver2, VehicleUUID: vehicle2})
+	assert.ErrorIs(t, err, domain.ErrVehicleDeleted)
+
+	relaxed := newFixture(domain.Rules{})
+	assert.NoError(t, relaxed.drivers.SoftDelete(context.Background(), driver1, 0))
+	_, err = relaxed.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.NoError(t, err)
+}
+
+func TestAssignLicenseCategory(t *testing.T) {
+	f := newFixture(domain.DefaultRules())
+	truck := vehicle.Vehicle{
+		Uuid:      uuid.New(),
+		Brand:     "Scania",
+		Model:     "R450",
+		Category:  sql.NullString{String: "E", Valid: true},
+		CreatedAt: time.Now(),
+	}
+	assert.NoError(t, f.vehicles.Create(context.Background(), truck))
+
+	_, err := f.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: truck.Uuid})
+	assert.ErrorIs(t, err, domain.ErrLicenseCategoryMismatch)
+
+	err = f.drivers.Update(context.Background(), driver1, &driverdto.UpdateInput{
+		Name:            "Driver 1",
+		Email:           "driver1@example.com",
+		TaxID:           "12345678909",
+		LicenseCategory: "AE",
+	})
+	assert.NoError(t, err)
+
+	_, err = f.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: truck.Uuid})
+	assert.NoError(t, err)
+}
+
+func TestUnassignKeepsHistory(t *testing.T) {
+	service := newFixture(domain.DefaultRules()).service
+	input := dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1}
+
+	first, err := service.Assign(inTenant, input)
+	assert.NoError(t, err)
+
+	ended, err := service.Unassign(inTenant, first.Uuid)
+	assert.NoError(t, err)
+	assert.True(t, ended.UnassignedAt.Valid)
+
+	_, err = service.Unassign(inTenant, first.Uuid)
+	assert.ErrorIs(t, err, domain.ErrAlreadyUnassigned)
+
+	// the pair can be assigned again once the previous assignment ended
+	_, err = service.Assign(inTenant, input)
+	assert.NoError(t, err)
+
+	history, err := service.ListByDriver(inTenant, driver1)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	history, err = service.ListByVehicle(inTenant, vehicle1)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+}
+
+func TestUnassignPair(t *testing.T) {
+	service := newFixture(domain.DefaultRules()).service
+
+	_, err := service.UnassignPair(inTenant, driver1, vehicle1)
+	assert.ErrorIs(t, err, domain.ErrActiveNotFound)
+
+	_, err = service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.NoError(t, err)
+
+	ended, err := service.UnassignPair(inTenant, driver1, vehicle1)
+	assert.NoError(t, err)
+	assert.True(t, ended.UnassignedAt.Valid)
+}
+
+func TestGetAssignmentNotFound(t *testing.T) {
+	_, err := newFixture(domain.DefaultRules()).service.GetByID(inTenant, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestAssignmentEventsGoToOutbox(t *testing.T) {
+	f := newFixture(domain.DefaultRules())
+
+	out, err := f.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.NoError(t, err)
+	_, err = f.service.Unassign(inTenant, out.Uuid)
+	assert.NoError(t, err)
+
+	// a rejected assignment rolls back without leaving an event behind
+	_, err = f.service.Assign(inTenant, dto.CreateInput{DriverUUID: driver1, VehicleUUID: uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrVehicleNotFound)
+
+	messages := f.outbox.Messages()
+	assert.Len(t, messages, 2)
+	assert.Equal(t, domain.EventDriverAssigned, messages[0].Name)
+	assert.Equal(t, domain.EventDriverUnassigned, messages[1].Name)
+	for _, msg := range messages {
+		assert.Equal(t, domain.AggregateType, msg.AggregateType)
+		assert.Equal(t, out.Uuid, msg.AggregateUUID)
+	}
+	assert.JSONEq(t, `"`+driver1.String()+`"`, string(jsonField(t, messages[1].Payload, "driver_uuid")))
+}
+
+func jsonField(t *testing.T, payload []byte, name string) json.RawMessage {
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(payload, &fields))
+	return fields[name]
+}
@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+)
+
+// assignmentService records assignments in the history of both the driver
+// and the vehicle involved, which is where they are looked up.
+type assignmentService struct {
+	assignment.IAssignmentService
+	recorder *Recorder
+}
+
+func NewAssignmentService(inner assignment.IAssignmentService, recorder *Recorder) assignment.IAssignmentService {
+	return &assignmentService{IAssignmentService: inner, recorder: recorder}
+}
+
+type assignmentSnapshot struct {
+	Uuid         uuid.UUID  `json:"uuid"`
+	DriverUUID   uuid.UUID  `json:"driver_uuid"`
+	VehicleUUID  uuid.UUID  `json:"vehicle_uuid"`
+	Primary      bool       `json:"primary"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+	AssignedBy   string     `json:"assigned_by"`
+	Notes        string     `json:"notes"`
+}
+
+func newAssignmentSnapshot(a dto.Output) assignmentSnapshot {
+	s := assignmentSnapshot{
+		Uuid:        a.Uuid,
+		DriverUUID:  a.DriverUUID,
+		VehicleUUID: a.VehicleUUID,
+		Primary:     a.Primary,
+		AssignedAt:  a.AssignedAt,
+		AssignedBy:  a.AssignedBy,
+		Notes:       a.Notes,
+	}
+	if a.UnassignedAt.Valid {
+		s.UnassignedAt = &a.UnassignedAt.Time
+	}
+	return s
+}
+
+func (s *assignmentService) Assign(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	var out *dto.Output
+	err := s.recorder.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if out, err = s.IAssignmentService.Assign(ctx, input); err != nil {
+			return err
+		}
+		return s.recordBothSides(ctx, domain.ActionAssign, nil, out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *assignmentService) Unassign(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	return s.unassign(ctx, func(ctx context.Context) (*dto.Output, error) {
+		return s.IAssignmentService.Unassign(ctx, uid)
+	})
+}
+
+func (s *assignmentService) UnassignPair(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	return s.unassign(ctx, func(ctx context.Context) (*dto.Output, error) {
+		return s.IAssignmentService.UnassignPair(ctx, driverUUID, vehicleUUID)
+	})
+}
+
+// unassign records the ended assignment; the only field an unassign changes is
+// UnassignedAt, so the before snapshot is rebuilt from the result.
+func (s *assignmentService) unassign(ctx context.Context, end func(ctx context.Context) (*dto.Output, error)) (*dto.Output, error) {
+	var out *dto.Output
+	err := s.recorder.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if out, err = end(ctx); err != nil {
+			return err
+		}
+		before := *out
+		before.UnassignedAt.Valid = false
+		return s.recordBothSides(ctx, domain.ActionUnassign, &before, out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *assignmentService) recordBothSides(ctx context.Context, action domain.Action, before, after *dto.Output) error {
+	var b, a interface{}
+	if before != nil {
+		b = newAssignmentSnapshot(*before)
+	}
+	if after != nil {
+		a = newAssignmentSnapshot(*after)
+	}
+	if err := s.recorder.record(ctx, domain.EntityDriver, after.DriverUUID, action, b, a); err != nil {
+		return err
+	}
+	return s.recorder.record(ctx, domain.EntityVehicle, after.VehicleUUID, action, b, a)
+}
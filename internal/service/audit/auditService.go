@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	dto "github.com/moura95/go-ddd/internal/dtos/audit"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type IAuditService interface {
+	ListByEntity(ctx context.Context, entityType string, uid uuid.UUID, filter dto.ListInput) ([]dto.Output, int, error)
+}
+
+type auditService struct {
+	database   *sqlx.DB
+	repository domain.IAuditRepository
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewAuditService(db *sqlx.DB, repo domain.IAuditRepository, cfg cfg.Config, log *zap.SugaredLogger) *auditService {
+	return &auditService{
+		database:   db,
+		repository: repo,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+func (a *auditService) ListByEntity(ctx context.Context, entityType string, uid uuid.UUID, filter dto.ListInput) ([]dto.Output, int, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	filter.Normalize()
+	entries, total, err := a.repository.ListByEntity(ctx, entityType, uid, filter)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list audit %w", err)
+	}
+	return entries, total, nil
+}
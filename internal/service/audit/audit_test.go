@@ -0,0 +1,159 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	assignmentmemory "github.com/moura95/go-ddd/internal/domain/assignment/memory"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	"github.com/moura95/go-ddd/internal/domain/audit/memory"
+	drivermemory "github.com/moura95/go-ddd/internal/domain/driver/memory"
+	eventmemory "github.com/moura95/go-ddd/internal/domain/event/memory"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	vehiclememory "github.com/moura95/go-ddd/internal/domain/vehicle/memory"
+	assignmentdto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	dto "github.com/moura95/go-ddd/internal/dtos/audit"
+	driverdto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+	"github.com/moura95/go-ddd/internal/service/audit"
+	"github.com/moura95/go-ddd/internal/service/driver"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var (
+	driver1  = uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
+	vehicle1 = uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9")
+)
+
+func requestContext() context.Context {
+	ctx := tenant.WithID(context.Background(), tenant.Default)
+	return domain.WithMetadata(ctx, domain.Metadata{RequestID: "req-1", IP: "10.0.0.1", RemoteIP: "10.0.0.2"})
+}
+
+// stubDriverService keeps one driver and fails updates on demand.
+type stubDriverService struct {
+	driver.IDriverService
+	current   aggregate.DriverVehicleAggregate
+	updateErr error
+}
+
+func (s *stubDriverService) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	d := s.current
+	return &d, nil
+}
+
+func (s *stubDriverService) Update(ctx context.Context, input driverdto.UpdateInput) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	s.current.DriverLicense = input.DriverLicense
+	s.current.Version++
+	return nil
+}
+
+func TestDriverUpdateRecordsBeforeAndAfter(t *testing.T) {
+	repo := memory.NewAuditRepositoryMemory()
+	recorder := audit.NewRecorder(database.NewMemoryUnitOfWork(repo), repo, zap.NewNop().Sugar())
+	stub := &stubDriverService{current: aggregate.DriverVehicleAggregate{Uuid: driver1, DriverLicense: "02650306461", Version: 1}}
+	service := audit.NewDriverService(stub, recorder)
+
+	err := service.Update(requestContext(), driverdto.UpdateInput{Uuid: driver1, DriverLicense: "98765432109"})
+	assert.NoError(t, err)
+
+	entries, total, err := repo.ListByEntity(context.Background(), domain.EntityDriver, driver1, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	entry := entries[0]
+	assert.Equal(t, string(domain.ActionUpdate), entry.Action)
+	assert.Equal(t, domain.Anonymous, entry.Actor)
+	assert.Equal(t, "req-1", entry.RequestID.String)
+	assert.Equal(t, "10.0.0.1", entry.IP.String)
+	assert.Equal(t, "10.0.0.2", entry.RemoteIP.String)
+
+	var before, after map[string]interface{}
+	assert.NoError(t, json.Unmarshal(entry.Before, &before))
+	assert.NoError(t, json.Unmarshal(entry.After, &after))
+	assert.Equal(t, "02650306461", before["driver_license"])
+	assert.Equal(t, "98765432109", after["driver_license"])
+}
+
+func TestFailedWriteIsNotRecorded(t *testing.T) {
+	repo := memory.NewAuditRepositoryMemory()
+	recorder := audit.NewRecorder(database.NewMemoryUnitOfWork(repo), repo, zap.NewNop().Sugar())
+	stub := &stubDriverService{current: aggregate.DriverVehicleAggregate{Uuid: driver1}, updateErr: errors.New("boom")}
+	service := audit.NewDriverService(stub, recorder)
+
+	assert.Error(t, service.Update(requestContext(), driverdto.UpdateInput{Uuid: driver1}))
+
+	_, total, err := repo.ListByEntity(context.Background(), domain.EntityDriver, driver1, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+type assignmentFixture struct {
+	inner   assignment.IAssignmentService
+	service assignment.IAssignmentService
+}
+
+func newAssignmentFixture(auditRepo domain.IAuditRepository) *assignmentFixture {
+	drivers := drivermemory.NewDriverRepositoryMemory()
+	vehicles := vehiclememory.NewVehicleRepositoryMemory()
+	repo := assignmentmemory.NewAssignmentRepositoryMemory(drivers, vehicles)
+	snapshots := []database.Snapshotter{drivers, vehicles, repo}
+	if s, ok := auditRepo.(database.Snapshotter); ok {
+		snapshots = append(snapshots, s)
+	}
+	uow := database.NewMemoryUnitOfWork(snapshots...)
+	config := cfg.Config{AssignmentSinglePrimaryDriver: true}
+	inner := assignment.NewAssignmentService(nil, uow, repo, eventmemory.NewOutboxRepositoryMemory(), config, zap.NewNop().Sugar())
+	recorder := audit.NewRecorder(uow, auditRepo, zap.NewNop().Sugar())
+	return &assignmentFixture{inner: inner, service: audit.NewAssignmentService(inner, recorder)}
+}
+
+func TestAssignAndUnassignAreRecordedOnBothSides(t *testing.T) {
+	repo := memory.NewAuditRepositoryMemory()
+	f := newAssignmentFixture(repo)
+
+	out, err := f.service.Assign(requestContext(), assignmentdto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.NoError(t, err)
+	_, err = f.service.Unassign(requestContext(), out.Uuid)
+	assert.NoError(t, err)
+
+	for _, side := range []struct {
+		entity string
+		uid    uuid.UUID
+	}{{domain.EntityDriver, driver1}, {domain.EntityVehicle, vehicle1}} {
+		entries, total, err := repo.ListByEntity(context.Background(), side.entity, side.uid, dto.ListInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total, side.entity)
+		assert.Equal(t, string(domain.ActionUnassign), entries[0].Action)
+		assert.Equal(t, string(domain.ActionAssign), entries[1].Action)
+		assert.Nil(t, entries[1].Before)
+	}
+}
+
+type failingAuditRepository struct {
+	domain.IAuditRepository
+}
+
+func (failingAuditRepository) Append(ctx context.Context, entry *domain.Entry) error {
+	return errors.New("audit_log unavailable")
+}
+
+func TestChangeRollsBackWhenAuditFails(t *testing.T) {
+	f := newAssignmentFixture(failingAuditRepository{})
+
+	_, err := f.service.Assign(requestContext(), assignmentdto.CreateInput{DriverUUID: driver1, VehicleUUID: vehicle1})
+	assert.Error(t, err)
+
+	active, err := f.inner.ListByDriver(context.Background(), driver1)
+	assert.NoError(t, err)
+	assert.Empty(t, active)
+}
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/service/driver"
+)
+
+// driverService audits every write made through the wrapped service; reads
+// go straight to it through the embedded interface.
+type driverService struct {
+	driver.IDriverService
+	recorder *Recorder
+}
+
+func NewDriverService(inner driver.IDriverService, recorder *Recorder) driver.IDriverService {
+	return &driverService{IDriverService: inner, recorder: recorder}
+}
+
+type driverSnapshot struct {
+	Uuid            uuid.UUID `json:"uuid"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	TaxID           string    `json:"tax_id"`
+	DriverLicense   string    `json:"driver_license"`
+	LicenseCategory string    `json:"license_category"`
+	DateOfBirth     string    `json:"date_of_birth"`
+	DeletedAt       string    `json:"deleted_at"`
+	Version         int       `json:"version"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func newDriverSnapshot(d *aggregate.DriverVehicleAggregate) driverSnapshot {
+	return driverSnapshot{
+		Uuid:            d.Uuid,
+		Name:            d.Name,
+		Email:           d.Email,
+		TaxID:           d.TaxID,
+		DriverLicense:   d.DriverLicense,
+		LicenseCategory: d.LicenseCategory.String,
+		DateOfBirth:     d.DateOfBirth.String,
+		DeletedAt:       d.DeletedAt.String,
+		Version:         d.Version,
+		UpdatedAt:       d.UpdatedAt,
+	}
+}
+
+func (s *driverService) load(ctx context.Context, uid uuid.UUID) (interface{}, error) {
+	d, err := s.IDriverService.GetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return newDriverSnapshot(d), nil
+}
+
+func (s *driverService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	var uid uuid.UUID
+	err := s.recorder.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if uid, err = s.IDriverService.Create(ctx, input); err != nil {
+			return err
+		}
+		after, err := s.load(ctx, uid)
+		if err != nil {
+			return err
+		}
+		return s.recorder.record(ctx, domain.EntityDriver, uid, domain.ActionCreate, nil, after)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uid, nil
+}
+
+func (s *driverService) Update(ctx context.Context, input dto.UpdateInput) error {
+	return s.recorder.track(ctx, domain.EntityDriver, input.Uuid, domain.ActionUpdate, s.load, func(ctx context.Context) error {
+		return s.IDriverService.Update(ctx, input)
+	})
+}
+
+func (s *driverService) Patch(ctx context.Context, input dto.PatchInput) (*aggregate.DriverVehicleAggregate, error) {
+	var patched *aggregate.DriverVehicleAggregate
+	err := s.recorder.track(ctx, domain.EntityDriver, input.Uuid, domain.ActionUpdate, s.load, func(ctx context.Context) error {
+		var err error
+		patched, err = s.IDriverService.Patch(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func (s *driverService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return s.recorder.track(ctx, domain.EntityDriver, uid, domain.ActionSoftDelete, s.load, func(ctx context.Context) error {
+		return s.IDriverService.SoftDelete(ctx, uid, expectedVersion)
+	})
+}
+
+func (s *driverService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return s.recorder.track(ctx, domain.EntityDriver, uid, domain.ActionUnDelete, s.load, func(ctx context.Context) error {
+		return s.IDriverService.UnDelete(ctx, uid, expectedVersion)
+	})
+}
+
+func (s *driverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return s.recorder.track(ctx, domain.EntityDriver, uid, domain.ActionHardDelete, s.load, func(ctx context.Context) error {
+		return s.IDriverService.HardDelete(ctx, uid, expectedVersion)
+	})
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"go.uber.org/zap"
+)
+
+// Recorder appends audit entries in the same unit of work as the change they
+// describe, so a change is never committed without its entry or vice versa.
+type Recorder struct {
+	uow        database.UnitOfWork
+	repository domain.IAuditRepository
+	logger     *zap.SugaredLogger
+}
+
+func NewRecorder(uow database.UnitOfWork, repo domain.IAuditRepository, log *zap.SugaredLogger) *Recorder {
+	return &Recorder{
+		uow:        uow,
+		repository: repo,
+		logger:     log,
+	}
+}
+
+// loader returns the snapshot of an entity that goes into an audit entry.
+type loader func(ctx context.Context, uid uuid.UUID) (interface{}, error)
+
+// track runs write and records the entity as it was before and after it. The
+// before snapshot is skipped for a create and the after one for a hard delete.
+func (r *Recorder) track(ctx context.Context, entityType string, uid uuid.UUID, action domain.Action, load loader, write func(ctx context.Context) error) error {
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		var before, after interface{}
+		var err error
+		if action != domain.ActionCreate {
+			if before, err = load(ctx, uid); err != nil {
+				return err
+			}
+		}
+		if err := write(ctx); err != nil {
+			return err
+		}
+		if action != domain.ActionHardDelete {
+			if after, err = load(ctx, uid); err != nil {
+				return err
+			}
+		}
+		return r.record(ctx, entityType, uid, action, before, after)
+	})
+}
+
+func (r *Recorder) record(ctx context.Context, entityType string, uid uuid.UUID, action domain.Action, before, after interface{}) error {
+	entry, err := domain.NewEntry(ctx, entityType, uid, action, before, after)
+	if err == nil {
+		err = r.repository.Append(ctx, entry)
+	}
+	if err != nil {
+		logging.FromContext(ctx, r.logger).Errorf("Failed Audit %s %s %s: %s", entityType, action, uid, err.Error())
+		return fmt.Errorf("failed to record audit %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/audit"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/service/vehicle"
+)
+
+type vehicleService struct {
+	vehicle.IVehicleService
+	recorder *Recorder
+}
+
+func NewVehicleService(inner vehicle.IVehicleService, recorder *Recorder) vehicle.IVehicleService {
+	return &vehicleService{IVehicleService: inner, recorder: recorder}
+}
+
+type vehicleSnapshot struct {
+	Uuid              uuid.UUID `json:"uuid"`
+	Brand             string    `json:"brand"`
+	Model             string    `json:"model"`
+	YearOfManufacture uint      `json:"year_of_manufacture"`
+	LicensePlate      string    `json:"license_plate"`
+	Renavam           string    `json:"renavam"`
+	Category          string    `json:"category"`
+	Color             string    `json:"color"`
+	DeletedAt         string    `json:"deleted_at"`
+	Version           int       `json:"version"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func newVehicleSnapshot(v *dto.Output) vehicleSnapshot {
+	return vehicleSnapshot{
+		Uuid:              v.Uuid,
+		Brand:             v.Brand,
+		Model:             v.Model,
+		YearOfManufacture: v.YearOfManufacture,
+		LicensePlate:      v.LicensePlate,
+		Renavam:           v.Renavam.String,
+		Category:          v.Category.String,
+		Color:             v.Color,
+		DeletedAt:         v.DeletedAt.String,
+		Version:           v.Version,
+		UpdatedAt:         v.UpdatedAt,
+	}
+}
+
+func (s *vehicleService) load(ctx context.Context, uid uuid.UUID) (interface{}, error) {
+	v, err := s.IVehicleService.GetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return newVehicleSnapshot(v), nil
+}
+
+func (s *vehicleService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	var uid uuid.UUID
+	err := s.recorder.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if uid, err = s.IVehicleService.Create(ctx, input); err != nil {
+			return err
+		}
+		after, err := s.load(ctx, uid)
+		if err != nil {
+			return err
+		}
+		return s.recorder.record(ctx, domain.EntityVehicle, uid, domain.ActionCreate, nil, after)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uid, nil
+}
+
+func (s *vehicleService) Update(ctx context.Context, input dto.UpdateInput) error {
+	return s.recorder.track(ctx, domain.EntityVehicle, input.Uuid, domain.ActionUpdate, s.load, func(ctx context.Context) error {
+		return s.IVehicleService.Update(ctx, input)
+	})
+}
+
+func (s *vehicleService) Patch(ctx context.Context, input dto.PatchInput) (*dto.Output, error) {
+	var patched *dto.Output
+	err := s.recorder.track(ctx, domain.EntityVehicle, input.Uuid, domain.ActionUpdate, s.load, func(ctx context.Context) error {
+		var err error
+		patched, err = s.IVehicleService.Patch(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func (s *vehicleService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return s.recorder.track(ctx, domain.EntityVehicle, uid, domain.ActionSoftDelete, s.load, func(ctx context.Context) error {
+		return s.IVehicleService.SoftDelete(ctx, uid, expectedVersion)
+	})
+}
+
+func (s *vehicleService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return s.recorder.track(ctx, domain.EntityVehicle, uid, domain.ActionUnDelete, s.load, func(ctx context.Context) error {
+		return s.IVehicleService.UnDelete(ctx, uid, expectedVersion)
+	})
+}
+
+func (s *vehicleService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return s.recorder.track(ctx, domain.EntityVehicle, uid, domain.ActionHardDelete, s.load, func(ctx context.Context) error {
+		return s.IVehicleService.HardDelete(ctx, uid, expectedVersion)
+	})
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	domain "github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/auth"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/infra/logging"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const defaultRefreshTTL = 30 * 24 * time.Hour
+
+// dummyHash is compared against when the email is unknown, so a failed login
+// takes as long whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+
+type IAuthService interface {
+	Login(ctx context.Context, input dto.LoginInput) (*dto.Tokens, error)
+	// Refresh rotates refreshToken: it is revoked and a new one of the same
+	// session is returned with a new access token. Presenting a revoked
+	// token revokes the whole session.
+	Refresh(ctx context.Context, refreshToken string) (*dto.Tokens, error)
+	// Logout revokes the session refreshToken belongs to. Access tokens
+	// already issued stay valid until they expire.
+	Logout(ctx context.Context, refreshToken string) error
+	// Bootstrap creates an admin unless the email is already registered.
+	Bootstrap(ctx context.Context, email, password string) error
+}
+
+type authService struct {
+	database   *sqlx.DB
+	uow        database.UnitOfWork
+	repository domain.IUserRepository
+	signer     *auth.Signer
+	refreshTTL time.Duration
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewAuthService(db *sqlx.DB, uow database.UnitOfWork, repo domain.IUserRepository, signer *auth.Signer, cfg cfg.Config, log *zap.SugaredLogger) *authService {
+	refreshTTL := cfg.AuthRefreshTokenTTL
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTTL
+	}
+	return &authService{
+		database:   db,
+		uow:        uow,
+		repository: repo,
+		signer:     signer,
+		refreshTTL: refreshTTL,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+func (a *authService) Login(ctx context.Context, input dto.LoginInput) (*dto.Tokens, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	u, err := a.repository.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(input.Email)))
+	if errors.Is(err, domain.ErrNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(input.Password))
+		return nil, fmt.Errorf("failed to login %w", domain.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to login %w", err)
+	}
+	if err := u.CheckPassword(input.Password); err != nil {
+		return nil, fmt.Errorf("failed to login %w", err)
+	}
+
+	tokens, err := a.issue(ctx, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to login %w", err)
+	}
+	return tokens, nil
+}
+
+func (a *authService) Refresh(ctx context.Context, refreshToken string) (*dto.Tokens, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	var tokens *dto.Tokens
+	var reused bool
+	err := a.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := a.repository.GetRefreshToken(ctx, domain.HashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if current.Revoked() {
+			// a rotated token came back: someone else holds the session,
+			// so end it for everyone; the revocation has to commit
+			reused = true
+			return a.repository.RevokeFamily(ctx, current.FamilyUUID, now)
+		}
+		if current.Expired(now) {
+			return domain.ErrInvalidToken
+		}
+		u, err := a.repository.GetByID(ctx, current.UserUUID)
+		if err != nil {
+			return err
+		}
+		if !u.Active {
+			return domain.ErrInvalidToken
+		}
+		if err := a.repository.RevokeRefreshToken(ctx, current.Uuid, now); err != nil {
+			return err
+		}
+		tokens, err = a.issue(ctx, u, current)
+		return err
+	})
+	if err == nil && reused {
+		logging.FromContext(ctx, a.logger).Warnf("Refresh Token Reused, revoked its session")
+		err = domain.ErrTokenRevoked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token %w", err)
+	}
+	return tokens, nil
+}
+
+func (a *authService) Logout(ctx context.Context, refreshToken string) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	err := a.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := a.repository.GetRefreshToken(ctx, domain.HashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		return a.repository.RevokeFamily(ctx, current.FamilyUUID, time.Now())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to logout %w", err)
+	}
+	return nil
+}
+
+func (a *authService) Bootstrap(ctx context.Context, email, password string) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, a.config.DBQueryTimeout)
+	defer cancel()
+
+	u, err := domain.NewUser(email, password, string(domain.RoleAdmin))
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap user %w", err)
+	}
+	u.TenantID = tenant.Default
+	if _, err := a.repository.GetByEmail(ctx, u.Email); err == nil {
+		return nil
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("failed to bootstrap user %w", err)
+	}
+	if err := a.repository.Create(ctx, u); err != nil && !errors.Is(err, domain.ErrEmailTaken) {
+		return fmt.Errorf("failed to bootstrap user %w", err)
+	}
+	a.logger.Infof("Bootstrap User Successful email: %s", u.Email)
+	return nil
+}
+
+// issue stores a new refresh token, continuing the session of previous when
+// given, and signs an access token for u.
+func (a *authService) issue(ctx context.Context, u *domain.User, previous *domain.RefreshToken) (*dto.Tokens, error) {
+	family := uuid.Nil
+	if previous != nil {
+		family = previous.FamilyUUID
+	}
+	refresh, plain, err := domain.NewRefreshToken(u.Uuid, family, a.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.repository.CreateRefreshToken(ctx, refresh); err != nil {
+		return nil, err
+	}
+	access, expiresAt, err := a.signer.Issue(u)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Tokens{
+		AccessToken:           access,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          plain,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/domain/user/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/auth"
+	infraauth "github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/service/auth"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newAuthService(t *testing.T) (auth.IAuthService, *infraauth.Signer) {
+	config := cfg.Config{AuthJWTSecret: "0123456789abcdef0123456789abcdef", AuthAccessTokenTTL: time.Minute, AuthRefreshTokenTTL: time.Hour}
+	signer, err := infraauth.NewSigner(config)
+	assert.NoError(t, err)
+	repo := memory.NewUserRepositoryMemory()
+	service := auth.NewAuthService(nil, database.NewMemoryUnitOfWork(repo), repo, signer, config, zap.NewNop().Sugar())
+	assert.NoError(t, service.Bootstrap(context.Background(), "admin@example.com", "correct horse"))
+	return service, signer
+}
+
+func TestLogin(t *testing.T) {
+	ctx := context.Background()
+	service, signer := newAuthService(t)
+
+	tokens, err := service.Login(ctx, dto.LoginInput{Email: "Admin@Example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+	claims, err := signer.Verify(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@example.com", claims.Email)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	_, err = service.Login(ctx, dto.LoginInput{Email: "admin@example.com", Password: "wrong horse"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = service.Login(ctx, dto.LoginInput{Email: "nobody@example.com", Password: "correct horse"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+}
+
+func TestBootstrapKeepsAnExistingUser(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newAuthService(t)
+
+	assert.NoError(t, service.Bootstrap(ctx, "admin@example.com", "another password"))
+	_, err := service.Login(ctx, dto.LoginInput{Email: "admin@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+}
+
+func TestRefreshRotatesTheToken(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newAuthService(t)
+	login, err := service.Login(ctx, dto.LoginInput{Email: "admin@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	refreshed, err := service.Refresh(ctx, login.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+
+	again, err := service.Refresh(ctx, refreshed.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = service.Refresh(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	// the first token was rotated away: presenting it again ends the session
+	_, err = service.Refresh(ctx, login.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+	_, err = service.Refresh(ctx, again.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+}
+
+func TestLogoutRevokesTheSession(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newAuthService(t)
+	first, err := service.Login(ctx, dto.LoginInput{Email: "admin@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+	second, err := service.Login(ctx, dto.LoginInput{Email: "admin@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.Logout(ctx, first.RefreshToken))
+	assert.NoError(t, service.Logout(ctx, first.RefreshToken), "logging out twice is fine")
+	_, err = service.Refresh(ctx, first.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+
+	_, err = service.Refresh(ctx, second.RefreshToken)
+	assert.NoError(t, err, "other sessions are untouched")
+
+	assert.ErrorIs(t, service.Logout(ctx, "unknown"), domain.ErrInvalidToken)
+}
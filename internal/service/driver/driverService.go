@@ -1,136 +1,242 @@
 package driver
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/moura95/go-ddd/internal/aggregate"
 	"github.com/moura95/go-ddd/internal/domain/driver"
+	"github.com/moura95/go-ddd/internal/domain/event"
 	driver_dto "github.com/moura95/go-ddd/internal/dtos/driver"
-	"github.com/moura95/go-ddd/internal/dtos/driver_vehicle"
 	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
 
 	"go.uber.org/zap"
 )
 
 type IDriverService interface {
-	Create(driver driver_dto.CreateInput) error
-	Subscribe(driverVehicle driver_vehicle.Input) error
-	UnSubscribe(driverVehicle driver_vehicle.Input) error
-	List() ([]driver_dto.Output, error)
-	GetByID(uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error)
-	Update(driver driver_dto.UpdateInput) error
-	SoftDelete(uid uuid.UUID) error
-	UnDelete(uid uuid.UUID) error
-	HardDelete(uid uuid.UUID) error
+	Create(ctx context.Context, driver driver_dto.CreateInput) (uuid.UUID, error)
+	List(ctx context.Context, filter driver_dto.ListInput) ([]driver_dto.Output, int, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error)
+	Update(ctx context.Context, driver driver_dto.UpdateInput) error
+	Patch(ctx context.Context, input driver_dto.PatchInput) (*aggregate.DriverVehicleAggregate, error)
+	SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
 }
 
 type driverService struct {
 	database   *sqlx.DB
+	uow        database.UnitOfWork
 	repository driver.IDriverRepository
+	outbox     event.IOutboxRepository
 	config     cfg.Config
 	logger     *zap.SugaredLogger
 }
 
-func NewDriverService(db *sqlx.DB, repo driver.IDriverRepository, cfg cfg.Config, log *zap.SugaredLogger) *driverService {
+func NewDriverService(db *sqlx.DB, uow database.UnitOfWork, repo driver.IDriverRepository, outbox event.IOutboxRepository, cfg cfg.Config, log *zap.SugaredLogger) *driverService {
 	return &driverService{
 		database:   db,
+		uow:        uow,
 		repository: repo,
+		outbox:     outbox,
 		config:     cfg,
 		logger:     log,
 	}
 }
 
-func (d *driverService) Create(dto driver_dto.CreateInput) error {
+func (d *driverService) Create(ctx context.Context, dto driver_dto.CreateInput) (uuid.UUID, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
 	dr := driver_dto.CreateInput{
-		Name:          dto.Name,
-		Email:         dto.Email,
-		TaxID:         dto.TaxID,
-		DriverLicense: dto.DriverLicense,
-		DateOfBirth:   dto.DateOfBirth,
+		Name:            dto.Name,
+		Email:           dto.Email,
+		TaxID:           dto.TaxID,
+		DriverLicense:   dto.DriverLicense,
+		LicenseCategory: dto.LicenseCategory,
+		DateOfBirth:     dto.DateOfBirth,
 	}
-	err := d.repository.Create(dr)
+	var uid uuid.UUID
+	err := d.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if uid, err = d.repository.Create(ctx, dr); err != nil {
+			return err
+		}
+		return d.raise(ctx, uid, aggregate.EventDriverCreated)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create %s", err.Error())
+		return uuid.Nil, fmt.Errorf("failed to create %w", err)
 	}
-	return nil
+	return uid, nil
 }
-func (d *driverService) Subscribe(driverVehicle driver_vehicle.Input) error {
-	err := d.repository.Subscribe(driverVehicle)
+func (d *driverService) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
+	driverAggregate, err := d.repository.GetByID(ctx, uid)
 	if err != nil {
-		return fmt.Errorf("failed to create %s", err.Error())
+		return nil, fmt.Errorf("failed to get driver %w", err)
 	}
-	return nil
+	return driverAggregate, nil
 }
-func (d *driverService) UnSubscribe(driverVehicle driver_vehicle.Input) error {
-	// unRelate driver before delete
-	err := d.repository.UnSubscribe(driverVehicle)
+
+func (d *driverService) List(ctx context.Context, filter driver_dto.ListInput) ([]driver_dto.Output, int, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
+	filter.Normalize()
+	// documents are stored normalized, so match them the same way
+	if cpf, err := driver.NewCPF(filter.TaxID); err == nil {
+		filter.TaxID = cpf.String()
+	}
+	if cnh, err := driver.NewCNH(filter.DriverLicense); err == nil {
+		filter.DriverLicense = cnh.String()
+	}
+	drivers, total, err := d.repository.GetAll(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("failed to hard delete driver %s", err.Error())
+		return []driver_dto.Output{}, 0, fmt.Errorf("failed to list drivers %w", err)
 	}
-	return nil
+	return drivers, total, nil
 }
 
-func (d *driverService) GetByID(uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
-	driverOutput, err := d.repository.GetByID(uid)
+func (d *driverService) Update(ctx context.Context, dto driver_dto.UpdateInput) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
 
+	dr := driver_dto.UpdateInput{
+		Uuid:            dto.Uuid,
+		Name:            dto.Name,
+		Email:           dto.Email,
+		TaxID:           dto.TaxID,
+		DriverLicense:   dto.DriverLicense,
+		LicenseCategory: dto.LicenseCategory,
+		DateOfBirth:     dto.DateOfBirth,
+		ExpectedVersion: dto.ExpectedVersion,
+	}
+	err := d.uow.Do(ctx, func(ctx context.Context) error {
+		if err := d.repository.Update(ctx, dr.Uuid, &dr); err != nil {
+			return err
+		}
+		return d.raise(ctx, dr.Uuid, aggregate.EventDriverUpdated)
+	})
 	if err != nil {
-		return &aggregate.DriverVehicleAggregate{}, fmt.Errorf("failed to get driver %s", err.Error())
+		return fmt.Errorf("failed to update driver %w", err)
 	}
-	return (*aggregate.DriverVehicleAggregate)(driverOutput), nil
+	return nil
 }
 
-func (d *driverService) List() ([]driver_dto.Output, error) {
-	drivers, err := d.repository.GetAll()
+// Patch merges input into the stored driver and validates the result as a
+// whole. Without an expected version the write is still pinned to the version
+// that was read, so a concurrent change is never silently overwritten.
+func (d *driverService) Patch(ctx context.Context, input driver_dto.PatchInput) (*aggregate.DriverVehicleAggregate, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
+	var patched *aggregate.DriverVehicleAggregate
+	err := d.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := d.repository.GetByID(ctx, input.Uuid)
+		if err != nil {
+			return err
+		}
+		expected := input.ExpectedVersion
+		if expected == 0 {
+			expected = current.Version
+		}
+		merged := driver_dto.UpdateInput{
+			Uuid:            current.Uuid,
+			Name:            input.Name.Merge(current.Name),
+			Email:           input.Email.Merge(current.Email),
+			TaxID:           input.TaxID.Merge(current.TaxID),
+			DriverLicense:   input.DriverLicense.Merge(current.DriverLicense),
+			LicenseCategory: input.LicenseCategory.Merge(current.LicenseCategory.String),
+			DateOfBirth:     sql.NullString{String: input.DateOfBirth.Merge(current.DateOfBirth.String)},
+			ExpectedVersion: expected,
+		}
+		if err := d.repository.Update(ctx, merged.Uuid, &merged); err != nil {
+			return err
+		}
+		patched, err = d.repository.GetByID(ctx, merged.Uuid)
+		if err != nil {
+			return err
+		}
+		patched.Raise(aggregate.EventDriverUpdated)
+		return d.outbox.Append(ctx, patched.PullEvents()...)
+	})
 	if err != nil {
-		return []driver_dto.Output{}, fmt.Errorf("failed to list drivers %s", err.Error())
+		return nil, fmt.Errorf("failed to patch driver %w", err)
 	}
-	return drivers, nil
+	return patched, nil
 }
 
-func (d *driverService) Update(dto driver_dto.UpdateInput) error {
-	dr := driver_dto.UpdateInput{
-		Uuid:          dto.Uuid,
-		Name:          dto.Name,
-		Email:         dto.Email,
-		TaxID:         dto.TaxID,
-		DriverLicense: dto.DriverLicense,
-		DateOfBirth:   dto.DateOfBirth,
-	}
-	err := d.repository.Update(dr.Uuid, &dr)
+func (d *driverService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
+	err := d.uow.Do(ctx, func(ctx context.Context) error {
+		if err := d.repository.SoftDelete(ctx, uid, expectedVersion); err != nil {
+			return err
+		}
+		return d.raise(ctx, uid, aggregate.EventDriverSoftDeleted)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update driver %s", err.Error())
+		return fmt.Errorf("failed to delete driver %w", err)
 	}
 	return nil
 }
 
-func (d *driverService) SoftDelete(uid uuid.UUID) error {
-	err := d.repository.SoftDelete(uid)
+func (d *driverService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
+	err := d.uow.Do(ctx, func(ctx context.Context) error {
+		if err := d.repository.UnDelete(ctx, uid, expectedVersion); err != nil {
+			return err
+		}
+		return d.raise(ctx, uid, aggregate.EventDriverRestored)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete driver %s", err.Error())
+		return fmt.Errorf("failed to recover driver %w", err)
 	}
 	return nil
 }
 
-func (d *driverService) UnDelete(uid uuid.UUID) error {
-	err := d.repository.UnDelete(uid)
+func (d *driverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, d.config.DBQueryTimeout)
+	defer cancel()
+
+	err := d.uow.Do(ctx, func(ctx context.Context) error {
+		// the event describes the driver as it was before it was removed
+		deleted, err := d.repository.GetByID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		// unRelate driver before delete
+		if err := d.repository.UnRelate(ctx, uid); err != nil {
+			return err
+		}
+		if err := d.repository.HardDelete(ctx, uid, expectedVersion); err != nil {
+			return err
+		}
+		deleted.Raise(aggregate.EventDriverDeleted)
+		return d.outbox.Append(ctx, deleted.PullEvents()...)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to recover driver %s", err.Error())
+		return fmt.Errorf("failed to hard delete driver %w", err)
 	}
 	return nil
 }
 
-func (d *driverService) HardDelete(uid uuid.UUID) error {
-	// unRelate driver before delete
-	err := d.repository.UnRelate(uid)
+// raise loads the driver aggregate after a change, lets it raise name and
+// writes the event to the outbox in the caller's unit of work.
+func (d *driverService) raise(ctx context.Context, uid uuid.UUID, name string) error {
+	agg, err := d.repository.GetByID(ctx, uid)
 	if err != nil {
-		return fmt.Errorf("failed to hard delete driver %s", err.Error())
+		return err
 	}
-
-	err = d.repository.HardDelete(uid)
-	if err != nil {
-		return fmt.Errorf("failed to hardelete driver %s", err.Error())
-	}
-	return nil
+	agg.Raise(name)
+	return d.outbox.Append(ctx, agg.PullEvents()...)
 }
@@ -1,11 +1,14 @@
 package driver_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/driver"
 	"github.com/moura95/go-ddd/internal/domain/driver/memory"
 	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,11 +29,11 @@ func TestCreateDriver(t *testing.T) {
 	d := dto.CreateInput{
 		Name:          "Driver Test",
 		Email:         "test@example.com",
-		TaxID:         "1234567890",
-		DriverLicense: "XYZ12345",
+		TaxID:         "529.982.247-25",
+		DriverLicense: "98765432109",
 	}
 
-	err := service.repository.Create(d)
+	err := service.repository.Create(context.Background(), d)
 	if err != nil {
 		t.Error("Failed to created")
 	}
@@ -38,15 +41,29 @@ func TestCreateDriver(t *testing.T) {
 
 }
 
+func TestCreateDriverInvalid(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
+
+	err := service.repository.Create(context.Background(), dto.CreateInput{Name: "Driver Test"})
+	assert.ErrorIs(t, err, domain.ErrInvalidEmail)
+	assert.ErrorIs(t, err, domain.ErrInvalidTaxID)
+
+	_, total, err := service.repository.GetAll(context.Background(), dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
+
 func TestGetAll(t *testing.T) {
 	mockRepo := memory.NewDriverRepositoryMemory()
 	service := NewDriverServiceTest(mockRepo)
 
-	drivers, err := service.repository.GetAll()
+	drivers, total, err := service.repository.GetAll(context.Background(), dto.ListInput{})
 	if err != nil {
 		t.Error("Failed to created")
 	}
 	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
 	assert.Equal(t, drivers[0].Uuid, uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321"))
 	assert.Equal(t, drivers[0].Name, "Driver 1")
 	assert.Equal(t, drivers[0].Email, "driver1@example.com")
@@ -61,11 +78,73 @@ func TestGetAll(t *testing.T) {
 	assert.Equal(t, drivers[1].DriverLicense, "XYZ98765")
 }
 
+func TestGetAllFilterAndSort(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
+
+	drivers, total, err := service.repository.GetAll(context.Background(), dto.ListInput{Email: "DRIVER2@"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Driver 2", drivers[0].Name)
+
+	drivers, total, err = service.repository.GetAll(context.Background(), dto.ListInput{TaxID: "1234567890"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Driver 1", drivers[0].Name)
+
+	drivers, _, err = service.repository.GetAll(context.Background(), dto.ListInput{
+		Input: pagination.Input{SortBy: dto.SortByName, SortOrder: pagination.OrderDesc},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Driver 2", drivers[0].Name)
+	assert.Equal(t, "Driver 1", drivers[1].Name)
+}
+
+func TestGetAllPagination(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
+
+	drivers, total, err := service.repository.GetAll(context.Background(), dto.ListInput{Input: pagination.Input{Page: 2, PageSize: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, drivers, 1)
+	assert.Equal(t, "Driver 2", drivers[0].Name)
+
+	drivers, total, err = service.repository.GetAll(context.Background(), dto.ListInput{Input: pagination.Input{Page: 3, PageSize: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Empty(t, drivers)
+}
+
+func TestGetAllExcludesSoftDeleted(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
+
+	err := service.repository.SoftDelete(context.Background(), uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321"), 0)
+	assert.NoError(t, err)
+
+	drivers, total, err := service.repository.GetAll(context.Background(), dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Driver 2", drivers[0].Name)
+}
+
+func TestGetAllCancelledContext(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := service.repository.GetAll(ctx, dto.ListInput{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestGetID(t *testing.T) {
 	mockRepo := memory.NewDriverRepositoryMemory()
 	service := NewDriverServiceTest(mockRepo)
 
-	driver, err := service.repository.GetByID(uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321"))
+	driver, err := service.repository.GetByID(context.Background(), uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321"))
 	if err != nil {
 		t.Error("Failed to get")
 	}
@@ -75,7 +154,17 @@ func TestGetID(t *testing.T) {
 	assert.Equal(t, driver.Email, "driver1@example.com")
 	assert.Equal(t, driver.TaxID, "1234567890")
 	assert.Equal(t, driver.DriverLicense, "ABC12345")
+	assert.NotNil(t, driver.Vehicles)
+	assert.Empty(t, driver.Vehicles)
+}
+
+func TestGetIDNotFound(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
 
+	driver, err := service.repository.GetByID(context.Background(), uuid.New())
+	assert.Nil(t, driver)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
 }
 
 func TestUpdate(t *testing.T) {
@@ -87,10 +176,10 @@ func TestUpdate(t *testing.T) {
 	d := &dto.UpdateInput{
 		Name:          "Drive Updated",
 		Email:         "driver12345@example.com",
-		TaxID:         "123456",
-		DriverLicense: "ABC123451",
+		TaxID:         "11144477735",
+		DriverLicense: "02650306461",
 	}
-	err := service.repository.Update(uid, d)
+	err := service.repository.Update(context.Background(), uid, d)
 	if err != nil {
 		t.Error("Failed to update")
 	}
@@ -102,7 +191,7 @@ func TestHardDelete(t *testing.T) {
 	service := NewDriverServiceTest(mockRepo)
 
 	uid := uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
-	err := service.repository.HardDelete(uid)
+	err := service.repository.HardDelete(context.Background(), uid, 0)
 	if err != nil {
 		t.Error("Failed to delete")
 	}
@@ -114,9 +203,25 @@ func TestSoftDelete(t *testing.T) {
 	service := NewDriverServiceTest(mockRepo)
 
 	uid := uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
-	err := service.repository.SoftDelete(uid)
+	err := service.repository.SoftDelete(context.Background(), uid, 0)
 	if err != nil {
 		t.Error("Failed to delete")
 	}
 	assert.NoError(t, err)
 }
+
+func TestWritesRejectStaleVersion(t *testing.T) {
+	mockRepo := memory.NewDriverRepositoryMemory()
+	service := NewDriverServiceTest(mockRepo)
+
+	uid := uuid.MustParse("61a218e4-7908-45d7-88bf-6226b53ab321")
+	assert.NoError(t, service.repository.SoftDelete(context.Background(), uid, 1))
+
+	err := service.repository.UnDelete(context.Background(), uid, 1)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+
+	got, err := service.repository.GetByID(context.Background(), uid)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got.Version)
+	assert.True(t, got.DeletedAt.Valid)
+}
@@ -0,0 +1,88 @@
+// Package metrics counts and times the calls made to the services it wraps.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/infra/metrics"
+	"github.com/moura95/go-ddd/internal/service/driver"
+)
+
+// track starts timing a call; the returned func records it once its error
+// is known.
+func track(m *metrics.Metrics, service, method string) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		m.ObserveCall(service, method, err, time.Since(start))
+	}
+}
+
+// driverService records every call to the wrapped service with its outcome.
+type driverService struct {
+	inner   driver.IDriverService
+	metrics *metrics.Metrics
+}
+
+func NewDriverService(inner driver.IDriverService, m *metrics.Metrics) driver.IDriverService {
+	return &driverService{inner: inner, metrics: m}
+}
+
+func (d *driverService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	done := track(d.metrics, "driver", "Create")
+	uid, err := d.inner.Create(ctx, input)
+	done(err)
+	return uid, err
+}
+
+func (d *driverService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	done := track(d.metrics, "driver", "List")
+	drivers, total, err := d.inner.List(ctx, filter)
+	done(err)
+	return drivers, total, err
+}
+
+func (d *driverService) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	done := track(d.metrics, "driver", "GetByID")
+	agg, err := d.inner.GetByID(ctx, uid)
+	done(err)
+	return agg, err
+}
+
+func (d *driverService) Update(ctx context.Context, input dto.UpdateInput) error {
+	done := track(d.metrics, "driver", "Update")
+	err := d.inner.Update(ctx, input)
+	done(err)
+	return err
+}
+
+func (d *driverService) Patch(ctx context.Context, input dto.PatchInput) (*aggregate.DriverVehicleAggregate, error) {
+	done := track(d.metrics, "driver", "Patch")
+	agg, err := d.inner.Patch(ctx, input)
+	done(err)
+	return agg, err
+}
+
+func (d *driverService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	done := track(d.metrics, "driver", "SoftDelete")
+	err := d.inner.SoftDelete(ctx, uid, expectedVersion)
+	done(err)
+	return err
+}
+
+func (d *driverService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	done := track(d.metrics, "driver", "UnDelete")
+	err := d.inner.UnDelete(ctx, uid, expectedVersion)
+	done(err)
+	return err
+}
+
+func (d *driverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	done := track(d.metrics, "driver", "HardDelete")
+	err := d.inner.HardDelete(ctx, uid, expectedVersion)
+	done(err)
+	return err
+}
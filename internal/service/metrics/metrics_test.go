@@ -0,0 +1,38 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/vehicle"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	inframetrics "github.com/moura95/go-ddd/internal/infra/metrics"
+	"github.com/moura95/go-ddd/internal/service/metrics"
+	vehicleservice "github.com/moura95/go-ddd/internal/service/vehicle"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubVehicleService finds no vehicle.
+type stubVehicleService struct {
+	vehicleservice.IVehicleService
+}
+
+func (s stubVehicleService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	return nil, vehicle.ErrNotFound
+}
+
+func TestVehicleServiceRecordsCalls(t *testing.T) {
+	m := inframetrics.New()
+	service := metrics.NewVehicleService(stubVehicleService{}, m)
+
+	_, err := service.GetByID(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, vehicle.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `goddd_service_calls_total{method="GetByID",outcome="not_found",service="vehicle"} 1`)
+	assert.Contains(t, w.Body.String(), `goddd_service_call_duration_seconds_count{method="GetByID",service="vehicle"} 1`)
+}
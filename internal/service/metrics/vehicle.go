@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/metrics"
+	"github.com/moura95/go-ddd/internal/service/vehicle"
+)
+
+// vehicleService records every call to the wrapped service with its outcome.
+type vehicleService struct {
+	inner   vehicle.IVehicleService
+	metrics *metrics.Metrics
+}
+
+func NewVehicleService(inner vehicle.IVehicleService, m *metrics.Metrics) vehicle.IVehicleService {
+	return &vehicleService{inner: inner, metrics: m}
+}
+
+func (v *vehicleService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	done := track(v.metrics, "vehicle", "Create")
+	uid, err := v.inner.Create(ctx, input)
+	done(err)
+	return uid, err
+}
+
+func (v *vehicleService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	done := track(v.metrics, "vehicle", "List")
+	vehicles, total, err := v.inner.List(ctx, filter)
+	done(err)
+	return vehicles, total, err
+}
+
+func (v *vehicleService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	done := track(v.metrics, "vehicle", "GetByID")
+	out, err := v.inner.GetByID(ctx, uid)
+	done(err)
+	return out, err
+}
+
+func (v *vehicleService) GetByLicensePlate(ctx context.Context, plate string) (*dto.Output, error) {
+	done := track(v.metrics, "vehicle", "GetByLicensePlate")
+	out, err := v.inner.GetByLicensePlate(ctx, plate)
+	done(err)
+	return out, err
+}
+
+func (v *vehicleService) Update(ctx context.Context, input dto.UpdateInput) error {
+	done := track(v.metrics, "vehicle", "Update")
+	err := v.inner.Update(ctx, input)
+	done(err)
+	return err
+}
+
+func (v *vehicleService) Patch(ctx context.Context, input dto.PatchInput) (*dto.Output, error) {
+	done := track(v.metrics, "vehicle", "Patch")
+	out, err := v.inner.Patch(ctx, input)
+	done(err)
+	return out, err
+}
+
+func (v *vehicleService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	done := track(v.metrics, "vehicle", "SoftDelete")
+	err := v.inner.SoftDelete(ctx, uid, expectedVersion)
+	done(err)
+	return err
+}
+
+func (v *vehicleService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	done := track(v.metrics, "vehicle", "UnDelete")
+	err := v.inner.UnDelete(ctx, uid, expectedVersion)
+	done(err)
+	return err
+}
+
+func (v *vehicleService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	done := track(v.metrics, "vehicle", "HardDelete")
+	err := v.inner.HardDelete(ctx, uid, expectedVersion)
+	done(err)
+	return err
+}
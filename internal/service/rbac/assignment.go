@@ -0,0 +1,61 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+)
+
+type assignmentService struct {
+	inner assignment.IAssignmentService
+}
+
+func NewAssignmentService(inner assignment.IAssignmentService) assignment.IAssignmentService {
+	return &assignmentService{inner: inner}
+}
+
+func (a *assignmentService) Assign(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAssignmentWrite); err != nil {
+		return nil, err
+	}
+	return a.inner.Assign(ctx, input)
+}
+
+func (a *assignmentService) Unassign(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAssignmentWrite); err != nil {
+		return nil, err
+	}
+	return a.inner.Unassign(ctx, uid)
+}
+
+func (a *assignmentService) UnassignPair(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAssignmentWrite); err != nil {
+		return nil, err
+	}
+	return a.inner.UnassignPair(ctx, driverUUID, vehicleUUID)
+}
+
+func (a *assignmentService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAssignmentRead); err != nil {
+		return nil, err
+	}
+	return a.inner.GetByID(ctx, uid)
+}
+
+func (a *assignmentService) ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAssignmentRead); err != nil {
+		return []dto.Output{}, err
+	}
+	return a.inner.ListByDriver(ctx, driverUUID)
+}
+
+func (a *assignmentService) ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]dto.Output, error) {
+	if err := auth.Require(ctx, user.PermAssignmentRead); err != nil {
+		return []dto.Output{}, err
+	}
+	return a.inner.ListByVehicle(ctx, vehicleUUID)
+}
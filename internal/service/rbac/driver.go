@@ -0,0 +1,80 @@
+// Package rbac re-checks in the service layer the permissions the routers
+// already demand, so a service reached another way is guarded as well.
+package rbac
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/service/driver"
+)
+
+// driverService lets a call through to the wrapped service only when the
+// caller on ctx holds the permission the operation needs.
+type driverService struct {
+	inner driver.IDriverService
+}
+
+func NewDriverService(inner driver.IDriverService) driver.IDriverService {
+	return &driverService{inner: inner}
+}
+
+func (d *driverService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	if err := auth.Require(ctx, user.PermDriverWrite); err != nil {
+		return uuid.Nil, err
+	}
+	return d.inner.Create(ctx, input)
+}
+
+func (d *driverService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if err := auth.Require(ctx, user.PermDriverRead); err != nil {
+		return []dto.Output{}, 0, err
+	}
+	return d.inner.List(ctx, filter)
+}
+
+func (d *driverService) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	if err := auth.Require(ctx, user.PermDriverRead); err != nil {
+		return nil, err
+	}
+	return d.inner.GetByID(ctx, uid)
+}
+
+func (d *driverService) Update(ctx context.Context, input dto.UpdateInput) error {
+	if err := auth.Require(ctx, user.PermDriverWrite); err != nil {
+		return err
+	}
+	return d.inner.Update(ctx, input)
+}
+
+func (d *driverService) Patch(ctx context.Context, input dto.PatchInput) (*aggregate.DriverVehicleAggregate, error) {
+	if err := auth.Require(ctx, user.PermDriverWrite); err != nil {
+		return nil, err
+	}
+	return d.inner.Patch(ctx, input)
+}
+
+func (d *driverService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	if err := auth.Require(ctx, user.PermDriverWrite); err != nil {
+		return err
+	}
+	return d.inner.SoftDelete(ctx, uid, expectedVersion)
+}
+
+func (d *driverService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	if err := auth.Require(ctx, user.PermDriverWrite); err != nil {
+		return err
+	}
+	return d.inner.UnDelete(ctx, uid, expectedVersion)
+}
+
+func (d *driverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	if err := auth.Require(ctx, user.PermDriverHardDelete); err != nil {
+		return err
+	}
+	return d.inner.HardDelete(ctx, uid, expectedVersion)
+}
@@ -0,0 +1,64 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	assignmentdto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+	"github.com/moura95/go-ddd/internal/service/driver"
+	"github.com/moura95/go-ddd/internal/service/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func as(roles ...user.Role) context.Context {
+	return auth.WithClaims(context.Background(), &auth.Claims{UserUUID: uuid.New(), Roles: roles})
+}
+
+// stubDriverService counts the calls that got through.
+type stubDriverService struct {
+	driver.IDriverService
+	hardDeletes int
+}
+
+func (s *stubDriverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	s.hardDeletes++
+	return nil
+}
+
+type stubAssignmentService struct {
+	assignment.IAssignmentService
+	assigns int
+}
+
+func (s *stubAssignmentService) Assign(ctx context.Context, input assignmentdto.CreateInput) (*assignmentdto.Output, error) {
+	s.assigns++
+	return &assignmentdto.Output{}, nil
+}
+
+func TestDriverHardDeleteNeedsAnAdmin(t *testing.T) {
+	inner := &stubDriverService{}
+	service := rbac.NewDriverService(inner)
+
+	assert.ErrorIs(t, service.HardDelete(context.Background(), uuid.New(), 0), user.ErrInvalidToken)
+	assert.ErrorIs(t, service.HardDelete(as(user.RoleViewer), uuid.New(), 0), user.ErrForbidden)
+	assert.ErrorIs(t, service.HardDelete(as(user.RoleDispatcher), uuid.New(), 0), user.ErrForbidden)
+	assert.Equal(t, 0, inner.hardDeletes)
+
+	assert.NoError(t, service.HardDelete(as(user.RoleAdmin), uuid.New(), 0))
+	assert.Equal(t, 1, inner.hardDeletes)
+}
+
+func TestDispatcherMayAssign(t *testing.T) {
+	inner := &stubAssignmentService{}
+	service := rbac.NewAssignmentService(inner)
+
+	_, err := service.Assign(as(user.RoleViewer), assignmentdto.CreateInput{})
+	assert.ErrorIs(t, err, user.ErrForbidden)
+	_, err = service.Assign(as(user.RoleDispatcher), assignmentdto.CreateInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inner.assigns)
+}
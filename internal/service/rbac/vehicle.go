@@ -0,0 +1,82 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/service/vehicle"
+)
+
+type vehicleService struct {
+	inner vehicle.IVehicleService
+}
+
+func NewVehicleService(inner vehicle.IVehicleService) vehicle.IVehicleService {
+	return &vehicleService{inner: inner}
+}
+
+func (v *vehicleService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	if err := auth.Require(ctx, user.PermVehicleWrite); err != nil {
+		return uuid.Nil, err
+	}
+	return v.inner.Create(ctx, input)
+}
+
+func (v *vehicleService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if err := auth.Require(ctx, user.PermVehicleRead); err != nil {
+		return []dto.Output{}, 0, err
+	}
+	return v.inner.List(ctx, filter)
+}
+
+func (v *vehicleService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermVehicleRead); err != nil {
+		return nil, err
+	}
+	return v.inner.GetByID(ctx, uid)
+}
+
+func (v *vehicleService) GetByLicensePlate(ctx context.Context, plate string) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermVehicleRead); err != nil {
+		return nil, err
+	}
+	return v.inner.GetByLicensePlate(ctx, plate)
+}
+
+func (v *vehicleService) Update(ctx context.Context, input dto.UpdateInput) error {
+	if err := auth.Require(ctx, user.PermVehicleWrite); err != nil {
+		return err
+	}
+	return v.inner.Update(ctx, input)
+}
+
+func (v *vehicleService) Patch(ctx context.Context, input dto.PatchInput) (*dto.Output, error) {
+	if err := auth.Require(ctx, user.PermVehicleWrite); err != nil {
+		return nil, err
+	}
+	return v.inner.Patch(ctx, input)
+}
+
+func (v *vehicleService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	if err := auth.Require(ctx, user.PermVehicleWrite); err != nil {
+		return err
+	}
+	return v.inner.SoftDelete(ctx, uid, expectedVersion)
+}
+
+func (v *vehicleService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	if err := auth.Require(ctx, user.PermVehicleWrite); err != nil {
+		return err
+	}
+	return v.inner.UnDelete(ctx, uid, expectedVersion)
+}
+
+func (v *vehicleService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	if err := auth.Require(ctx, user.PermVehicleHardDelete); err != nil {
+		return err
+	}
+	return v.inner.HardDelete(ctx, uid, expectedVersion)
+}
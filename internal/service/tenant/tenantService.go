@@ -0,0 +1,127 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	domain "github.com/moura95/go-ddd/internal/domain/tenant"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+// ITenantService onboards client companies. Every call requires the caller
+// on ctx to hold tenant:manage within the default tenant.
+type ITenantService interface {
+	// Create adds the tenant together with its first admin.
+	Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error)
+	List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+}
+
+type tenantService struct {
+	database   *sqlx.DB
+	uow        database.UnitOfWork
+	repository domain.ITenantRepository
+	users      user.IUserRepository
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewTenantService(db *sqlx.DB, uow database.UnitOfWork, repo domain.ITenantRepository, users user.IUserRepository, cfg cfg.Config, log *zap.SugaredLogger) *tenantService {
+	return &tenantService{
+		database:   db,
+		uow:        uow,
+		repository: repo,
+		users:      users,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+func (s *tenantService) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create tenant %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	t, err := domain.NewTenant(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant %w", err)
+	}
+	admin, err := user.NewUser(input.AdminEmail, input.AdminPassword, string(user.RoleAdmin))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant %w", err)
+	}
+	admin.TenantID = t.Uuid
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repository.Create(ctx, t); err != nil {
+			return err
+		}
+		return s.users.Create(ctx, admin)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant %w", err)
+	}
+	return newOutput(t), nil
+}
+
+func (s *tenantService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if err := requireOperator(ctx); err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list tenants %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	filter.Normalize()
+	tenants, total, err := s.repository.GetAll(ctx, filter)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list tenants %w", err)
+	}
+	out := make([]dto.Output, 0, len(tenants))
+	for i := range tenants {
+		out = append(out, *newOutput(&tenants[i]))
+	}
+	return out, total, nil
+}
+
+func (s *tenantService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get tenant %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	t, err := s.repository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant %w", err)
+	}
+	return newOutput(t), nil
+}
+
+// requireOperator lets through callers holding tenant:manage in the default
+// tenant; admins of client tenants hold the permission too, but may not see
+// or create other tenants.
+func requireOperator(ctx context.Context) error {
+	if err := auth.Require(ctx, user.PermTenantManage); err != nil {
+		return err
+	}
+	if id, ok := domain.FromContext(ctx); !ok || id != domain.Default {
+		return user.ErrForbidden
+	}
+	return nil
+}
+
+func newOutput(t *domain.Tenant) *dto.Output {
+	return &dto.Output{
+		Uuid:      t.Uuid,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	domain "github.com/moura95/go-ddd/internal/domain/tenant"
+	tenantmemory "github.com/moura95/go-ddd/internal/domain/tenant/memory"
+	"github.com/moura95/go-ddd/internal/domain/user"
+	usermemory "github.com/moura95/go-ddd/internal/domain/user/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/tenant"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/service/tenant"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func admin(tenantID uuid.UUID) context.Context {
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{UserUUID: uuid.New(), TenantUUID: tenantID, Roles: []user.Role{user.RoleAdmin}})
+	return domain.WithID(ctx, tenantID)
+}
+
+func TestCreateTenantWithItsAdmin(t *testing.T) {
+	tenants := tenantmemory.NewTenantRepositoryMemory()
+	users := usermemory.NewUserRepositoryMemory()
+	service := tenant.NewTenantService(nil, database.NewMemoryUnitOfWork(tenants, users), tenants, users, cfg.Config{}, zap.NewNop().Sugar())
+
+	out, err := service.Create(admin(domain.Default), dto.CreateInput{Name: "Acme Logistics", AdminEmail: "ops@acme.example", AdminPassword: "correct horse"})
+	assert.NoError(t, err)
+	u, err := users.GetByEmail(context.Background(), "ops@acme.example")
+	assert.NoError(t, err)
+	assert.Equal(t, out.Uuid, u.TenantID)
+	assert.Equal(t, []user.Role{user.RoleAdmin}, u.Roles)
+
+	// a client admin may not onboard or look up other tenants
+	_, err = service.Create(admin(out.Uuid), dto.CreateInput{Name: "Other", AdminEmail: "ops@other.example", AdminPassword: "correct horse"})
+	assert.ErrorIs(t, err, user.ErrForbidden)
+	_, err = service.GetByID(admin(out.Uuid), out.Uuid)
+	assert.ErrorIs(t, err, user.ErrForbidden)
+
+	// a taken admin email rolls the tenant back
+	_, err = service.Create(admin(domain.Default), dto.CreateInput{Name: "Copycat", AdminEmail: "ops@acme.example", AdminPassword: "correct horse"})
+	assert.ErrorIs(t, err, user.ErrEmailTaken)
+	_, total, err := service.List(admin(domain.Default), dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/assignment"
+	"github.com/moura95/go-ddd/internal/service/assignment"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type assignmentService struct {
+	inner assignment.IAssignmentService
+}
+
+func NewAssignmentService(inner assignment.IAssignmentService) assignment.IAssignmentService {
+	return &assignmentService{inner: inner}
+}
+
+func (a *assignmentService) Assign(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentService.Assign", trace.WithAttributes(
+		attribute.String("driver.uuid", input.DriverUUID.String()),
+		attribute.String("vehicle.uuid", input.VehicleUUID.String()),
+	))
+	out, err := a.inner.Assign(ctx, input)
+	end(span, err)
+	return out, err
+}
+
+func (a *assignmentService) Unassign(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentService.Unassign", trace.WithAttributes(attribute.String("assignment.uuid", uid.String())))
+	out, err := a.inner.Unassign(ctx, uid)
+	end(span, err)
+	return out, err
+}
+
+func (a *assignmentService) UnassignPair(ctx context.Context, driverUUID, vehicleUUID uuid.UUID) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentService.UnassignPair", trace.WithAttributes(
+		attribute.String("driver.uuid", driverUUID.String()),
+		attribute.String("vehicle.uuid", vehicleUUID.String()),
+	))
+	out, err := a.inner.UnassignPair(ctx, driverUUID, vehicleUUID)
+	end(span, err)
+	return out, err
+}
+
+func (a *assignmentService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentService.GetByID", trace.WithAttributes(attribute.String("assignment.uuid", uid.String())))
+	out, err := a.inner.GetByID(ctx, uid)
+	end(span, err)
+	return out, err
+}
+
+func (a *assignmentService) ListByDriver(ctx context.Context, driverUUID uuid.UUID) ([]dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentService.ListByDriver", trace.WithAttributes(attribute.String("driver.uuid", driverUUID.String())))
+	out, err := a.inner.ListByDriver(ctx, driverUUID)
+	end(span, err)
+	return out, err
+}
+
+func (a *assignmentService) ListByVehicle(ctx context.Context, vehicleUUID uuid.UUID) ([]dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentService.ListByVehicle", trace.WithAttributes(attribute.String("vehicle.uuid", vehicleUUID.String())))
+	out, err := a.inner.ListByVehicle(ctx, vehicleUUID)
+	end(span, err)
+	return out, err
+}
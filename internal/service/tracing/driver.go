@@ -0,0 +1,101 @@
+// Package tracing opens a span around each call made to the services it
+// wraps, so a slow request shows which service method and which statements
+// under it took the time.
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/domainerr"
+	dto "github.com/moura95/go-ddd/internal/dtos/driver"
+	"github.com/moura95/go-ddd/internal/service/driver"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/moura95/go-ddd/internal/service")
+
+// end closes span with the call's error. Domain errors, such as a driver not
+// found, are answers rather than failures: they are recorded with their kind
+// but leave the span's status unset.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		var derr *domainerr.Error
+		if errors.As(err, &derr) {
+			span.SetAttributes(attribute.String("error.kind", string(derr.Kind)))
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+type driverService struct {
+	inner driver.IDriverService
+}
+
+func NewDriverService(inner driver.IDriverService) driver.IDriverService {
+	return &driverService{inner: inner}
+}
+
+func (d *driverService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.Create")
+	uid, err := d.inner.Create(ctx, input)
+	end(span, err)
+	return uid, err
+}
+
+func (d *driverService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.List")
+	drivers, total, err := d.inner.List(ctx, filter)
+	end(span, err)
+	return drivers, total, err
+}
+
+func (d *driverService) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.GetByID", trace.WithAttributes(attribute.String("driver.uuid", uid.String())))
+	agg, err := d.inner.GetByID(ctx, uid)
+	end(span, err)
+	return agg, err
+}
+
+func (d *driverService) Update(ctx context.Context, input dto.UpdateInput) error {
+	ctx, span := tracer.Start(ctx, "DriverService.Update", trace.WithAttributes(attribute.String("driver.uuid", input.Uuid.String())))
+	err := d.inner.Update(ctx, input)
+	end(span, err)
+	return err
+}
+
+func (d *driverService) Patch(ctx context.Context, input dto.PatchInput) (*aggregate.DriverVehicleAggregate, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.Patch", trace.WithAttributes(attribute.String("driver.uuid", input.Uuid.String())))
+	agg, err := d.inner.Patch(ctx, input)
+	end(span, err)
+	return agg, err
+}
+
+func (d *driverService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, span := tracer.Start(ctx, "DriverService.SoftDelete", trace.WithAttributes(attribute.String("driver.uuid", uid.String())))
+	err := d.inner.SoftDelete(ctx, uid, expectedVersion)
+	end(span, err)
+	return err
+}
+
+func (d *driverService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, span := tracer.Start(ctx, "DriverService.UnDelete", trace.WithAttributes(attribute.String("driver.uuid", uid.String())))
+	err := d.inner.UnDelete(ctx, uid, expectedVersion)
+	end(span, err)
+	return err
+}
+
+func (d *driverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, span := tracer.Start(ctx, "DriverService.HardDelete", trace.WithAttributes(attribute.String("driver.uuid", uid.String())))
+	err := d.inner.HardDelete(ctx, uid, expectedVersion)
+	end(span, err)
+	return err
+}
@@ -0,0 +1,49 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/aggregate"
+	"github.com/moura95/go-ddd/internal/domain/driver"
+	driverservice "github.com/moura95/go-ddd/internal/service/driver"
+	"github.com/moura95/go-ddd/internal/service/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// stubDriverService finds no driver and fails every hard delete.
+type stubDriverService struct {
+	driverservice.IDriverService
+}
+
+func (s stubDriverService) GetByID(ctx context.Context, uid uuid.UUID) (*aggregate.DriverVehicleAggregate, error) {
+	return nil, driver.ErrNotFound
+}
+
+func (s stubDriverService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	return errors.New("connection reset")
+}
+
+func TestDriverServiceSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	service := tracing.NewDriverService(stubDriverService{})
+
+	_, err := service.GetByID(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, driver.ErrNotFound)
+	assert.Error(t, service.HardDelete(context.Background(), uuid.New(), 0))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "DriverService.GetByID", spans[0].Name())
+	// a missing driver is an answer, not a failure
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "DriverService.HardDelete", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
+	"github.com/moura95/go-ddd/internal/service/vehicle"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type vehicleService struct {
+	inner vehicle.IVehicleService
+}
+
+func NewVehicleService(inner vehicle.IVehicleService) vehicle.IVehicleService {
+	return &vehicleService{inner: inner}
+}
+
+func (v *vehicleService) Create(ctx context.Context, input dto.CreateInput) (uuid.UUID, error) {
+	ctx, span := tracer.Start(ctx, "VehicleService.Create")
+	uid, err := v.inner.Create(ctx, input)
+	end(span, err)
+	return uid, err
+}
+
+func (v *vehicleService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	ctx, span := tracer.Start(ctx, "VehicleService.List")
+	vehicles, total, err := v.inner.List(ctx, filter)
+	end(span, err)
+	return vehicles, total, err
+}
+
+func (v *vehicleService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "VehicleService.GetByID", trace.WithAttributes(attribute.String("vehicle.uuid", uid.String())))
+	out, err := v.inner.GetByID(ctx, uid)
+	end(span, err)
+	return out, err
+}
+
+func (v *vehicleService) GetByLicensePlate(ctx context.Context, plate string) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "VehicleService.GetByLicensePlate")
+	out, err := v.inner.GetByLicensePlate(ctx, plate)
+	end(span, err)
+	return out, err
+}
+
+func (v *vehicleService) Update(ctx context.Context, input dto.UpdateInput) error {
+	ctx, span := tracer.Start(ctx, "VehicleService.Update", trace.WithAttributes(attribute.String("vehicle.uuid", input.Uuid.String())))
+	err := v.inner.Update(ctx, input)
+	end(span, err)
+	return err
+}
+
+func (v *vehicleService) Patch(ctx context.Context, input dto.PatchInput) (*dto.Output, error) {
+	ctx, span := tracer.Start(ctx, "VehicleService.Patch", trace.WithAttributes(attribute.String("vehicle.uuid", input.Uuid.String())))
+	out, err := v.inner.Patch(ctx, input)
+	end(span, err)
+	return out, err
+}
+
+func (v *vehicleService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, span := tracer.Start(ctx, "VehicleService.SoftDelete", trace.WithAttributes(attribute.String("vehicle.uuid", uid.String())))
+	err := v.inner.SoftDelete(ctx, uid, expectedVersion)
+	end(span, err)
+	return err
+}
+
+func (v *vehicleService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, span := tracer.Start(ctx, "VehicleService.UnDelete", trace.WithAttributes(attribute.String("vehicle.uuid", uid.String())))
+	err := v.inner.UnDelete(ctx, uid, expectedVersion)
+	end(span, err)
+	return err
+}
+
+func (v *vehicleService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, span := tracer.Start(ctx, "VehicleService.HardDelete", trace.WithAttributes(attribute.String("vehicle.uuid", uid.String())))
+	err := v.inner.HardDelete(ctx, uid, expectedVersion)
+	end(span, err)
+	return err
+}
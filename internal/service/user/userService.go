@@ -0,0 +1,219 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	domain "github.com/moura95/go-ddd/internal/domain/user"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+// IUserService manages accounts and their roles. Every call requires the
+// caller on ctx to hold user:manage, and only reaches users of the tenant on
+// ctx; users of other tenants are reported as not found.
+type IUserService interface {
+	Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error)
+	List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+	// GrantRole gives the user role; granting a role it has is a no-op.
+	GrantRole(ctx context.Context, uid uuid.UUID, role string) (*dto.Output, error)
+	// RevokeRole takes role from the user, refusing to take admin from the
+	// last active admin.
+	RevokeRole(ctx context.Context, uid uuid.UUID, role string) (*dto.Output, error)
+}
+
+type userService struct {
+	database   *sqlx.DB
+	uow        database.UnitOfWork
+	repository domain.IUserRepository
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewUserService(db *sqlx.DB, uow database.UnitOfWork, repo domain.IUserRepository, cfg cfg.Config, log *zap.SugaredLogger) *userService {
+	return &userService{
+		database:   db,
+		uow:        uow,
+		repository: repo,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+func (s *userService) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	if err := auth.Require(ctx, domain.PermUserManage); err != nil {
+		return nil, fmt.Errorf("failed to create user %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user %w", err)
+	}
+	u, err := domain.NewUser(input.Email, input.Password, input.Roles...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user %w", err)
+	}
+	u.TenantID = tenantID
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		return s.repository.Create(ctx, u)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user %w", err)
+	}
+	return newOutput(u), nil
+}
+
+func (s *userService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	if err := auth.Require(ctx, domain.PermUserManage); err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list users %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list users %w", err)
+	}
+	filter.Normalize()
+	filter.TenantID = tenantID
+	users, total, err := s.repository.GetAll(ctx, filter)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list users %w", err)
+	}
+	out := make([]dto.Output, 0, len(users))
+	for i := range users {
+		out = append(out, *newOutput(&users[i]))
+	}
+	return out, total, nil
+}
+
+func (s *userService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	if err := auth.Require(ctx, domain.PermUserManage); err != nil {
+		return nil, fmt.Errorf("failed to get user %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	u, err := s.getInTenant(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %w", err)
+	}
+	return newOutput(u), nil
+}
+
+func (s *userService) GrantRole(ctx context.Context, uid uuid.UUID, raw string) (*dto.Output, error) {
+	if err := auth.Require(ctx, domain.PermUserManage); err != nil {
+		return nil, fmt.Errorf("failed to grant role %w", err)
+	}
+	role, err := domain.NewRole(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant role %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	var u *domain.User
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.getInTenant(ctx, uid); err != nil {
+			return err
+		}
+		if err := s.repository.AddRole(ctx, uid, role, grantedBy(ctx)); err != nil {
+			return err
+		}
+		u, err = s.repository.GetByID(ctx, uid)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant role %w", err)
+	}
+	return newOutput(u), nil
+}
+
+func (s *userService) RevokeRole(ctx context.Context, uid uuid.UUID, raw string) (*dto.Output, error) {
+	if err := auth.Require(ctx, domain.PermUserManage); err != nil {
+		return nil, fmt.Errorf("failed to revoke role %w", err)
+	}
+	role, err := domain.NewRole(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role %w", err)
+	}
+	ctx, cancel := database.WithQueryTimeout(ctx, s.config.DBQueryTimeout)
+	defer cancel()
+
+	var u *domain.User
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		target, err := s.getInTenant(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if err := s.repository.RemoveRole(ctx, uid, role); err != nil {
+			return err
+		}
+		if role == domain.RoleAdmin {
+			// counted after the removal so the whole change rolls back
+			admins, err := s.repository.CountWithRole(ctx, target.TenantID, domain.RoleAdmin)
+			if err != nil {
+				return err
+			}
+			if admins == 0 {
+				return domain.ErrLastAdmin
+			}
+		}
+		u, err = s.repository.GetByID(ctx, uid)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role %w", err)
+	}
+	return newOutput(u), nil
+}
+
+// getInTenant loads the user, hiding users of other tenants behind
+// ErrNotFound so their existence does not leak.
+func (s *userService) getInTenant(ctx context.Context, uid uuid.UUID) (*domain.User, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.repository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if u.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	return u, nil
+}
+
+// grantedBy names the caller granting a role, for the role's history.
+func grantedBy(ctx context.Context) string {
+	if claims, ok := auth.ClaimsFrom(ctx); ok {
+		return claims.UserUUID.String()
+	}
+	return ""
+}
+
+func newOutput(u *domain.User) *dto.Output {
+	roles := make([]string, len(u.Roles))
+	for i, role := range u.Roles {
+		roles[i] = string(role)
+	}
+	return &dto.Output{
+		Uuid:      u.Uuid,
+		TenantID:  u.TenantID,
+		Email:     u.Email,
+		Roles:     roles,
+		Active:    u.Active,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moura95/go-ddd/internal/domain/tenant"
+	domain "github.com/moura95/go-ddd/internal/domain/user"
+	"github.com/moura95/go-ddd/internal/domain/user/memory"
+	dto "github.com/moura95/go-ddd/internal/dtos/user"
+	"github.com/moura95/go-ddd/internal/infra/auth"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"github.com/moura95/go-ddd/internal/service/user"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newUserService() (user.IUserService, context.Context) {
+	repo := memory.NewUserRepositoryMemory()
+	service := user.NewUserService(nil, database.NewMemoryUnitOfWork(repo), repo, cfg.Config{}, zap.NewNop().Sugar())
+	return service, principal(tenant.Default, domain.RoleAdmin)
+}
+
+// principal is a caller of tenantID holding role, as AuthMiddleware leaves it.
+func principal(tenantID uuid.UUID, role domain.Role) context.Context {
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{UserUUID: uuid.New(), TenantUUID: tenantID, Roles: []domain.Role{role}})
+	return tenant.WithID(ctx, tenantID)
+}
+
+func TestCreateUserNeedsUserManage(t *testing.T) {
+	service, admin := newUserService()
+	viewer := principal(tenant.Default, domain.RoleViewer)
+
+	_, err := service.Create(viewer, dto.CreateInput{Email: "ops@example.com", Password: "correct horse"})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	out, err := service.Create(admin, dto.CreateInput{Email: "ops@example.com", Password: "correct horse", Roles: []string{"dispatcher"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dispatcher"}, out.Roles)
+
+	_, err = service.Create(admin, dto.CreateInput{Email: "root@example.com", Password: "correct horse", Roles: []string{"root"}})
+	assert.ErrorIs(t, err, domain.ErrUnknownRole)
+}
+
+func TestGrantAndRevokeRole(t *testing.T) {
+	service, admin := newUserService()
+	out, err := service.Create(admin, dto.CreateInput{Email: "ops@example.com", Password: "correct horse", Roles: []string{"viewer"}})
+	assert.NoError(t, err)
+
+	out, err = service.GrantRole(admin, out.Uuid, "dispatcher")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"viewer", "dispatcher"}, out.Roles)
+	_, err = service.GrantRole(admin, out.Uuid, "dispatcher")
+	assert.NoError(t, err)
+
+	out, err = service.RevokeRole(admin, out.Uuid, "viewer")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dispatcher"}, out.Roles)
+
+	_, err = service.GrantRole(admin, uuid.New(), "viewer")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRevokeRoleKeepsTheLastAdmin(t *testing.T) {
+	service, admin := newUserService()
+	first, err := service.Create(admin, dto.CreateInput{Email: "first@example.com", Password: "correct horse", Roles: []string{"admin"}})
+	assert.NoError(t, err)
+	second, err := service.Create(admin, dto.CreateInput{Email: "second@example.com", Password: "correct horse", Roles: []string{"admin"}})
+	assert.NoError(t, err)
+
+	_, err = service.RevokeRole(admin, first.Uuid, "admin")
+	assert.NoError(t, err)
+	_, err = service.RevokeRole(admin, second.Uuid, "admin")
+	assert.ErrorIs(t, err, domain.ErrLastAdmin)
+
+	out, err := service.GetByID(admin, second.Uuid)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, out.Roles)
+}
+
+func TestUsersStayInTheirTenant(t *testing.T) {
+	service, admin := newUserService()
+	other := principal(uuid.New(), domain.RoleAdmin)
+	out, err := service.Create(admin, dto.CreateInput{Email: "ops@example.com", Password: "correct horse", Roles: []string{"viewer"}})
+	assert.NoError(t, err)
+	assert.Equal(t, tenant.Default, out.TenantID)
+
+	_, err = service.GetByID(other, out.Uuid)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = service.GrantRole(other, out.Uuid, "admin")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	users, total, err := service.List(other, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Zero(t, total)
+
+	users, total, err = service.List(admin, dto.ListInput{})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, 1, total)
+}
@@ -1,102 +1,299 @@
 package vehicle
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/moura95/go-ddd/internal/domain/vehicle"
+	"github.com/moura95/go-ddd/internal/domain/event"
+	domain "github.com/moura95/go-ddd/internal/domain/vehicle"
 	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
 	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
 	"go.uber.org/zap"
 )
 
 type IVehicleService interface {
-	Create(vehicle dto.CreateInput) error
-	List() ([]dto.Output, error)
-	GetByID(uid uuid.UUID) (*dto.Output, error)
-	Update(vehicle dto.UpdateInput) error
-	SoftDelete(uid uuid.UUID) error
-	UnDelete(uid uuid.UUID) error
-	HardDelete(uid uuid.UUID) error
+	Create(ctx context.Context, vehicle dto.CreateInput) (uuid.UUID, error)
+	List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+	GetByLicensePlate(ctx context.Context, plate string) (*dto.Output, error)
+	Update(ctx context.Context, vehicle dto.UpdateInput) error
+	Patch(ctx context.Context, input dto.PatchInput) (*dto.Output, error)
+	SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
+	HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error
 }
 
 type vehicleService struct {
 	database   *sqlx.DB
-	repository vehicle.IVehicleRepository
+	uow        database.UnitOfWork
+	repository domain.IVehicleRepository
+	outbox     event.IOutboxRepository
 	config     cfg.Config
 	logger     *zap.SugaredLogger
 }
 
-func NewVehicleService(db *sqlx.DB, repo vehicle.IVehicleRepository, cfg cfg.Config, log *zap.SugaredLogger) *vehicleService {
+func NewVehicleService(db *sqlx.DB, uow database.UnitOfWork, repo domain.IVehicleRepository, outbox event.IOutboxRepository, cfg cfg.Config, log *zap.SugaredLogger) *vehicleService {
 	return &vehicleService{
 		database:   db,
+		uow:        uow,
 		repository: repo,
+		outbox:     outbox,
 		config:     cfg,
 		logger:     log,
 	}
 }
 
-func (v *vehicleService) Create(vehicle dto.CreateInput) error {
-	err := v.repository.Create(vehicle)
+func (v *vehicleService) Create(ctx context.Context, vehicle dto.CreateInput) (uuid.UUID, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	err := v.ensurePlateAvailable(ctx, vehicle.LicensePlate, uuid.Nil)
 	if err != nil {
-		return fmt.Errorf("failed to create %s", err.Error())
+		return uuid.Nil, fmt.Errorf("failed to create %w", err)
 	}
-	return nil
+
+	var uid uuid.UUID
+	err = v.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if uid, err = v.repository.Create(ctx, vehicle); err != nil {
+			return err
+		}
+		return v.raise(ctx, uid, domain.EventCreated)
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create %w", err)
+	}
+	return uid, nil
 }
 
-func (v *vehicleService) List() ([]dto.Output, error) {
-	vehicles, err := v.repository.GetAll()
+func (v *vehicleService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	filter.Normalize()
+	vehicles, total, err := v.repository.GetAll(ctx, filter)
 	if err != nil {
-		return []dto.Output{}, fmt.Errorf("failed to get %s", err.Error())
+		return []dto.Output{}, 0, fmt.Errorf("failed to get %w", err)
 
 	}
-	return vehicles, nil
+	return vehicles, total, nil
 }
 
-func (v *vehicleService) GetByID(uid uuid.UUID) (*dto.Output, error) {
-	ve, err := v.repository.GetByID(uid)
+func (v *vehicleService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	ve, err := v.repository.GetByID(ctx, uid)
 	if err != nil {
-		return &dto.Output{}, fmt.Errorf("failed to get %s", err.Error())
+		return nil, fmt.Errorf("failed to get %w", err)
 
 	}
 	return ve, nil
 
 }
 
-func (v *vehicleService) Update(vehicle dto.UpdateInput) error {
-	err := v.repository.Update(&vehicle)
+// GetByLicensePlate finds a vehicle by plate in either format, so a vehicle
+// registered as ABC1234 is also found when looked up as ABC1C34.
+func (v *vehicleService) GetByLicensePlate(ctx context.Context, plate string) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	p, err := domain.NewLicensePlate(plate)
+	if err != nil {
+		return nil, err
+	}
+	ve, err := v.repository.GetByLicensePlate(ctx, plateStrings(p.Equivalents()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %w", err)
+	}
+	return ve, nil
+}
+
+func (v *vehicleService) Update(ctx context.Context, vehicle dto.UpdateInput) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	err := v.ensurePlateAvailable(ctx, vehicle.LicensePlate, vehicle.Uuid)
 	if err != nil {
-		return fmt.Errorf("failed to update %s", err.Error())
+		return fmt.Errorf("failed to update %w", err)
+	}
+
+	err = v.uow.Do(ctx, func(ctx context.Context) error {
+		if err := v.repository.Update(ctx, &vehicle); err != nil {
+			return err
+		}
+		return v.raise(ctx, vehicle.Uuid, domain.EventUpdated)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %w", err)
 	}
 	return nil
 }
 
-func (v *vehicleService) SoftDelete(uid uuid.UUID) error {
-	err := v.repository.SoftDelete(uid)
+// Patch merges input into the stored vehicle and runs the same checks as
+// Update on the merged result. The write is pinned to the version that was
+// read when the caller did not supply one.
+func (v *vehicleService) Patch(ctx context.Context, input dto.PatchInput) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	var patched *dto.Output
+	err := v.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := v.repository.GetByID(ctx, input.Uuid)
+		if err != nil {
+			return err
+		}
+		expected := input.ExpectedVersion
+		if expected == 0 {
+			expected = current.Version
+		}
+		merged := dto.UpdateInput{
+			Uuid:              current.Uuid,
+			Brand:             input.Brand.Merge(current.Brand),
+			Model:             input.Model.Merge(current.Model),
+			YearOfManufacture: input.YearOfManufacture.Merge(current.YearOfManufacture),
+			LicensePlate:      input.LicensePlate.Merge(current.LicensePlate),
+			Renavam:           input.Renavam.Merge(current.Renavam.String),
+			Category:          input.Category.Merge(current.Category.String),
+			Color:             input.Color.Merge(current.Color),
+			ExpectedVersion:   expected,
+		}
+		if err := v.ensurePlateAvailable(ctx, merged.LicensePlate, merged.Uuid); err != nil {
+			return err
+		}
+		if err := v.repository.Update(ctx, &merged); err != nil {
+			return err
+		}
+		patched, err = v.repository.GetByID(ctx, merged.Uuid)
+		if err != nil {
+			return err
+		}
+		ve := toVehicle(patched)
+		ve.Raise(domain.EventUpdated)
+		return v.outbox.Append(ctx, ve.PullEvents()...)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete")
+		return nil, fmt.Errorf("failed to patch %w", err)
+	}
+	return patched, nil
+}
+
+func (v *vehicleService) SoftDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	err := v.uow.Do(ctx, func(ctx context.Context) error {
+		if err := v.repository.SoftDelete(ctx, uid, expectedVersion); err != nil {
+			return err
+		}
+		return v.raise(ctx, uid, domain.EventSoftDeleted)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %w", err)
 	}
 	return nil
 }
-func (v *vehicleService) UnDelete(uid uuid.UUID) error {
-	err := v.repository.UnDelete(uid)
+func (v *vehicleService) UnDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	err := v.uow.Do(ctx, func(ctx context.Context) error {
+		if err := v.repository.UnDelete(ctx, uid, expectedVersion); err != nil {
+			return err
+		}
+		return v.raise(ctx, uid, domain.EventRestored)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to un delete %s", err.Error())
+		return fmt.Errorf("failed to un delete %w", err)
 	}
 	return nil
 }
 
-func (v *vehicleService) HardDelete(uid uuid.UUID) error {
-	// unRelate driver before delete
-	err := v.repository.UnRelate(uid)
+func (v *vehicleService) HardDelete(ctx context.Context, uid uuid.UUID, expectedVersion int) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, v.config.DBQueryTimeout)
+	defer cancel()
+
+	err := v.uow.Do(ctx, func(ctx context.Context) error {
+		// the event describes the vehicle as it was before it was removed
+		out, err := v.repository.GetByID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		// unRelate driver before delete
+		if err := v.repository.UnRelate(ctx, uid); err != nil {
+			return err
+		}
+		if err := v.repository.HardDelete(ctx, uid, expectedVersion); err != nil {
+			return err
+		}
+		deleted := toVehicle(out)
+		deleted.Raise(domain.EventDeleted)
+		return v.outbox.Append(ctx, deleted.PullEvents()...)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete %s", err.Error())
+		return fmt.Errorf("failed to delete %w", err)
 	}
+	return nil
+}
 
-	err = v.repository.HardDelete(uid)
+// ensurePlateAvailable rejects a plate that is already registered, in either
+// format, to a vehicle other than self. Malformed plates are left for the
+// domain constructor to report alongside the other invalid fields.
+func (v *vehicleService) ensurePlateAvailable(ctx context.Context, plate string, self uuid.UUID) error {
+	p, err := domain.NewLicensePlate(plate)
 	if err != nil {
-		return fmt.Errorf("failed to  delete %s", err.Error())
+		return nil
+	}
+	existing, err := v.repository.GetByLicensePlate(ctx, plateStrings(p.Equivalents()))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.Uuid != self {
+		return domain.ErrLicensePlateTaken
 	}
 	return nil
 }
+
+// raise loads the vehicle after a change, lets it raise name and writes the
+// event to the outbox in the caller's unit of work.
+func (v *vehicleService) raise(ctx context.Context, uid uuid.UUID, name string) error {
+	out, err := v.repository.GetByID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	ve := toVehicle(out)
+	ve.Raise(name)
+	return v.outbox.Append(ctx, ve.PullEvents()...)
+}
+
+func toVehicle(out *dto.Output) *domain.Vehicle {
+	return &domain.Vehicle{
+		Uuid:              out.Uuid,
+		Brand:             out.Brand,
+		Model:             out.Model,
+		YearOfManufacture: out.YearOfManufacture,
+		LicensePlate:      out.LicensePlate,
+		Renavam:           out.Renavam,
+		Category:          out.Category,
+		Color:             out.Color,
+		DeletedAt:         out.DeletedAt,
+		Version:           out.Version,
+		CreatedAt:         out.CreatedAt,
+		UpdatedAt:         out.UpdatedAt,
+	}
+}
+
+func plateStrings(plates []domain.LicensePlate) []string {
+	out := make([]string, len(plates))
+	for i, p := range plates {
+		out[i] = p.String()
+	}
+	return out
+}
@@ -1,11 +1,14 @@
 package vehicle
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/moura95/go-ddd/internal/domain/vehicle"
 	"github.com/moura95/go-ddd/internal/domain/vehicle/memory"
+	"github.com/moura95/go-ddd/internal/dtos/pagination"
+	dto "github.com/moura95/go-ddd/internal/dtos/vehicle"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,7 +35,7 @@ func TestCreateVehicle(t *testing.T) {
 		Color:             "Blue",
 	}
 
-	err := service.repository.Create(ve)
+	err := service.repository.Create(context.Background(), ve)
 	if err != nil {
 		t.Error("Failed to created")
 	}
@@ -45,11 +48,12 @@ func TestGetAllVehicles(t *testing.T) {
 
 	service := NewVehicleServiceTest(mockRepo)
 
-	vehicles, err := service.repository.GetAll()
+	vehicles, total, err := service.repository.GetAll(context.Background(), dto.ListInput{})
 	if err != nil {
 		t.Error("Failed to created")
 	}
 	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
 	assert.Equal(t, vehicles[0].Uuid, uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9"))
 	assert.Equal(t, vehicles[0].Brand, "Scania")
 	assert.Equal(t, vehicles[0].Model, "R500")
@@ -66,11 +70,66 @@ func TestGetAllVehicles(t *testing.T) {
 	assert.Equal(t, vehicles[1].Color, "Black")
 }
 
+func TestGetAllVehiclesFilters(t *testing.T) {
+	mockRepo := memory.NewVehicleRepositoryMemory()
+	service := NewVehicleServiceTest(mockRepo)
+
+	vehicles, total, err := service.repository.GetAll(context.Background(), dto.ListInput{Brand: "scan"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "R500", vehicles[0].Model)
+
+	vehicles, total, err = service.repository.GetAll(context.Background(), dto.ListInput{YearFrom: 2015, YearTo: 2019})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "FH16", vehicles[0].Model)
+
+	vehicles, total, err = service.repository.GetAll(context.Background(), dto.ListInput{LicensePlatePrefix: "xyz", Color: "black"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Volvo", vehicles[0].Brand)
+
+	_, total, err = service.repository.GetAll(context.Background(), dto.ListInput{Color: "Green"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	// prefixes are spelled like stored plates, and wildcards are literal
+	vehicles, total, err = service.repository.GetAll(context.Background(), dto.ListInput{LicensePlatePrefix: "abc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Scania", vehicles[0].Brand)
+	for _, prefix := range []string{"A_C", "%", "_"} {
+		_, total, err = service.repository.GetAll(context.Background(), dto.ListInput{LicensePlatePrefix: prefix})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, total, prefix)
+	}
+}
+
+func TestGetAllVehiclesSortAndPage(t *testing.T) {
+	mockRepo := memory.NewVehicleRepositoryMemory()
+	service := NewVehicleServiceTest(mockRepo)
+
+	vehicles, total, err := service.repository.GetAll(context.Background(), dto.ListInput{
+		Input: pagination.Input{Page: 1, PageSize: 1, SortBy: dto.SortByYearOfManufacture},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, vehicles, 1)
+	assert.Equal(t, uint(2019), vehicles[0].YearOfManufacture)
+
+	vehicles, _, err = service.repository.GetAll(context.Background(), dto.ListInput{
+		Input: pagination.Input{SortBy: dto.SortByBrand, SortOrder: pagination.OrderDesc},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Volvo", vehicles[0].Brand)
+	assert.Equal(t, "Scania", vehicles[1].Brand)
+}
+
 func TestGetVehicleID(t *testing.T) {
 	mockRepo := memory.NewVehicleRepositoryMemory()
 	service := NewVehicleServiceTest(mockRepo)
 
-	vehicle, err := service.repository.GetByID(uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9"))
+	vehicle, err := service.repository.GetByID(context.Background(), uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9"))
 	if err != nil {
 		t.Error("Failed to get")
 	}
@@ -85,6 +144,26 @@ func TestGetVehicleID(t *testing.T) {
 
 }
 
+func TestGetVehicleByEquivalentPlate(t *testing.T) {
+	mockRepo := memory.NewVehicleRepositoryMemory()
+	service := NewVehicleServiceTest(mockRepo)
+
+	ve, err := vehicle.NewVehicle("Volvo", "FH16", "abc-1234", "Blue", "", "", 2021)
+	assert.NoError(t, err)
+	assert.NoError(t, service.repository.Create(context.Background(), *ve))
+
+	plate, err := vehicle.NewLicensePlate("ABC1C34")
+	assert.NoError(t, err)
+
+	found, err := service.repository.GetByLicensePlate(context.Background(), plateStrings(plate.Equivalents()))
+	assert.NoError(t, err)
+	assert.Equal(t, ve.Uuid, found.Uuid)
+	assert.Equal(t, "ABC1234", found.LicensePlate)
+
+	_, err = service.repository.GetByLicensePlate(context.Background(), []string{"ZZZ9Z99"})
+	assert.ErrorIs(t, err, vehicle.ErrNotFound)
+}
+
 func TestUpdateVehicle(t *testing.T) {
 	mockRepo := memory.NewVehicleRepositoryMemory()
 	service := NewVehicleServiceTest(mockRepo)
@@ -100,7 +179,7 @@ func TestUpdateVehicle(t *testing.T) {
 		Color:             "RED",
 	}
 
-	err := service.repository.Update(d)
+	err := service.repository.Update(context.Background(), d)
 	if err != nil {
 		t.Error("Failed to update")
 	}
@@ -112,7 +191,7 @@ func TestVehicleHardDelete(t *testing.T) {
 	service := NewVehicleServiceTest(mockRepo)
 
 	uid := uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9")
-	err := service.repository.HardDelete(uid)
+	err := service.repository.HardDelete(context.Background(), uid, 0)
 	if err != nil {
 		t.Error("Failed to delete")
 	}
@@ -124,7 +203,7 @@ func TestVehicleSoftDelete(t *testing.T) {
 	service := NewVehicleServiceTest(mockRepo)
 
 	uid := uuid.MustParse("43ee3d4c-de06-4021-ab6f-ba8113418df9")
-	err := service.repository.SoftDelete(uid)
+	err := service.repository.SoftDelete(context.Background(), uid, 0)
 	if err != nil {
 		t.Error("Failed to delete")
 	}
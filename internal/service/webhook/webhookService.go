@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	domain "github.com/moura95/go-ddd/internal/domain/webhook"
+	eventdto "github.com/moura95/go-ddd/internal/dtos/event"
+	dto "github.com/moura95/go-ddd/internal/dtos/webhook"
+	"github.com/moura95/go-ddd/internal/infra/cfg"
+	"github.com/moura95/go-ddd/internal/infra/database"
+	"go.uber.org/zap"
+)
+
+type IWebhookService interface {
+	Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error)
+	List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error)
+	GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error)
+	Update(ctx context.Context, input dto.UpdateInput) (*dto.Output, error)
+	Delete(ctx context.Context, uid uuid.UUID) error
+	ListDeliveries(ctx context.Context, uid uuid.UUID, filter dto.DeliveryListInput) ([]dto.DeliveryOutput, int, error)
+	Redeliver(ctx context.Context, uid, deliveryUUID uuid.UUID) (*dto.DeliveryOutput, error)
+	// Enqueue queues a delivery of msg for every matching subscription of the
+	// tenant msg belongs to. It is subscribed to the outbox bus, so it runs in
+	// the relay's transaction.
+	Enqueue(ctx context.Context, msg eventdto.Message) error
+}
+
+type webhookService struct {
+	database   *sqlx.DB
+	uow        database.UnitOfWork
+	repository domain.IWebhookRepository
+	config     cfg.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewWebhookService(db *sqlx.DB, uow database.UnitOfWork, repo domain.IWebhookRepository, cfg cfg.Config, log *zap.SugaredLogger) *webhookService {
+	return &webhookService{
+		database:   db,
+		uow:        uow,
+		repository: repo,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+func (w *webhookService) Create(ctx context.Context, input dto.CreateInput) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	out, err := w.repository.Create(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook %w", err)
+	}
+	return out, nil
+}
+
+func (w *webhookService) List(ctx context.Context, filter dto.ListInput) ([]dto.Output, int, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	filter.Normalize()
+	subscriptions, total, err := w.repository.GetAll(ctx, filter)
+	if err != nil {
+		return []dto.Output{}, 0, fmt.Errorf("failed to list webhooks %w", err)
+	}
+	return subscriptions, total, nil
+}
+
+func (w *webhookService) GetByID(ctx context.Context, uid uuid.UUID) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	out, err := w.repository.GetByID(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook %w", err)
+	}
+	return out, nil
+}
+
+func (w *webhookService) Update(ctx context.Context, input dto.UpdateInput) (*dto.Output, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	var out *dto.Output
+	err := w.uow.Do(ctx, func(ctx context.Context) error {
+		if err := w.repository.Update(ctx, input); err != nil {
+			return err
+		}
+		var err error
+		out, err = w.repository.GetByID(ctx, input.Uuid)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook %w", err)
+	}
+	return out, nil
+}
+
+func (w *webhookService) Delete(ctx context.Context, uid uuid.UUID) error {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	if err := w.repository.Delete(ctx, uid); err != nil {
+		return fmt.Errorf("failed to delete webhook %w", err)
+	}
+	return nil
+}
+
+func (w *webhookService) ListDeliveries(ctx context.Context, uid uuid.UUID, filter dto.DeliveryListInput) ([]dto.DeliveryOutput, int, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	filter.Normalize()
+	// an unknown subscription is a 404 rather than an empty log
+	if _, err := w.repository.GetByID(ctx, uid); err != nil {
+		return []dto.DeliveryOutput{}, 0, fmt.Errorf("failed to list webhook deliveries %w", err)
+	}
+	deliveries, total, err := w.repository.ListDeliveries(ctx, uid, filter)
+	if err != nil {
+		return []dto.DeliveryOutput{}, 0, fmt.Errorf("failed to list webhook deliveries %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// Redeliver sends a dead or delivered message again with a fresh retry
+// schedule.
+func (w *webhookService) Redeliver(ctx context.Context, uid, deliveryUUID uuid.UUID) (*dto.DeliveryOutput, error) {
+	ctx, cancel := database.WithQueryTimeout(ctx, w.config.DBQueryTimeout)
+	defer cancel()
+
+	var out *dto.DeliveryOutput
+	err := w.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := w.repository.GetDelivery(ctx, uid, deliveryUUID)
+		if err != nil {
+			return err
+		}
+		d := domain.DeliveryFrom(*current)
+		if err := d.Redeliver(time.Now()); err != nil {
+			return err
+		}
+		if err := w.repository.SaveDelivery(ctx, d); err != nil {
+			return err
+		}
+		out, err = w.repository.GetDelivery(ctx, uid, deliveryUUID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook %w", err)
+	}
+	return out, nil
+}
+
+// envelope is the body POSTed to subscribers.
+type envelope struct {
+	ID            uuid.UUID       `json:"id"`
+	Event         string          `json:"event"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateUUID uuid.UUID       `json:"aggregate_uuid"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func (w *webhookService) Enqueue(ctx context.Context, msg eventdto.Message) error {
+	subscriptions, err := w.repository.ListSubscribed(ctx, msg.TenantID, msg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhooks %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	// the body is built once so every retry sends, and signs, the same bytes
+	body, err := json.Marshal(envelope{
+		ID:            msg.Uuid,
+		Event:         msg.Name,
+		AggregateType: msg.AggregateType,
+		AggregateUUID: msg.AggregateUUID,
+		OccurredAt:    msg.OccurredAt,
+		Data:          msg.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhooks %w", err)
+	}
+	for _, s := range subscriptions {
+		d := domain.NewDelivery(s.TenantID, s.Uuid, msg.Uuid, msg.Name, body)
+		if err := w.repository.CreateDelivery(ctx, d); err != nil {
+			return fmt.Errorf("failed to enqueue webhooks %w", err)
+		}
+	}
+	return nil
+}